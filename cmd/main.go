@@ -1,15 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"os"
+	"strconv"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/DioSaputra28/belajar-gin-1/config"
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
 	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
 	"github.com/DioSaputra28/belajar-gin-1/internal/auth"
+	"github.com/DioSaputra28/belajar-gin-1/internal/avatars"
+	"github.com/DioSaputra28/belajar-gin-1/internal/bulk"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/blob"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
 	"github.com/DioSaputra28/belajar-gin-1/internal/common/middleware"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contactcsv"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/duplicates"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/relationships"
+	"github.com/DioSaputra28/belajar-gin-1/internal/reminders"
+	"github.com/DioSaputra28/belajar-gin-1/internal/search"
+	"github.com/DioSaputra28/belajar-gin-1/internal/sharing"
+	"github.com/DioSaputra28/belajar-gin-1/internal/trash"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/vcard"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 	"github.com/gin-gonic/gin"
 )
 
@@ -42,6 +64,19 @@ func main() {
 	router.POST("/auth/login", authHandler.Login)
 	router.GET("/me", middleware.AuthMiddleware(authRepo), authHandler.Me)
 
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "storage"
+	}
+	blobStore := blob.NewFileStore(storageDir)
+
+	avatarRepo := avatars.NewAvatarRepository(db)
+	avatarSvc := avatars.NewAvatarService(avatarRepo, blobStore)
+	avatarHandler := avatars.NewAvatarHandler(avatarSvc)
+
+	router.PUT("/me/avatar", middleware.AuthMiddleware(authRepo), avatarHandler.UploadMyAvatar)
+	router.DELETE("/me/avatar", middleware.AuthMiddleware(authRepo), avatarHandler.DeleteMyAvatar)
+
 	userRepo := users.NewUserRepository(db)
 	userSvc := users.NewUserService(userRepo)
 	userHandler := users.NewUserHandler(userSvc)
@@ -53,37 +88,253 @@ func main() {
 		userAuth.POST("", userHandler.CreateUser)
 		userAuth.PUT("/:id", userHandler.UpdateUser)
 		userAuth.GET("/:id", userHandler.FindUserById)
+		userAuth.GET("/:id/avatar", avatarHandler.GetUserAvatar)
 		userAuth.DELETE("/:id", userHandler.DeleteUser)
 	}
 
+	workspaceRepo := workspaces.NewWorkspaceRepository(db)
+	workspaceSvc := workspaces.NewWorkspaceService(workspaceRepo)
+	workspaceHandler := workspaces.NewWorkspaceHandler(workspaceSvc)
+
+	workspaceAuth := router.Group("/workspaces")
+	workspaceAuth.Use(middleware.AuthMiddleware(authRepo))
+	{
+		workspaceAuth.GET("", workspaceHandler.GetWorkspaces)
+		workspaceAuth.POST("", workspaceHandler.CreateWorkspace)
+		workspaceAuth.PUT("/active", workspaceHandler.SwitchWorkspace)
+		workspaceAuth.PUT("/:id", workspaceHandler.UpdateWorkspace)
+		workspaceAuth.GET("/:id", workspaceHandler.FindWorkspaceById)
+		workspaceAuth.GET("/:id/members", workspaceHandler.GetMembers)
+		workspaceAuth.PUT("/:id/members/:user_id", workspaceHandler.UpdateMember)
+		workspaceAuth.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMember)
+		workspaceAuth.GET("/:id/invitations", workspaceHandler.GetInvitations)
+		workspaceAuth.POST("/:id/invitations", workspaceHandler.CreateInvitation)
+		workspaceAuth.DELETE("/:id/invitations/:invitation_id", workspaceHandler.DeleteInvitation)
+	}
+
+	invitationAuth := router.Group("/invitations")
+	invitationAuth.Use(middleware.AuthMiddleware(authRepo))
+	{
+		invitationAuth.GET("", workspaceHandler.GetMyInvitations)
+		invitationAuth.POST("/:token/accept", workspaceHandler.AcceptInvitation)
+	}
+
 	contactRepo := contacts.NewContactRepository(db)
 	contactSvc := contacts.NewContactService(contactRepo)
-	contactHandler := contacts.NewContactHandler(contactSvc)
+
+	// Contacts stored before phone_e164 existed get it filled in once, in
+	// the background, so the digit search finds them too.
+	go func() {
+		filled, err := contactSvc.BackfillPhoneE164()
+		if err != nil {
+			log.Printf("contacts: backfilling phone_e164 failed: %v", err)
+		}
+		if filled > 0 {
+			log.Printf("contacts: backfilled phone_e164 of %d contacts", filled)
+		}
+	}()
+
+	addressDuplicates, err := addresses.ParseDuplicatePolicy(os.Getenv("ADDRESS_DUPLICATES"))
+	if err != nil {
+		panic(err)
+	}
+
+	geocoder := geo.NewOfflineGeocoder()
+
+	addressRepo := addresses.NewAddressRepository(db)
+	addressSvc := addresses.NewAddressService(addressRepo, geocoder, addressDuplicates)
+	addressHandler := addresses.NewAddressHandler(addressSvc)
+
+	// Addresses stored before they were geocoded on save are located once,
+	// in the background.
+	go func() {
+		located, err := addressSvc.BackfillCoordinates()
+		if err != nil {
+			log.Printf("addresses: backfilling coordinates failed: %v", err)
+		}
+		if located > 0 {
+			log.Printf("addresses: backfilled coordinates of %d addresses", located)
+		}
+	}()
+
+	contactHandler := contacts.NewContactHandler(contactSvc, map[string]contacts.IncludeFunc{
+		"addresses": addressSvc.IncludeAddresses,
+	})
+
+	vcardRepo := vcard.NewVCardRepository(db)
+	vcardSvc := vcard.NewVCardService(vcardRepo, geocoder)
+	vcardHandler := vcard.NewVCardHandler(vcardSvc)
+
+	contactCSVRepo := contactcsv.NewContactCSVRepository(db)
+	contactCSVSvc := contactcsv.NewContactCSVService(contactCSVRepo, geocoder)
+	contactCSVHandler := contactcsv.NewContactCSVHandler(contactCSVSvc)
+
+	duplicateRepo := duplicates.NewDuplicateRepository(db)
+	duplicateSvc := duplicates.NewDuplicateService(duplicateRepo)
+	duplicateHandler := duplicates.NewDuplicateHandler(duplicateSvc)
+
+	activityRepo := activities.NewActivityRepository(db)
+	activitySvc := activities.NewActivityService(activityRepo)
+	activityHandler := activities.NewActivityHandler(activitySvc)
+
+	shareRepo := sharing.NewShareRepository(db)
+	shareSvc := sharing.NewShareService(shareRepo)
+	shareHandler := sharing.NewShareHandler(shareSvc)
+
+	relationshipRepo := relationships.NewRelationshipRepository(db)
+	relationshipSvc := relationships.NewRelationshipService(relationshipRepo)
+	relationshipHandler := relationships.NewRelationshipHandler(relationshipSvc)
+
+	trashRetention := trash.DefaultRetention
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		trashRetention = time.Duration(days) * 24 * time.Hour
+	}
+
+	bulkRepo := bulk.NewBulkRepository(db, avatarSvc, trashRetention)
+	bulkSvc := bulk.NewBulkService(bulkRepo, contactSvc)
+	bulkHandler := bulk.NewBulkHandler(bulkSvc)
 
 	contactAuth := router.Group("/contacts")
-	contactAuth.Use(middleware.AuthMiddleware(authRepo))
+	contactAuth.Use(middleware.AuthMiddleware(authRepo), middleware.WorkspaceMiddleware(workspaceSvc))
 	{
 		contactAuth.GET("", contactHandler.GetContacts)
 		contactAuth.POST("", contactHandler.CreateContact)
 		contactAuth.PUT("/:id", contactHandler.UpdateContact)
 		contactAuth.GET("/:id", contactHandler.FindContactById)
 		contactAuth.DELETE("/:id", contactHandler.DeleteContact)
+		contactAuth.GET("/:id/addresses", addressHandler.GetAddresses)
+		contactAuth.POST("/:id/addresses", addressHandler.CreateAddress)
+		contactAuth.GET("/:id/addresses/:address_id", addressHandler.FindAddressById)
+		contactAuth.PUT("/:id/addresses/:address_id", addressHandler.UpdateAddress)
+		contactAuth.DELETE("/:id/addresses/:address_id", addressHandler.DeleteAddress)
+
+		contactAuth.POST("/bulk", bulkHandler.Run)
+		contactAuth.GET("/export.vcf", vcardHandler.ExportContacts)
+		contactAuth.POST("/import", vcardHandler.ImportContacts)
+		contactAuth.GET("/export.csv", contactCSVHandler.ExportContacts)
+		contactAuth.POST("/import.csv", contactCSVHandler.ImportContacts)
+		contactAuth.GET("/sort-preference", contactHandler.GetSortPreference)
+		contactAuth.PUT("/sort-preference", contactHandler.UpdateSortPreference)
+		contactAuth.GET("/duplicates", duplicateHandler.FindDuplicates)
+		contactAuth.POST("/merge", duplicateHandler.MergeContacts)
+		contactAuth.GET("/:id/vcard", vcardHandler.ExportContact)
+		contactAuth.GET("/:id/avatar", avatarHandler.GetContactAvatar)
+		contactAuth.PUT("/:id/avatar", avatarHandler.UploadContactAvatar)
+		contactAuth.DELETE("/:id/avatar", avatarHandler.DeleteContactAvatar)
+		contactAuth.PUT("/:id/favorite", contactHandler.FavoriteContact)
+		contactAuth.DELETE("/:id/favorite", contactHandler.UnfavoriteContact)
+		contactAuth.GET("/:id/history", contactHandler.GetHistory)
+		contactAuth.POST("/:id/history/:revision_id/restore", contactHandler.RestoreContact)
+		contactAuth.GET("/:id/activities", activityHandler.GetActivities)
+		contactAuth.POST("/:id/activities", activityHandler.CreateActivity)
+		contactAuth.PUT("/:id/activities/:activity_id", activityHandler.UpdateActivity)
+		contactAuth.DELETE("/:id/activities/:activity_id", activityHandler.DeleteActivity)
+		contactAuth.POST("/:id/relationships", relationshipHandler.CreateRelationship)
+		contactAuth.DELETE("/:id/relationships/:relationship_id", relationshipHandler.DeleteRelationship)
+		contactAuth.GET("/:id/related", relationshipHandler.GetRelated)
+		contactAuth.GET("/:id/shares", shareHandler.GetContactShares)
+		contactAuth.POST("/:id/shares", shareHandler.ShareContact)
 	}
 
-	addressRepo := addresses.NewAddressRepository(db)
-	addressSvc := addresses.NewAddressService(addressRepo)
-	addressHandler := addresses.NewAddressHandler(addressSvc)
+	groupRepo := groups.NewGroupRepository(db)
+	groupSvc := groups.NewGroupService(groupRepo)
+	groupHandler := groups.NewGroupHandler(groupSvc)
+
+	groupAuth := router.Group("/groups")
+	groupAuth.Use(middleware.AuthMiddleware(authRepo), middleware.WorkspaceMiddleware(workspaceSvc))
+	{
+		groupAuth.GET("", groupHandler.GetGroups)
+		groupAuth.POST("", groupHandler.CreateGroup)
+		groupAuth.PUT("/:id", groupHandler.UpdateGroup)
+		groupAuth.GET("/:id", groupHandler.FindGroupById)
+		groupAuth.DELETE("/:id", groupHandler.DeleteGroup)
+		groupAuth.POST("/:id/contacts", groupHandler.AddContacts)
+		groupAuth.DELETE("/:id/contacts", groupHandler.RemoveContacts)
+		groupAuth.GET("/:id/shares", shareHandler.GetGroupShares)
+		groupAuth.POST("/:id/shares", shareHandler.ShareGroup)
+	}
+
+	companyRepo := companies.NewCompanyRepository(db)
+	companySvc := companies.NewCompanyService(companyRepo)
+	companyHandler := companies.NewCompanyHandler(companySvc)
+
+	companyAuth := router.Group("/companies")
+	companyAuth.Use(middleware.AuthMiddleware(authRepo))
+	{
+		companyAuth.GET("", companyHandler.GetCompanies)
+		companyAuth.POST("", companyHandler.CreateCompany)
+		companyAuth.PUT("/:id", companyHandler.UpdateCompany)
+		companyAuth.GET("/:id", companyHandler.FindCompanyById)
+		companyAuth.DELETE("/:id", companyHandler.DeleteCompany)
+		companyAuth.GET("/:id/contacts", middleware.WorkspaceMiddleware(workspaceSvc), contactHandler.GetCompanyContacts)
+	}
+
+	shareAuth := router.Group("/shares")
+	shareAuth.Use(middleware.AuthMiddleware(authRepo))
+	{
+		shareAuth.GET("", shareHandler.GetShares)
+		shareAuth.DELETE("/:id", shareHandler.RevokeShare)
+	}
+
+	customFieldRepo := customfields.NewCustomFieldRepository(db)
+	customFieldSvc := customfields.NewCustomFieldService(customFieldRepo)
+	customFieldHandler := customfields.NewCustomFieldHandler(customFieldSvc)
+
+	customFieldAuth := router.Group("/custom-fields")
+	customFieldAuth.Use(middleware.AuthMiddleware(authRepo))
+	{
+		customFieldAuth.GET("", customFieldHandler.GetFields)
+		customFieldAuth.POST("", customFieldHandler.CreateField)
+		customFieldAuth.PUT("/:id", customFieldHandler.UpdateField)
+		customFieldAuth.GET("/:id", customFieldHandler.FindFieldById)
+		customFieldAuth.DELETE("/:id", customFieldHandler.DeleteField)
+	}
 
 	addressAuth := router.Group("/addresses")
-	addressAuth.Use(middleware.AuthMiddleware(authRepo))
+	addressAuth.Use(middleware.AuthMiddleware(authRepo), middleware.WorkspaceMiddleware(workspaceSvc))
+	{
+		addressAuth.GET("", addressHandler.ListAddresses)
+		addressAuth.GET("/nearby", addressHandler.Nearby)
+		addressAuth.GET("/export.geojson", addressHandler.ExportGeoJSON)
+		addressAuth.GET("/:id/label", addressHandler.GetLabel)
+	}
+
+	searchRepo := search.NewSearchRepository(db)
+	searchSvc := search.NewSearchService(search.NewMySQLIndex(db), searchRepo)
+	searchHandler := search.NewSearchHandler(searchSvc)
+
+	router.GET("/search", middleware.AuthMiddleware(authRepo), middleware.WorkspaceMiddleware(workspaceSvc), searchHandler.Search)
+
+	reminderRepo := reminders.NewReminderRepository(db)
+	reminderSvc := reminders.NewReminderService(reminderRepo)
+	reminderHandler := reminders.NewReminderHandler(reminderSvc)
+
+	reminderAuth := router.Group("/reminders")
+	reminderAuth.Use(middleware.AuthMiddleware(authRepo))
 	{
-		addressAuth.GET("", addressHandler.GetAddresses)
-		addressAuth.POST("", addressHandler.CreateAddress)
-		addressAuth.PUT("/:id", addressHandler.UpdateAddress)
-		addressAuth.GET("/:id", addressHandler.FindAddressById)
-		addressAuth.DELETE("/:id", addressHandler.DeleteAddress)
+		reminderAuth.GET("/upcoming", middleware.WorkspaceMiddleware(workspaceSvc), reminderHandler.GetUpcoming)
+		reminderAuth.GET("/notifications", reminderHandler.GetNotifications)
+		reminderAuth.PUT("/notifications/:id/read", reminderHandler.MarkNotificationRead)
 	}
 
+	// Notify a week ahead; hourly runs pick up each user's new day shortly
+	// after midnight in their timezone.
+	reminders.NewScheduler(reminderSvc, time.Hour, 7).Start(context.Background())
+
+	trashRepo := trash.NewTrashRepository(db)
+	trashSvc := trash.NewTrashService(trashRepo, avatarSvc, trashRetention)
+	trashHandler := trash.NewTrashHandler(trashSvc)
+
+	trashAuth := router.Group("/trash")
+	trashAuth.Use(middleware.AuthMiddleware(authRepo), middleware.WorkspaceMiddleware(workspaceSvc))
+	{
+		trashAuth.GET("", trashHandler.GetTrash)
+		trashAuth.POST("/:type/:id/restore", trashHandler.RestoreItem)
+		trashAuth.DELETE("/:type/:id", trashHandler.PurgeItem)
+	}
+
+	trash.NewPurger(trashSvc, time.Hour).Start(context.Background())
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
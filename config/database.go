@@ -24,7 +24,9 @@ func NewDB() *gorm.DB {
 		return nil
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// Store and read times in UTC. Anything shown to a user is converted to
+	// the timezone on their profile instead of the server's local zone.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27",
 		dbUser,
 		dbPassword,
 		dbHost,
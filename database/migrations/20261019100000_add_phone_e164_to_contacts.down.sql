@@ -0,0 +1,5 @@
+DROP INDEX idx_contacts_phone_e164 ON contacts;
+
+ALTER TABLE contacts DROP COLUMN phone_e164;
+
+ALTER TABLE users DROP COLUMN region;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN region VARCHAR(2) NOT NULL DEFAULT 'ID' AFTER token;
+
+ALTER TABLE contacts ADD COLUMN phone_e164 VARCHAR(16) AFTER phone;
+
+CREATE INDEX idx_contacts_phone_e164 ON contacts (phone_e164);
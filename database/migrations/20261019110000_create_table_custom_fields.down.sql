@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contact_custom_values;
+
+DROP TABLE IF EXISTS custom_fields;
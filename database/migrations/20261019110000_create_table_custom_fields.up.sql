@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS custom_fields (
+    field_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    `key` VARCHAR(50) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    options TEXT,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_custom_fields_user_key ON custom_fields (user_id, `key`);
+
+CREATE TABLE IF NOT EXISTS contact_custom_values (
+    value_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    field_id BIGINT UNSIGNED NOT NULL,
+    value VARCHAR(1000) NOT NULL,
+    number_value DECIMAL(20,6),
+    date_value DATE,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (field_id) REFERENCES custom_fields (field_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_contact_custom_values_contact_field ON contact_custom_values (contact_id, field_id);
+
+CREATE INDEX idx_contact_custom_values_field_id ON contact_custom_values (field_id);
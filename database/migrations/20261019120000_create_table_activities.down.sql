@@ -0,0 +1,5 @@
+DROP INDEX idx_contacts_last_interaction_at ON contacts;
+
+ALTER TABLE contacts DROP COLUMN last_interaction_at;
+
+DROP TABLE IF EXISTS activities;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS activities (
+    activity_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    author_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    body TEXT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_activities_contact_occurred ON activities (contact_id, occurred_at);
+
+CREATE INDEX idx_activities_author_id ON activities (author_id);
+
+CREATE INDEX idx_activities_deleted_at ON activities (deleted_at);
+
+ALTER TABLE contacts ADD COLUMN last_interaction_at DATETIME(3) NULL AFTER phone_e164;
+
+CREATE INDEX idx_contacts_last_interaction_at ON contacts (last_interaction_at);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS reminder_notifications;
+
+ALTER TABLE contacts DROP COLUMN anniversary;
+ALTER TABLE contacts DROP COLUMN birthday;
+
+ALTER TABLE users DROP COLUMN timezone;
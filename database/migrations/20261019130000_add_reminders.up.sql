@@ -0,0 +1,22 @@
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC' AFTER region;
+
+ALTER TABLE contacts ADD COLUMN birthday DATE NULL AFTER phone_e164;
+ALTER TABLE contacts ADD COLUMN anniversary DATE NULL AFTER birthday;
+
+CREATE TABLE IF NOT EXISTS reminder_notifications (
+    notification_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    occurs_on DATE NOT NULL,
+    message VARCHAR(255) NOT NULL,
+    read_at DATETIME(3) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_reminder_notifications_occurrence ON reminder_notifications (user_id, contact_id, kind, label, occurs_on);
+
+CREATE INDEX idx_reminder_notifications_user ON reminder_notifications (user_id, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contact_shares;
+
+DROP TABLE IF EXISTS contact_group_members;
+
+DROP TABLE IF EXISTS contact_groups;
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS contact_groups (
+    group_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_contact_groups_user_name ON contact_groups (user_id, name);
+
+CREATE TABLE IF NOT EXISTS contact_group_members (
+    group_id BIGINT UNSIGNED NOT NULL,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (group_id, contact_id),
+    FOREIGN KEY (group_id) REFERENCES contact_groups (group_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_contact_group_members_contact_id ON contact_group_members (contact_id);
+
+CREATE TABLE IF NOT EXISTS contact_shares (
+    share_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    owner_id BIGINT UNSIGNED NOT NULL,
+    grantee_id BIGINT UNSIGNED NOT NULL,
+    contact_id BIGINT UNSIGNED NULL,
+    group_id BIGINT UNSIGNED NULL,
+    permission VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (grantee_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES contact_groups (group_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_contact_shares_grantee_contact ON contact_shares (grantee_id, contact_id);
+
+CREATE UNIQUE INDEX idx_contact_shares_grantee_group ON contact_shares (grantee_id, group_id);
+
+CREATE INDEX idx_contact_shares_owner_id ON contact_shares (owner_id);
+
+CREATE INDEX idx_contact_shares_contact_id ON contact_shares (contact_id);
+
+CREATE INDEX idx_contact_shares_group_id ON contact_shares (group_id);
@@ -0,0 +1,17 @@
+ALTER TABLE users DROP FOREIGN KEY fk_users_active_workspace;
+
+DROP INDEX idx_users_active_workspace_id ON users;
+
+ALTER TABLE users DROP COLUMN active_workspace_id;
+
+ALTER TABLE contacts DROP FOREIGN KEY fk_contacts_workspace;
+
+DROP INDEX idx_contacts_workspace_id ON contacts;
+
+ALTER TABLE contacts DROP COLUMN workspace_id;
+
+DROP TABLE IF EXISTS workspace_invitations;
+
+DROP TABLE IF EXISTS workspace_members;
+
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    workspace_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_workspaces_created_by ON workspaces (created_by);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces (workspace_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members (user_id);
+
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    invitation_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    workspace_id BIGINT UNSIGNED NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    token VARCHAR(64) NOT NULL,
+    invited_by BIGINT UNSIGNED NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces (workspace_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_workspace_invitations_token ON workspace_invitations (token);
+
+CREATE INDEX idx_workspace_invitations_workspace_id ON workspace_invitations (workspace_id);
+
+CREATE INDEX idx_workspace_invitations_email ON workspace_invitations (email);
+
+ALTER TABLE contacts
+    ADD COLUMN workspace_id BIGINT UNSIGNED NULL AFTER user_id,
+    ADD CONSTRAINT fk_contacts_workspace FOREIGN KEY (workspace_id) REFERENCES workspaces (workspace_id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX idx_contacts_workspace_id ON contacts (workspace_id);
+
+ALTER TABLE users
+    ADD COLUMN active_workspace_id BIGINT UNSIGNED NULL,
+    ADD CONSTRAINT fk_users_active_workspace FOREIGN KEY (active_workspace_id) REFERENCES workspaces (workspace_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX idx_users_active_workspace_id ON users (active_workspace_id);
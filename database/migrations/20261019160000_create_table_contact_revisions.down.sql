@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contact_revision_changes;
+
+DROP TABLE IF EXISTS contact_revisions;
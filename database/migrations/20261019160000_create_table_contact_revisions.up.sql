@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS contact_revisions (
+    revision_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    entity VARCHAR(10) NOT NULL,
+    entity_id BIGINT UNSIGNED NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    author_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_contact_revisions_contact ON contact_revisions (contact_id);
+
+CREATE INDEX idx_contact_revisions_author_id ON contact_revisions (author_id);
+
+CREATE TABLE IF NOT EXISTS contact_revision_changes (
+    change_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    revision_id BIGINT UNSIGNED NOT NULL,
+    field VARCHAR(60) NOT NULL,
+    old_value TEXT NULL,
+    new_value TEXT NULL,
+    FOREIGN KEY (revision_id) REFERENCES contact_revisions (revision_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_contact_revision_changes_revision_id ON contact_revision_changes (revision_id);
@@ -0,0 +1,10 @@
+ALTER TABLE users DROP COLUMN contact_order;
+ALTER TABLE users DROP COLUMN contact_sort;
+
+DROP INDEX idx_contacts_updated_at ON contacts;
+
+DROP INDEX idx_contacts_created_at ON contacts;
+
+DROP INDEX idx_contacts_favorite ON contacts;
+
+ALTER TABLE contacts DROP COLUMN favorite;
@@ -0,0 +1,10 @@
+ALTER TABLE contacts ADD COLUMN favorite BOOLEAN NOT NULL DEFAULT FALSE AFTER anniversary;
+
+CREATE INDEX idx_contacts_favorite ON contacts (favorite);
+
+CREATE INDEX idx_contacts_created_at ON contacts (created_at);
+
+CREATE INDEX idx_contacts_updated_at ON contacts (updated_at);
+
+ALTER TABLE users ADD COLUMN contact_sort VARCHAR(60) NOT NULL DEFAULT '' AFTER active_workspace_id;
+ALTER TABLE users ADD COLUMN contact_order VARCHAR(4) NOT NULL DEFAULT '' AFTER contact_sort;
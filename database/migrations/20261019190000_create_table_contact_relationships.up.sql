@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS contact_relationships (
+    relationship_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    related_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (related_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_contact_relationships_pair ON contact_relationships (contact_id, related_id, type);
+
+CREATE INDEX idx_contact_relationships_related_id ON contact_relationships (related_id);
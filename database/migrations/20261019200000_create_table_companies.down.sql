@@ -0,0 +1,8 @@
+ALTER TABLE contacts DROP FOREIGN KEY fk_contacts_company;
+
+DROP INDEX idx_contacts_company_id ON contacts;
+
+ALTER TABLE contacts DROP COLUMN job_title;
+ALTER TABLE contacts DROP COLUMN company_id;
+
+DROP TABLE IF EXISTS companies;
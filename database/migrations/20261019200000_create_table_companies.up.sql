@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS companies (
+    company_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    domain VARCHAR(255),
+    industry VARCHAR(100),
+    address VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_companies_user_name ON companies (user_id, name);
+
+CREATE INDEX idx_companies_domain ON companies (domain);
+
+ALTER TABLE contacts ADD COLUMN company_id BIGINT UNSIGNED NULL AFTER phone_e164;
+ALTER TABLE contacts ADD COLUMN job_title VARCHAR(100) AFTER company_id;
+ALTER TABLE contacts ADD CONSTRAINT fk_contacts_company FOREIGN KEY (company_id) REFERENCES companies (company_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX idx_contacts_company_id ON contacts (company_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS contact_tags (
+    contact_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    PRIMARY KEY (contact_id, name),
+    FOREIGN KEY (contact_id) REFERENCES contacts (contact_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_contact_tags_name ON contact_tags (name);
@@ -0,0 +1,5 @@
+DROP INDEX ft_activities_search ON activities;
+
+DROP INDEX ft_addresses_search ON addresses;
+
+DROP INDEX ft_contacts_search ON contacts;
@@ -0,0 +1,5 @@
+CREATE FULLTEXT INDEX ft_contacts_search ON contacts (first_name, last_name, email, phone, job_title);
+
+CREATE FULLTEXT INDEX ft_addresses_search ON addresses (street, city, state, postal_code, country);
+
+CREATE FULLTEXT INDEX ft_activities_search ON activities (body);
@@ -0,0 +1,6 @@
+DROP INDEX idx_addresses_primary_type ON addresses;
+
+ALTER TABLE addresses
+    DROP COLUMN primary_type,
+    DROP COLUMN is_primary,
+    DROP COLUMN type;
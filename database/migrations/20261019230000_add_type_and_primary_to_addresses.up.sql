@@ -0,0 +1,6 @@
+ALTER TABLE addresses
+    ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'other' AFTER country,
+    ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT FALSE AFTER type,
+    ADD COLUMN primary_type VARCHAR(20) GENERATED ALWAYS AS (IF(is_primary AND deleted_at IS NULL, type, NULL)) STORED AFTER is_primary;
+
+CREATE UNIQUE INDEX idx_addresses_primary_type ON addresses (contact_id, primary_type);
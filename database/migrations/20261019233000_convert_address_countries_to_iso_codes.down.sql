@@ -0,0 +1,249 @@
+UPDATE addresses SET country = 'Andorra' WHERE country = 'AD';
+UPDATE addresses SET country = 'United Arab Emirates' WHERE country = 'AE';
+UPDATE addresses SET country = 'Afghanistan' WHERE country = 'AF';
+UPDATE addresses SET country = 'Antigua and Barbuda' WHERE country = 'AG';
+UPDATE addresses SET country = 'Anguilla' WHERE country = 'AI';
+UPDATE addresses SET country = 'Albania' WHERE country = 'AL';
+UPDATE addresses SET country = 'Armenia' WHERE country = 'AM';
+UPDATE addresses SET country = 'Angola' WHERE country = 'AO';
+UPDATE addresses SET country = 'Antarctica' WHERE country = 'AQ';
+UPDATE addresses SET country = 'Argentina' WHERE country = 'AR';
+UPDATE addresses SET country = 'American Samoa' WHERE country = 'AS';
+UPDATE addresses SET country = 'Austria' WHERE country = 'AT';
+UPDATE addresses SET country = 'Australia' WHERE country = 'AU';
+UPDATE addresses SET country = 'Aruba' WHERE country = 'AW';
+UPDATE addresses SET country = 'Åland Islands' WHERE country = 'AX';
+UPDATE addresses SET country = 'Azerbaijan' WHERE country = 'AZ';
+UPDATE addresses SET country = 'Bosnia and Herzegovina' WHERE country = 'BA';
+UPDATE addresses SET country = 'Barbados' WHERE country = 'BB';
+UPDATE addresses SET country = 'Bangladesh' WHERE country = 'BD';
+UPDATE addresses SET country = 'Belgium' WHERE country = 'BE';
+UPDATE addresses SET country = 'Burkina Faso' WHERE country = 'BF';
+UPDATE addresses SET country = 'Bulgaria' WHERE country = 'BG';
+UPDATE addresses SET country = 'Bahrain' WHERE country = 'BH';
+UPDATE addresses SET country = 'Burundi' WHERE country = 'BI';
+UPDATE addresses SET country = 'Benin' WHERE country = 'BJ';
+UPDATE addresses SET country = 'Saint Barthélemy' WHERE country = 'BL';
+UPDATE addresses SET country = 'Bermuda' WHERE country = 'BM';
+UPDATE addresses SET country = 'Brunei Darussalam' WHERE country = 'BN';
+UPDATE addresses SET country = 'Bolivia' WHERE country = 'BO';
+UPDATE addresses SET country = 'Bonaire, Sint Eustatius and Saba' WHERE country = 'BQ';
+UPDATE addresses SET country = 'Brazil' WHERE country = 'BR';
+UPDATE addresses SET country = 'Bahamas' WHERE country = 'BS';
+UPDATE addresses SET country = 'Bhutan' WHERE country = 'BT';
+UPDATE addresses SET country = 'Bouvet Island' WHERE country = 'BV';
+UPDATE addresses SET country = 'Botswana' WHERE country = 'BW';
+UPDATE addresses SET country = 'Belarus' WHERE country = 'BY';
+UPDATE addresses SET country = 'Belize' WHERE country = 'BZ';
+UPDATE addresses SET country = 'Canada' WHERE country = 'CA';
+UPDATE addresses SET country = 'Cocos (Keeling) Islands' WHERE country = 'CC';
+UPDATE addresses SET country = 'Congo, Democratic Republic of the' WHERE country = 'CD';
+UPDATE addresses SET country = 'Central African Republic' WHERE country = 'CF';
+UPDATE addresses SET country = 'Congo' WHERE country = 'CG';
+UPDATE addresses SET country = 'Switzerland' WHERE country = 'CH';
+UPDATE addresses SET country = 'Côte d''Ivoire' WHERE country = 'CI';
+UPDATE addresses SET country = 'Cook Islands' WHERE country = 'CK';
+UPDATE addresses SET country = 'Chile' WHERE country = 'CL';
+UPDATE addresses SET country = 'Cameroon' WHERE country = 'CM';
+UPDATE addresses SET country = 'China' WHERE country = 'CN';
+UPDATE addresses SET country = 'Colombia' WHERE country = 'CO';
+UPDATE addresses SET country = 'Costa Rica' WHERE country = 'CR';
+UPDATE addresses SET country = 'Cuba' WHERE country = 'CU';
+UPDATE addresses SET country = 'Cabo Verde' WHERE country = 'CV';
+UPDATE addresses SET country = 'Curaçao' WHERE country = 'CW';
+UPDATE addresses SET country = 'Christmas Island' WHERE country = 'CX';
+UPDATE addresses SET country = 'Cyprus' WHERE country = 'CY';
+UPDATE addresses SET country = 'Czechia' WHERE country = 'CZ';
+UPDATE addresses SET country = 'Germany' WHERE country = 'DE';
+UPDATE addresses SET country = 'Djibouti' WHERE country = 'DJ';
+UPDATE addresses SET country = 'Denmark' WHERE country = 'DK';
+UPDATE addresses SET country = 'Dominica' WHERE country = 'DM';
+UPDATE addresses SET country = 'Dominican Republic' WHERE country = 'DO';
+UPDATE addresses SET country = 'Algeria' WHERE country = 'DZ';
+UPDATE addresses SET country = 'Ecuador' WHERE country = 'EC';
+UPDATE addresses SET country = 'Estonia' WHERE country = 'EE';
+UPDATE addresses SET country = 'Egypt' WHERE country = 'EG';
+UPDATE addresses SET country = 'Western Sahara' WHERE country = 'EH';
+UPDATE addresses SET country = 'Eritrea' WHERE country = 'ER';
+UPDATE addresses SET country = 'Spain' WHERE country = 'ES';
+UPDATE addresses SET country = 'Ethiopia' WHERE country = 'ET';
+UPDATE addresses SET country = 'Finland' WHERE country = 'FI';
+UPDATE addresses SET country = 'Fiji' WHERE country = 'FJ';
+UPDATE addresses SET country = 'Falkland Islands' WHERE country = 'FK';
+UPDATE addresses SET country = 'Micronesia' WHERE country = 'FM';
+UPDATE addresses SET country = 'Faroe Islands' WHERE country = 'FO';
+UPDATE addresses SET country = 'France' WHERE country = 'FR';
+UPDATE addresses SET country = 'Gabon' WHERE country = 'GA';
+UPDATE addresses SET country = 'United Kingdom' WHERE country = 'GB';
+UPDATE addresses SET country = 'Grenada' WHERE country = 'GD';
+UPDATE addresses SET country = 'Georgia' WHERE country = 'GE';
+UPDATE addresses SET country = 'French Guiana' WHERE country = 'GF';
+UPDATE addresses SET country = 'Guernsey' WHERE country = 'GG';
+UPDATE addresses SET country = 'Ghana' WHERE country = 'GH';
+UPDATE addresses SET country = 'Gibraltar' WHERE country = 'GI';
+UPDATE addresses SET country = 'Greenland' WHERE country = 'GL';
+UPDATE addresses SET country = 'Gambia' WHERE country = 'GM';
+UPDATE addresses SET country = 'Guinea' WHERE country = 'GN';
+UPDATE addresses SET country = 'Guadeloupe' WHERE country = 'GP';
+UPDATE addresses SET country = 'Equatorial Guinea' WHERE country = 'GQ';
+UPDATE addresses SET country = 'Greece' WHERE country = 'GR';
+UPDATE addresses SET country = 'South Georgia and the South Sandwich Islands' WHERE country = 'GS';
+UPDATE addresses SET country = 'Guatemala' WHERE country = 'GT';
+UPDATE addresses SET country = 'Guam' WHERE country = 'GU';
+UPDATE addresses SET country = 'Guinea-Bissau' WHERE country = 'GW';
+UPDATE addresses SET country = 'Guyana' WHERE country = 'GY';
+UPDATE addresses SET country = 'Hong Kong' WHERE country = 'HK';
+UPDATE addresses SET country = 'Heard Island and McDonald Islands' WHERE country = 'HM';
+UPDATE addresses SET country = 'Honduras' WHERE country = 'HN';
+UPDATE addresses SET country = 'Croatia' WHERE country = 'HR';
+UPDATE addresses SET country = 'Haiti' WHERE country = 'HT';
+UPDATE addresses SET country = 'Hungary' WHERE country = 'HU';
+UPDATE addresses SET country = 'Indonesia' WHERE country = 'ID';
+UPDATE addresses SET country = 'Ireland' WHERE country = 'IE';
+UPDATE addresses SET country = 'Israel' WHERE country = 'IL';
+UPDATE addresses SET country = 'Isle of Man' WHERE country = 'IM';
+UPDATE addresses SET country = 'India' WHERE country = 'IN';
+UPDATE addresses SET country = 'British Indian Ocean Territory' WHERE country = 'IO';
+UPDATE addresses SET country = 'Iraq' WHERE country = 'IQ';
+UPDATE addresses SET country = 'Iran' WHERE country = 'IR';
+UPDATE addresses SET country = 'Iceland' WHERE country = 'IS';
+UPDATE addresses SET country = 'Italy' WHERE country = 'IT';
+UPDATE addresses SET country = 'Jersey' WHERE country = 'JE';
+UPDATE addresses SET country = 'Jamaica' WHERE country = 'JM';
+UPDATE addresses SET country = 'Jordan' WHERE country = 'JO';
+UPDATE addresses SET country = 'Japan' WHERE country = 'JP';
+UPDATE addresses SET country = 'Kenya' WHERE country = 'KE';
+UPDATE addresses SET country = 'Kyrgyzstan' WHERE country = 'KG';
+UPDATE addresses SET country = 'Cambodia' WHERE country = 'KH';
+UPDATE addresses SET country = 'Kiribati' WHERE country = 'KI';
+UPDATE addresses SET country = 'Comoros' WHERE country = 'KM';
+UPDATE addresses SET country = 'Saint Kitts and Nevis' WHERE country = 'KN';
+UPDATE addresses SET country = 'North Korea' WHERE country = 'KP';
+UPDATE addresses SET country = 'South Korea' WHERE country = 'KR';
+UPDATE addresses SET country = 'Kuwait' WHERE country = 'KW';
+UPDATE addresses SET country = 'Cayman Islands' WHERE country = 'KY';
+UPDATE addresses SET country = 'Kazakhstan' WHERE country = 'KZ';
+UPDATE addresses SET country = 'Laos' WHERE country = 'LA';
+UPDATE addresses SET country = 'Lebanon' WHERE country = 'LB';
+UPDATE addresses SET country = 'Saint Lucia' WHERE country = 'LC';
+UPDATE addresses SET country = 'Liechtenstein' WHERE country = 'LI';
+UPDATE addresses SET country = 'Sri Lanka' WHERE country = 'LK';
+UPDATE addresses SET country = 'Liberia' WHERE country = 'LR';
+UPDATE addresses SET country = 'Lesotho' WHERE country = 'LS';
+UPDATE addresses SET country = 'Lithuania' WHERE country = 'LT';
+UPDATE addresses SET country = 'Luxembourg' WHERE country = 'LU';
+UPDATE addresses SET country = 'Latvia' WHERE country = 'LV';
+UPDATE addresses SET country = 'Libya' WHERE country = 'LY';
+UPDATE addresses SET country = 'Morocco' WHERE country = 'MA';
+UPDATE addresses SET country = 'Monaco' WHERE country = 'MC';
+UPDATE addresses SET country = 'Moldova' WHERE country = 'MD';
+UPDATE addresses SET country = 'Montenegro' WHERE country = 'ME';
+UPDATE addresses SET country = 'Saint Martin (French part)' WHERE country = 'MF';
+UPDATE addresses SET country = 'Madagascar' WHERE country = 'MG';
+UPDATE addresses SET country = 'Marshall Islands' WHERE country = 'MH';
+UPDATE addresses SET country = 'North Macedonia' WHERE country = 'MK';
+UPDATE addresses SET country = 'Mali' WHERE country = 'ML';
+UPDATE addresses SET country = 'Myanmar' WHERE country = 'MM';
+UPDATE addresses SET country = 'Mongolia' WHERE country = 'MN';
+UPDATE addresses SET country = 'Macao' WHERE country = 'MO';
+UPDATE addresses SET country = 'Northern Mariana Islands' WHERE country = 'MP';
+UPDATE addresses SET country = 'Martinique' WHERE country = 'MQ';
+UPDATE addresses SET country = 'Mauritania' WHERE country = 'MR';
+UPDATE addresses SET country = 'Montserrat' WHERE country = 'MS';
+UPDATE addresses SET country = 'Malta' WHERE country = 'MT';
+UPDATE addresses SET country = 'Mauritius' WHERE country = 'MU';
+UPDATE addresses SET country = 'Maldives' WHERE country = 'MV';
+UPDATE addresses SET country = 'Malawi' WHERE country = 'MW';
+UPDATE addresses SET country = 'Mexico' WHERE country = 'MX';
+UPDATE addresses SET country = 'Malaysia' WHERE country = 'MY';
+UPDATE addresses SET country = 'Mozambique' WHERE country = 'MZ';
+UPDATE addresses SET country = 'Namibia' WHERE country = 'NA';
+UPDATE addresses SET country = 'New Caledonia' WHERE country = 'NC';
+UPDATE addresses SET country = 'Niger' WHERE country = 'NE';
+UPDATE addresses SET country = 'Norfolk Island' WHERE country = 'NF';
+UPDATE addresses SET country = 'Nigeria' WHERE country = 'NG';
+UPDATE addresses SET country = 'Nicaragua' WHERE country = 'NI';
+UPDATE addresses SET country = 'Netherlands' WHERE country = 'NL';
+UPDATE addresses SET country = 'Norway' WHERE country = 'NO';
+UPDATE addresses SET country = 'Nepal' WHERE country = 'NP';
+UPDATE addresses SET country = 'Nauru' WHERE country = 'NR';
+UPDATE addresses SET country = 'Niue' WHERE country = 'NU';
+UPDATE addresses SET country = 'New Zealand' WHERE country = 'NZ';
+UPDATE addresses SET country = 'Oman' WHERE country = 'OM';
+UPDATE addresses SET country = 'Panama' WHERE country = 'PA';
+UPDATE addresses SET country = 'Peru' WHERE country = 'PE';
+UPDATE addresses SET country = 'French Polynesia' WHERE country = 'PF';
+UPDATE addresses SET country = 'Papua New Guinea' WHERE country = 'PG';
+UPDATE addresses SET country = 'Philippines' WHERE country = 'PH';
+UPDATE addresses SET country = 'Pakistan' WHERE country = 'PK';
+UPDATE addresses SET country = 'Poland' WHERE country = 'PL';
+UPDATE addresses SET country = 'Saint Pierre and Miquelon' WHERE country = 'PM';
+UPDATE addresses SET country = 'Pitcairn' WHERE country = 'PN';
+UPDATE addresses SET country = 'Puerto Rico' WHERE country = 'PR';
+UPDATE addresses SET country = 'Palestine' WHERE country = 'PS';
+UPDATE addresses SET country = 'Portugal' WHERE country = 'PT';
+UPDATE addresses SET country = 'Palau' WHERE country = 'PW';
+UPDATE addresses SET country = 'Paraguay' WHERE country = 'PY';
+UPDATE addresses SET country = 'Qatar' WHERE country = 'QA';
+UPDATE addresses SET country = 'Réunion' WHERE country = 'RE';
+UPDATE addresses SET country = 'Romania' WHERE country = 'RO';
+UPDATE addresses SET country = 'Serbia' WHERE country = 'RS';
+UPDATE addresses SET country = 'Russia' WHERE country = 'RU';
+UPDATE addresses SET country = 'Rwanda' WHERE country = 'RW';
+UPDATE addresses SET country = 'Saudi Arabia' WHERE country = 'SA';
+UPDATE addresses SET country = 'Solomon Islands' WHERE country = 'SB';
+UPDATE addresses SET country = 'Seychelles' WHERE country = 'SC';
+UPDATE addresses SET country = 'Sudan' WHERE country = 'SD';
+UPDATE addresses SET country = 'Sweden' WHERE country = 'SE';
+UPDATE addresses SET country = 'Singapore' WHERE country = 'SG';
+UPDATE addresses SET country = 'Saint Helena, Ascension and Tristan da Cunha' WHERE country = 'SH';
+UPDATE addresses SET country = 'Slovenia' WHERE country = 'SI';
+UPDATE addresses SET country = 'Svalbard and Jan Mayen' WHERE country = 'SJ';
+UPDATE addresses SET country = 'Slovakia' WHERE country = 'SK';
+UPDATE addresses SET country = 'Sierra Leone' WHERE country = 'SL';
+UPDATE addresses SET country = 'San Marino' WHERE country = 'SM';
+UPDATE addresses SET country = 'Senegal' WHERE country = 'SN';
+UPDATE addresses SET country = 'Somalia' WHERE country = 'SO';
+UPDATE addresses SET country = 'Suriname' WHERE country = 'SR';
+UPDATE addresses SET country = 'South Sudan' WHERE country = 'SS';
+UPDATE addresses SET country = 'Sao Tome and Principe' WHERE country = 'ST';
+UPDATE addresses SET country = 'El Salvador' WHERE country = 'SV';
+UPDATE addresses SET country = 'Sint Maarten (Dutch part)' WHERE country = 'SX';
+UPDATE addresses SET country = 'Syria' WHERE country = 'SY';
+UPDATE addresses SET country = 'Eswatini' WHERE country = 'SZ';
+UPDATE addresses SET country = 'Turks and Caicos Islands' WHERE country = 'TC';
+UPDATE addresses SET country = 'Chad' WHERE country = 'TD';
+UPDATE addresses SET country = 'French Southern Territories' WHERE country = 'TF';
+UPDATE addresses SET country = 'Togo' WHERE country = 'TG';
+UPDATE addresses SET country = 'Thailand' WHERE country = 'TH';
+UPDATE addresses SET country = 'Tajikistan' WHERE country = 'TJ';
+UPDATE addresses SET country = 'Tokelau' WHERE country = 'TK';
+UPDATE addresses SET country = 'Timor-Leste' WHERE country = 'TL';
+UPDATE addresses SET country = 'Turkmenistan' WHERE country = 'TM';
+UPDATE addresses SET country = 'Tunisia' WHERE country = 'TN';
+UPDATE addresses SET country = 'Tonga' WHERE country = 'TO';
+UPDATE addresses SET country = 'Türkiye' WHERE country = 'TR';
+UPDATE addresses SET country = 'Trinidad and Tobago' WHERE country = 'TT';
+UPDATE addresses SET country = 'Tuvalu' WHERE country = 'TV';
+UPDATE addresses SET country = 'Taiwan' WHERE country = 'TW';
+UPDATE addresses SET country = 'Tanzania' WHERE country = 'TZ';
+UPDATE addresses SET country = 'Ukraine' WHERE country = 'UA';
+UPDATE addresses SET country = 'Uganda' WHERE country = 'UG';
+UPDATE addresses SET country = 'United States Minor Outlying Islands' WHERE country = 'UM';
+UPDATE addresses SET country = 'United States' WHERE country = 'US';
+UPDATE addresses SET country = 'Uruguay' WHERE country = 'UY';
+UPDATE addresses SET country = 'Uzbekistan' WHERE country = 'UZ';
+UPDATE addresses SET country = 'Holy See' WHERE country = 'VA';
+UPDATE addresses SET country = 'Saint Vincent and the Grenadines' WHERE country = 'VC';
+UPDATE addresses SET country = 'Venezuela' WHERE country = 'VE';
+UPDATE addresses SET country = 'Virgin Islands (British)' WHERE country = 'VG';
+UPDATE addresses SET country = 'Virgin Islands (U.S.)' WHERE country = 'VI';
+UPDATE addresses SET country = 'Viet Nam' WHERE country = 'VN';
+UPDATE addresses SET country = 'Vanuatu' WHERE country = 'VU';
+UPDATE addresses SET country = 'Wallis and Futuna' WHERE country = 'WF';
+UPDATE addresses SET country = 'Samoa' WHERE country = 'WS';
+UPDATE addresses SET country = 'Yemen' WHERE country = 'YE';
+UPDATE addresses SET country = 'Mayotte' WHERE country = 'YT';
+UPDATE addresses SET country = 'South Africa' WHERE country = 'ZA';
+UPDATE addresses SET country = 'Zambia' WHERE country = 'ZM';
+UPDATE addresses SET country = 'Zimbabwe' WHERE country = 'ZW';
@@ -0,0 +1,253 @@
+-- Addresses store ISO 3166-1 alpha-2 codes. Existing values written as a
+-- country name, alias or alpha-3 code are converted; anything else is kept
+-- as it is and has to be corrected the next time the address is edited.
+
+UPDATE addresses SET country = 'AD' WHERE country IN ('Andorra', 'AND');
+UPDATE addresses SET country = 'AE' WHERE country IN ('United Arab Emirates', 'ARE', 'UAE', 'Emirates');
+UPDATE addresses SET country = 'AF' WHERE country IN ('Afghanistan', 'AFG');
+UPDATE addresses SET country = 'AG' WHERE country IN ('Antigua and Barbuda', 'ATG');
+UPDATE addresses SET country = 'AI' WHERE country IN ('Anguilla', 'AIA');
+UPDATE addresses SET country = 'AL' WHERE country IN ('Albania', 'ALB');
+UPDATE addresses SET country = 'AM' WHERE country IN ('Armenia', 'ARM');
+UPDATE addresses SET country = 'AO' WHERE country IN ('Angola', 'AGO');
+UPDATE addresses SET country = 'AQ' WHERE country IN ('Antarctica', 'ATA');
+UPDATE addresses SET country = 'AR' WHERE country IN ('Argentina', 'ARG');
+UPDATE addresses SET country = 'AS' WHERE country IN ('American Samoa', 'ASM');
+UPDATE addresses SET country = 'AT' WHERE country IN ('Austria', 'AUT', 'Österreich');
+UPDATE addresses SET country = 'AU' WHERE country IN ('Australia', 'AUS');
+UPDATE addresses SET country = 'AW' WHERE country IN ('Aruba', 'ABW');
+UPDATE addresses SET country = 'AX' WHERE country IN ('Åland Islands', 'ALA', 'Aland');
+UPDATE addresses SET country = 'AZ' WHERE country IN ('Azerbaijan', 'AZE');
+UPDATE addresses SET country = 'BA' WHERE country IN ('Bosnia and Herzegovina', 'BIH', 'Bosnia');
+UPDATE addresses SET country = 'BB' WHERE country IN ('Barbados', 'BRB');
+UPDATE addresses SET country = 'BD' WHERE country IN ('Bangladesh', 'BGD');
+UPDATE addresses SET country = 'BE' WHERE country IN ('Belgium', 'BEL', 'België', 'Belgique');
+UPDATE addresses SET country = 'BF' WHERE country IN ('Burkina Faso', 'BFA');
+UPDATE addresses SET country = 'BG' WHERE country IN ('Bulgaria', 'BGR');
+UPDATE addresses SET country = 'BH' WHERE country IN ('Bahrain', 'BHR');
+UPDATE addresses SET country = 'BI' WHERE country IN ('Burundi', 'BDI');
+UPDATE addresses SET country = 'BJ' WHERE country IN ('Benin', 'BEN');
+UPDATE addresses SET country = 'BL' WHERE country IN ('Saint Barthélemy', 'BLM');
+UPDATE addresses SET country = 'BM' WHERE country IN ('Bermuda', 'BMU');
+UPDATE addresses SET country = 'BN' WHERE country IN ('Brunei Darussalam', 'BRN', 'Brunei');
+UPDATE addresses SET country = 'BO' WHERE country IN ('Bolivia', 'BOL');
+UPDATE addresses SET country = 'BQ' WHERE country IN ('Bonaire, Sint Eustatius and Saba', 'BES', 'Caribbean Netherlands');
+UPDATE addresses SET country = 'BR' WHERE country IN ('Brazil', 'BRA', 'Brasil');
+UPDATE addresses SET country = 'BS' WHERE country IN ('Bahamas', 'BHS', 'The Bahamas');
+UPDATE addresses SET country = 'BT' WHERE country IN ('Bhutan', 'BTN');
+UPDATE addresses SET country = 'BV' WHERE country IN ('Bouvet Island', 'BVT');
+UPDATE addresses SET country = 'BW' WHERE country IN ('Botswana', 'BWA');
+UPDATE addresses SET country = 'BY' WHERE country IN ('Belarus', 'BLR');
+UPDATE addresses SET country = 'BZ' WHERE country IN ('Belize', 'BLZ');
+UPDATE addresses SET country = 'CA' WHERE country IN ('Canada', 'CAN');
+UPDATE addresses SET country = 'CC' WHERE country IN ('Cocos (Keeling) Islands', 'CCK');
+UPDATE addresses SET country = 'CD' WHERE country IN ('Congo, Democratic Republic of the', 'COD', 'Democratic Republic of the Congo', 'DR Congo', 'DRC');
+UPDATE addresses SET country = 'CF' WHERE country IN ('Central African Republic', 'CAF');
+UPDATE addresses SET country = 'CG' WHERE country IN ('Congo', 'COG', 'Republic of the Congo');
+UPDATE addresses SET country = 'CH' WHERE country IN ('Switzerland', 'CHE', 'Schweiz', 'Suisse', 'Svizzera');
+UPDATE addresses SET country = 'CI' WHERE country IN ('Côte d''Ivoire', 'CIV', 'Ivory Coast');
+UPDATE addresses SET country = 'CK' WHERE country IN ('Cook Islands', 'COK');
+UPDATE addresses SET country = 'CL' WHERE country IN ('Chile', 'CHL');
+UPDATE addresses SET country = 'CM' WHERE country IN ('Cameroon', 'CMR');
+UPDATE addresses SET country = 'CN' WHERE country IN ('China', 'CHN', 'People''s Republic of China', 'PRC');
+UPDATE addresses SET country = 'CO' WHERE country IN ('Colombia', 'COL');
+UPDATE addresses SET country = 'CR' WHERE country IN ('Costa Rica', 'CRI');
+UPDATE addresses SET country = 'CU' WHERE country IN ('Cuba', 'CUB');
+UPDATE addresses SET country = 'CV' WHERE country IN ('Cabo Verde', 'CPV', 'Cape Verde');
+UPDATE addresses SET country = 'CW' WHERE country IN ('Curaçao', 'CUW');
+UPDATE addresses SET country = 'CX' WHERE country IN ('Christmas Island', 'CXR');
+UPDATE addresses SET country = 'CY' WHERE country IN ('Cyprus', 'CYP');
+UPDATE addresses SET country = 'CZ' WHERE country IN ('Czechia', 'CZE', 'Czech Republic');
+UPDATE addresses SET country = 'DE' WHERE country IN ('Germany', 'DEU', 'Deutschland');
+UPDATE addresses SET country = 'DJ' WHERE country IN ('Djibouti', 'DJI');
+UPDATE addresses SET country = 'DK' WHERE country IN ('Denmark', 'DNK', 'Danmark');
+UPDATE addresses SET country = 'DM' WHERE country IN ('Dominica', 'DMA');
+UPDATE addresses SET country = 'DO' WHERE country IN ('Dominican Republic', 'DOM');
+UPDATE addresses SET country = 'DZ' WHERE country IN ('Algeria', 'DZA');
+UPDATE addresses SET country = 'EC' WHERE country IN ('Ecuador', 'ECU');
+UPDATE addresses SET country = 'EE' WHERE country IN ('Estonia', 'EST');
+UPDATE addresses SET country = 'EG' WHERE country IN ('Egypt', 'EGY');
+UPDATE addresses SET country = 'EH' WHERE country IN ('Western Sahara', 'ESH');
+UPDATE addresses SET country = 'ER' WHERE country IN ('Eritrea', 'ERI');
+UPDATE addresses SET country = 'ES' WHERE country IN ('Spain', 'ESP', 'España');
+UPDATE addresses SET country = 'ET' WHERE country IN ('Ethiopia', 'ETH');
+UPDATE addresses SET country = 'FI' WHERE country IN ('Finland', 'FIN', 'Suomi');
+UPDATE addresses SET country = 'FJ' WHERE country IN ('Fiji', 'FJI');
+UPDATE addresses SET country = 'FK' WHERE country IN ('Falkland Islands', 'FLK', 'Falkland Islands (Malvinas)');
+UPDATE addresses SET country = 'FM' WHERE country IN ('Micronesia', 'FSM', 'Federated States of Micronesia');
+UPDATE addresses SET country = 'FO' WHERE country IN ('Faroe Islands', 'FRO');
+UPDATE addresses SET country = 'FR' WHERE country IN ('France', 'FRA');
+UPDATE addresses SET country = 'GA' WHERE country IN ('Gabon', 'GAB');
+UPDATE addresses SET country = 'GB' WHERE country IN ('United Kingdom', 'GBR', 'UK', 'Great Britain', 'Britain', 'England', 'Scotland', 'Wales', 'Northern Ireland');
+UPDATE addresses SET country = 'GD' WHERE country IN ('Grenada', 'GRD');
+UPDATE addresses SET country = 'GE' WHERE country IN ('Georgia', 'GEO');
+UPDATE addresses SET country = 'GF' WHERE country IN ('French Guiana', 'GUF');
+UPDATE addresses SET country = 'GG' WHERE country IN ('Guernsey', 'GGY');
+UPDATE addresses SET country = 'GH' WHERE country IN ('Ghana', 'GHA');
+UPDATE addresses SET country = 'GI' WHERE country IN ('Gibraltar', 'GIB');
+UPDATE addresses SET country = 'GL' WHERE country IN ('Greenland', 'GRL');
+UPDATE addresses SET country = 'GM' WHERE country IN ('Gambia', 'GMB', 'The Gambia');
+UPDATE addresses SET country = 'GN' WHERE country IN ('Guinea', 'GIN');
+UPDATE addresses SET country = 'GP' WHERE country IN ('Guadeloupe', 'GLP');
+UPDATE addresses SET country = 'GQ' WHERE country IN ('Equatorial Guinea', 'GNQ');
+UPDATE addresses SET country = 'GR' WHERE country IN ('Greece', 'GRC', 'Hellas');
+UPDATE addresses SET country = 'GS' WHERE country IN ('South Georgia and the South Sandwich Islands', 'SGS');
+UPDATE addresses SET country = 'GT' WHERE country IN ('Guatemala', 'GTM');
+UPDATE addresses SET country = 'GU' WHERE country IN ('Guam', 'GUM');
+UPDATE addresses SET country = 'GW' WHERE country IN ('Guinea-Bissau', 'GNB');
+UPDATE addresses SET country = 'GY' WHERE country IN ('Guyana', 'GUY');
+UPDATE addresses SET country = 'HK' WHERE country IN ('Hong Kong', 'HKG');
+UPDATE addresses SET country = 'HM' WHERE country IN ('Heard Island and McDonald Islands', 'HMD');
+UPDATE addresses SET country = 'HN' WHERE country IN ('Honduras', 'HND');
+UPDATE addresses SET country = 'HR' WHERE country IN ('Croatia', 'HRV', 'Hrvatska');
+UPDATE addresses SET country = 'HT' WHERE country IN ('Haiti', 'HTI');
+UPDATE addresses SET country = 'HU' WHERE country IN ('Hungary', 'HUN', 'Magyarország');
+UPDATE addresses SET country = 'ID' WHERE country IN ('Indonesia', 'IDN', 'Republic of Indonesia', 'Republik Indonesia');
+UPDATE addresses SET country = 'IE' WHERE country IN ('Ireland', 'IRL', 'Éire');
+UPDATE addresses SET country = 'IL' WHERE country IN ('Israel', 'ISR');
+UPDATE addresses SET country = 'IM' WHERE country IN ('Isle of Man', 'IMN');
+UPDATE addresses SET country = 'IN' WHERE country IN ('India', 'IND', 'Bharat');
+UPDATE addresses SET country = 'IO' WHERE country IN ('British Indian Ocean Territory', 'IOT');
+UPDATE addresses SET country = 'IQ' WHERE country IN ('Iraq', 'IRQ');
+UPDATE addresses SET country = 'IR' WHERE country IN ('Iran', 'IRN', 'Islamic Republic of Iran');
+UPDATE addresses SET country = 'IS' WHERE country IN ('Iceland', 'ISL');
+UPDATE addresses SET country = 'IT' WHERE country IN ('Italy', 'ITA', 'Italia');
+UPDATE addresses SET country = 'JE' WHERE country IN ('Jersey', 'JEY');
+UPDATE addresses SET country = 'JM' WHERE country IN ('Jamaica', 'JAM');
+UPDATE addresses SET country = 'JO' WHERE country IN ('Jordan', 'JOR');
+UPDATE addresses SET country = 'JP' WHERE country IN ('Japan', 'JPN', 'Nippon');
+UPDATE addresses SET country = 'KE' WHERE country IN ('Kenya', 'KEN');
+UPDATE addresses SET country = 'KG' WHERE country IN ('Kyrgyzstan', 'KGZ');
+UPDATE addresses SET country = 'KH' WHERE country IN ('Cambodia', 'KHM');
+UPDATE addresses SET country = 'KI' WHERE country IN ('Kiribati', 'KIR');
+UPDATE addresses SET country = 'KM' WHERE country IN ('Comoros', 'COM');
+UPDATE addresses SET country = 'KN' WHERE country IN ('Saint Kitts and Nevis', 'KNA');
+UPDATE addresses SET country = 'KP' WHERE country IN ('North Korea', 'PRK', 'Democratic People''s Republic of Korea', 'DPRK');
+UPDATE addresses SET country = 'KR' WHERE country IN ('South Korea', 'KOR', 'Republic of Korea', 'Korea');
+UPDATE addresses SET country = 'KW' WHERE country IN ('Kuwait', 'KWT');
+UPDATE addresses SET country = 'KY' WHERE country IN ('Cayman Islands', 'CYM');
+UPDATE addresses SET country = 'KZ' WHERE country IN ('Kazakhstan', 'KAZ');
+UPDATE addresses SET country = 'LA' WHERE country IN ('Laos', 'LAO', 'Lao People''s Democratic Republic');
+UPDATE addresses SET country = 'LB' WHERE country IN ('Lebanon', 'LBN');
+UPDATE addresses SET country = 'LC' WHERE country IN ('Saint Lucia', 'LCA');
+UPDATE addresses SET country = 'LI' WHERE country IN ('Liechtenstein', 'LIE');
+UPDATE addresses SET country = 'LK' WHERE country IN ('Sri Lanka', 'LKA');
+UPDATE addresses SET country = 'LR' WHERE country IN ('Liberia', 'LBR');
+UPDATE addresses SET country = 'LS' WHERE country IN ('Lesotho', 'LSO');
+UPDATE addresses SET country = 'LT' WHERE country IN ('Lithuania', 'LTU');
+UPDATE addresses SET country = 'LU' WHERE country IN ('Luxembourg', 'LUX');
+UPDATE addresses SET country = 'LV' WHERE country IN ('Latvia', 'LVA');
+UPDATE addresses SET country = 'LY' WHERE country IN ('Libya', 'LBY');
+UPDATE addresses SET country = 'MA' WHERE country IN ('Morocco', 'MAR');
+UPDATE addresses SET country = 'MC' WHERE country IN ('Monaco', 'MCO');
+UPDATE addresses SET country = 'MD' WHERE country IN ('Moldova', 'MDA', 'Republic of Moldova');
+UPDATE addresses SET country = 'ME' WHERE country IN ('Montenegro', 'MNE');
+UPDATE addresses SET country = 'MF' WHERE country IN ('Saint Martin (French part)', 'MAF', 'Saint Martin');
+UPDATE addresses SET country = 'MG' WHERE country IN ('Madagascar', 'MDG');
+UPDATE addresses SET country = 'MH' WHERE country IN ('Marshall Islands', 'MHL');
+UPDATE addresses SET country = 'MK' WHERE country IN ('North Macedonia', 'MKD', 'Macedonia');
+UPDATE addresses SET country = 'ML' WHERE country IN ('Mali', 'MLI');
+UPDATE addresses SET country = 'MM' WHERE country IN ('Myanmar', 'MMR', 'Burma');
+UPDATE addresses SET country = 'MN' WHERE country IN ('Mongolia', 'MNG');
+UPDATE addresses SET country = 'MO' WHERE country IN ('Macao', 'MAC', 'Macau');
+UPDATE addresses SET country = 'MP' WHERE country IN ('Northern Mariana Islands', 'MNP');
+UPDATE addresses SET country = 'MQ' WHERE country IN ('Martinique', 'MTQ');
+UPDATE addresses SET country = 'MR' WHERE country IN ('Mauritania', 'MRT');
+UPDATE addresses SET country = 'MS' WHERE country IN ('Montserrat', 'MSR');
+UPDATE addresses SET country = 'MT' WHERE country IN ('Malta', 'MLT');
+UPDATE addresses SET country = 'MU' WHERE country IN ('Mauritius', 'MUS');
+UPDATE addresses SET country = 'MV' WHERE country IN ('Maldives', 'MDV');
+UPDATE addresses SET country = 'MW' WHERE country IN ('Malawi', 'MWI');
+UPDATE addresses SET country = 'MX' WHERE country IN ('Mexico', 'MEX', 'México');
+UPDATE addresses SET country = 'MY' WHERE country IN ('Malaysia', 'MYS');
+UPDATE addresses SET country = 'MZ' WHERE country IN ('Mozambique', 'MOZ');
+UPDATE addresses SET country = 'NA' WHERE country IN ('Namibia', 'NAM');
+UPDATE addresses SET country = 'NC' WHERE country IN ('New Caledonia', 'NCL');
+UPDATE addresses SET country = 'NE' WHERE country IN ('Niger', 'NER');
+UPDATE addresses SET country = 'NF' WHERE country IN ('Norfolk Island', 'NFK');
+UPDATE addresses SET country = 'NG' WHERE country IN ('Nigeria', 'NGA');
+UPDATE addresses SET country = 'NI' WHERE country IN ('Nicaragua', 'NIC');
+UPDATE addresses SET country = 'NL' WHERE country IN ('Netherlands', 'NLD', 'The Netherlands', 'Holland', 'Nederland');
+UPDATE addresses SET country = 'NO' WHERE country IN ('Norway', 'NOR', 'Norge');
+UPDATE addresses SET country = 'NP' WHERE country IN ('Nepal', 'NPL');
+UPDATE addresses SET country = 'NR' WHERE country IN ('Nauru', 'NRU');
+UPDATE addresses SET country = 'NU' WHERE country IN ('Niue', 'NIU');
+UPDATE addresses SET country = 'NZ' WHERE country IN ('New Zealand', 'NZL', 'Aotearoa');
+UPDATE addresses SET country = 'OM' WHERE country IN ('Oman', 'OMN');
+UPDATE addresses SET country = 'PA' WHERE country IN ('Panama', 'PAN');
+UPDATE addresses SET country = 'PE' WHERE country IN ('Peru', 'PER');
+UPDATE addresses SET country = 'PF' WHERE country IN ('French Polynesia', 'PYF');
+UPDATE addresses SET country = 'PG' WHERE country IN ('Papua New Guinea', 'PNG');
+UPDATE addresses SET country = 'PH' WHERE country IN ('Philippines', 'PHL', 'Pilipinas');
+UPDATE addresses SET country = 'PK' WHERE country IN ('Pakistan', 'PAK');
+UPDATE addresses SET country = 'PL' WHERE country IN ('Poland', 'POL', 'Polska');
+UPDATE addresses SET country = 'PM' WHERE country IN ('Saint Pierre and Miquelon', 'SPM');
+UPDATE addresses SET country = 'PN' WHERE country IN ('Pitcairn', 'PCN');
+UPDATE addresses SET country = 'PR' WHERE country IN ('Puerto Rico', 'PRI');
+UPDATE addresses SET country = 'PS' WHERE country IN ('Palestine', 'PSE', 'State of Palestine');
+UPDATE addresses SET country = 'PT' WHERE country IN ('Portugal', 'PRT');
+UPDATE addresses SET country = 'PW' WHERE country IN ('Palau', 'PLW');
+UPDATE addresses SET country = 'PY' WHERE country IN ('Paraguay', 'PRY');
+UPDATE addresses SET country = 'QA' WHERE country IN ('Qatar', 'QAT');
+UPDATE addresses SET country = 'RE' WHERE country IN ('Réunion', 'REU');
+UPDATE addresses SET country = 'RO' WHERE country IN ('Romania', 'ROU');
+UPDATE addresses SET country = 'RS' WHERE country IN ('Serbia', 'SRB');
+UPDATE addresses SET country = 'RU' WHERE country IN ('Russia', 'RUS', 'Russian Federation');
+UPDATE addresses SET country = 'RW' WHERE country IN ('Rwanda', 'RWA');
+UPDATE addresses SET country = 'SA' WHERE country IN ('Saudi Arabia', 'SAU', 'KSA');
+UPDATE addresses SET country = 'SB' WHERE country IN ('Solomon Islands', 'SLB');
+UPDATE addresses SET country = 'SC' WHERE country IN ('Seychelles', 'SYC');
+UPDATE addresses SET country = 'SD' WHERE country IN ('Sudan', 'SDN');
+UPDATE addresses SET country = 'SE' WHERE country IN ('Sweden', 'SWE', 'Sverige');
+UPDATE addresses SET country = 'SG' WHERE country IN ('Singapore', 'SGP');
+UPDATE addresses SET country = 'SH' WHERE country IN ('Saint Helena, Ascension and Tristan da Cunha', 'SHN', 'Saint Helena');
+UPDATE addresses SET country = 'SI' WHERE country IN ('Slovenia', 'SVN');
+UPDATE addresses SET country = 'SJ' WHERE country IN ('Svalbard and Jan Mayen', 'SJM');
+UPDATE addresses SET country = 'SK' WHERE country IN ('Slovakia', 'SVK');
+UPDATE addresses SET country = 'SL' WHERE country IN ('Sierra Leone', 'SLE');
+UPDATE addresses SET country = 'SM' WHERE country IN ('San Marino', 'SMR');
+UPDATE addresses SET country = 'SN' WHERE country IN ('Senegal', 'SEN');
+UPDATE addresses SET country = 'SO' WHERE country IN ('Somalia', 'SOM');
+UPDATE addresses SET country = 'SR' WHERE country IN ('Suriname', 'SUR');
+UPDATE addresses SET country = 'SS' WHERE country IN ('South Sudan', 'SSD');
+UPDATE addresses SET country = 'ST' WHERE country IN ('Sao Tome and Principe', 'STP');
+UPDATE addresses SET country = 'SV' WHERE country IN ('El Salvador', 'SLV');
+UPDATE addresses SET country = 'SX' WHERE country IN ('Sint Maarten (Dutch part)', 'SXM', 'Sint Maarten');
+UPDATE addresses SET country = 'SY' WHERE country IN ('Syria', 'SYR', 'Syrian Arab Republic');
+UPDATE addresses SET country = 'SZ' WHERE country IN ('Eswatini', 'SWZ', 'Swaziland');
+UPDATE addresses SET country = 'TC' WHERE country IN ('Turks and Caicos Islands', 'TCA');
+UPDATE addresses SET country = 'TD' WHERE country IN ('Chad', 'TCD');
+UPDATE addresses SET country = 'TF' WHERE country IN ('French Southern Territories', 'ATF');
+UPDATE addresses SET country = 'TG' WHERE country IN ('Togo', 'TGO');
+UPDATE addresses SET country = 'TH' WHERE country IN ('Thailand', 'THA');
+UPDATE addresses SET country = 'TJ' WHERE country IN ('Tajikistan', 'TJK');
+UPDATE addresses SET country = 'TK' WHERE country IN ('Tokelau', 'TKL');
+UPDATE addresses SET country = 'TL' WHERE country IN ('Timor-Leste', 'TLS', 'East Timor');
+UPDATE addresses SET country = 'TM' WHERE country IN ('Turkmenistan', 'TKM');
+UPDATE addresses SET country = 'TN' WHERE country IN ('Tunisia', 'TUN');
+UPDATE addresses SET country = 'TO' WHERE country IN ('Tonga', 'TON');
+UPDATE addresses SET country = 'TR' WHERE country IN ('Türkiye', 'TUR', 'Turkey');
+UPDATE addresses SET country = 'TT' WHERE country IN ('Trinidad and Tobago', 'TTO');
+UPDATE addresses SET country = 'TV' WHERE country IN ('Tuvalu', 'TUV');
+UPDATE addresses SET country = 'TW' WHERE country IN ('Taiwan', 'TWN');
+UPDATE addresses SET country = 'TZ' WHERE country IN ('Tanzania', 'TZA', 'United Republic of Tanzania');
+UPDATE addresses SET country = 'UA' WHERE country IN ('Ukraine', 'UKR');
+UPDATE addresses SET country = 'UG' WHERE country IN ('Uganda', 'UGA');
+UPDATE addresses SET country = 'UM' WHERE country IN ('United States Minor Outlying Islands', 'UMI');
+UPDATE addresses SET country = 'US' WHERE country IN ('United States', 'USA', 'United States of America', 'America', 'U.S.A.', 'U.S.');
+UPDATE addresses SET country = 'UY' WHERE country IN ('Uruguay', 'URY');
+UPDATE addresses SET country = 'UZ' WHERE country IN ('Uzbekistan', 'UZB');
+UPDATE addresses SET country = 'VA' WHERE country IN ('Holy See', 'VAT', 'Vatican', 'Vatican City');
+UPDATE addresses SET country = 'VC' WHERE country IN ('Saint Vincent and the Grenadines', 'VCT');
+UPDATE addresses SET country = 'VE' WHERE country IN ('Venezuela', 'VEN');
+UPDATE addresses SET country = 'VG' WHERE country IN ('Virgin Islands (British)', 'VGB', 'British Virgin Islands');
+UPDATE addresses SET country = 'VI' WHERE country IN ('Virgin Islands (U.S.)', 'VIR', 'US Virgin Islands');
+UPDATE addresses SET country = 'VN' WHERE country IN ('Viet Nam', 'VNM', 'Vietnam');
+UPDATE addresses SET country = 'VU' WHERE country IN ('Vanuatu', 'VUT');
+UPDATE addresses SET country = 'WF' WHERE country IN ('Wallis and Futuna', 'WLF');
+UPDATE addresses SET country = 'WS' WHERE country IN ('Samoa', 'WSM');
+UPDATE addresses SET country = 'YE' WHERE country IN ('Yemen', 'YEM');
+UPDATE addresses SET country = 'YT' WHERE country IN ('Mayotte', 'MYT');
+UPDATE addresses SET country = 'ZA' WHERE country IN ('South Africa', 'ZAF');
+UPDATE addresses SET country = 'ZM' WHERE country IN ('Zambia', 'ZMB');
+UPDATE addresses SET country = 'ZW' WHERE country IN ('Zimbabwe', 'ZWE');
@@ -0,0 +1,5 @@
+DROP INDEX idx_addresses_coordinates ON addresses;
+
+ALTER TABLE addresses
+    DROP COLUMN longitude,
+    DROP COLUMN latitude;
@@ -0,0 +1,5 @@
+ALTER TABLE addresses
+    ADD COLUMN latitude DECIMAL(9,6) NULL AFTER is_primary,
+    ADD COLUMN longitude DECIMAL(9,6) NULL AFTER latitude;
+
+CREATE INDEX idx_addresses_coordinates ON addresses (latitude, longitude);
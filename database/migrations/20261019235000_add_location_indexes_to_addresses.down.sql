@@ -0,0 +1,2 @@
+DROP INDEX idx_addresses_postal_code ON addresses;
+DROP INDEX idx_addresses_country_city ON addresses;
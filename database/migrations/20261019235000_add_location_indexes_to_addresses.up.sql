@@ -0,0 +1,2 @@
+CREATE INDEX idx_addresses_country_city ON addresses (country, city);
+CREATE INDEX idx_addresses_postal_code ON addresses (postal_code);
@@ -0,0 +1,165 @@
+package activities
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityHandler interface {
+	GetActivities(c *gin.Context)
+	CreateActivity(c *gin.Context)
+	UpdateActivity(c *gin.Context)
+	DeleteActivity(c *gin.Context)
+}
+
+type activityHandler struct {
+	svc ActivityService
+}
+
+func NewActivityHandler(svc ActivityService) ActivityHandler {
+	return &activityHandler{svc: svc}
+}
+
+func (h *activityHandler) GetActivities(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	intPage, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || intPage < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	intLimit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || intLimit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	activity_type := c.DefaultQuery("type", "")
+	switch activity_type {
+	case "", TypeNote, TypeCall, TypeMeeting, TypeEmail:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type"})
+		return
+	}
+
+	response, err := h.svc.GetActivities(user_id.(uint), c.GetUint("workspace_id"), uint(intId), intPage, intLimit, activity_type)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Activities retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *activityHandler) CreateActivity(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	var request CreateActivityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activity, err := h.svc.CreateActivity(user_id.(uint), c.GetUint("workspace_id"), uint(intId), request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Activity created successfully",
+		"data":    activity,
+	})
+}
+
+func (h *activityHandler) UpdateActivity(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	intActivityId, err := strconv.Atoi(c.Param("activity_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity_id"})
+		return
+	}
+
+	var request UpdateActivityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activity, err := h.svc.UpdateActivity(user_id.(uint), c.GetUint("workspace_id"), uint(intId), uint(intActivityId), request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Activity updated successfully",
+		"data":    activity,
+	})
+}
+
+func (h *activityHandler) DeleteActivity(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	intActivityId, err := strconv.Atoi(c.Param("activity_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity_id"})
+		return
+	}
+
+	if err := h.svc.DeleteActivity(user_id.(uint), c.GetUint("workspace_id"), uint(intId), uint(intActivityId)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Activity deleted successfully",
+	})
+}
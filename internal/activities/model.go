@@ -0,0 +1,54 @@
+package activities
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TypeNote    = "note"
+	TypeCall    = "call"
+	TypeMeeting = "meeting"
+	TypeEmail   = "email"
+)
+
+// Activity is an entry on a contact's timeline.
+type Activity struct {
+	ID         uint           `gorm:"column:activity_id;primaryKey" json:"id"`
+	ContactID  uint           `gorm:"not null;index:idx_activities_contact_occurred" json:"contact_id"`
+	AuthorID   uint           `gorm:"not null;index" json:"author_id"`
+	Author     users.User     `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"author"`
+	Type       string         `gorm:"type:varchar(10);not null" json:"type"`
+	Body       string         `gorm:"type:text;not null;index:ft_activities_search,class:FULLTEXT" json:"body"`
+	OccurredAt time.Time      `gorm:"not null;index:idx_activities_contact_occurred" json:"occurred_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (Activity) TableName() string {
+	return "activities"
+}
+
+type CreateActivityRequest struct {
+	Type       string     `json:"type" binding:"required,oneof=note call meeting email"`
+	Body       string     `json:"body" binding:"required,max=10000"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+type UpdateActivityRequest struct {
+	Type       string     `json:"type" binding:"omitempty,oneof=note call meeting email"`
+	Body       string     `json:"body" binding:"omitempty,max=10000"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+type GetActivitiesResponse struct {
+	Data       []Activity `json:"data"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
+	Total      int        `json:"total"`
+	TotalPages int        `json:"total_pages"`
+}
@@ -0,0 +1,112 @@
+package activities
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+type ActivityRepository interface {
+	FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	GetActivities(contact_id uint, page, limit int, activity_type string) (*GetActivitiesResponse, error)
+	FindActivityById(id, contact_id uint) (*Activity, error)
+	CreateActivity(activity *Activity) error
+	UpdateActivity(activity *Activity) error
+	DeleteActivity(id, contact_id uint) error
+}
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+func (r *activityRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.AccessibleBy(user_id, workspace_id, permission)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *activityRepository) GetActivities(contact_id uint, page, limit int, activity_type string) (*GetActivitiesResponse, error) {
+	var activities []Activity
+	var total int64
+
+	query := r.db.Model(&Activity{}).Where("contact_id = ?", contact_id)
+	if activity_type != "" {
+		query = query.Where("type = ?", activity_type)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// Newest first; the id breaks ties between entries logged at the same time.
+	err := query.Preload("Author").
+		Order("occurred_at DESC").Order("activity_id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &GetActivitiesResponse{
+		Data:       activities,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (r *activityRepository) FindActivityById(id, contact_id uint) (*Activity, error) {
+	var activity Activity
+	if err := r.db.Preload("Author").Where("activity_id = ? AND contact_id = ?", id, contact_id).First(&activity).Error; err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+func (r *activityRepository) CreateActivity(activity *Activity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Author").Create(activity).Error; err != nil {
+			return err
+		}
+		return refreshLastInteraction(tx, activity.ContactID)
+	})
+}
+
+func (r *activityRepository) UpdateActivity(activity *Activity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Author").Save(activity).Error; err != nil {
+			return err
+		}
+		return refreshLastInteraction(tx, activity.ContactID)
+	})
+}
+
+func (r *activityRepository) DeleteActivity(id, contact_id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("activity_id = ? AND contact_id = ?", id, contact_id).Delete(&Activity{}).Error; err != nil {
+			return err
+		}
+		return refreshLastInteraction(tx, contact_id)
+	})
+}
+
+// refreshLastInteraction recomputes contacts.last_interaction_at from the
+// remaining activities, so editing or deleting the latest entry moves it back.
+func refreshLastInteraction(tx *gorm.DB, contact_id uint) error {
+	latest := tx.Model(&Activity{}).Select("MAX(occurred_at)").Where("contact_id = ?", contact_id)
+	return tx.Model(&contacts.Contact{}).
+		Where("contact_id = ?", contact_id).
+		UpdateColumn("last_interaction_at", latest).Error
+}
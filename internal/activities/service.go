@@ -0,0 +1,116 @@
+package activities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+type ActivityService interface {
+	GetActivities(user_id, workspace_id, contact_id uint, page, limit int, activity_type string) (*GetActivitiesResponse, error)
+	CreateActivity(user_id, workspace_id, contact_id uint, request CreateActivityRequest) (*Activity, error)
+	UpdateActivity(user_id, workspace_id, contact_id, id uint, request UpdateActivityRequest) (*Activity, error)
+	DeleteActivity(user_id, workspace_id, contact_id, id uint) error
+}
+
+type activityService struct {
+	repo ActivityRepository
+}
+
+func NewActivityService(repo ActivityRepository) ActivityService {
+	return &activityService{repo: repo}
+}
+
+func (s *activityService) GetActivities(user_id, workspace_id, contact_id uint, page, limit int, activity_type string) (*GetActivitiesResponse, error) {
+	if err := s.checkContact(contact_id, user_id, workspace_id, contacts.PermissionView); err != nil {
+		return nil, err
+	}
+
+	response, err := s.repo.GetActivities(contact_id, page, limit, activity_type)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *activityService) CreateActivity(user_id, workspace_id, contact_id uint, request CreateActivityRequest) (*Activity, error) {
+	if err := s.checkContact(contact_id, user_id, workspace_id, contacts.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	activity := Activity{
+		ContactID:  contact_id,
+		AuthorID:   user_id,
+		Type:       request.Type,
+		Body:       request.Body,
+		OccurredAt: time.Now(),
+	}
+	if request.OccurredAt != nil {
+		activity.OccurredAt = *request.OccurredAt
+	}
+
+	if err := s.repo.CreateActivity(&activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+func (s *activityService) UpdateActivity(user_id, workspace_id, contact_id, id uint, request UpdateActivityRequest) (*Activity, error) {
+	activity, err := s.findActivity(user_id, workspace_id, contact_id, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Type != "" {
+		activity.Type = request.Type
+	}
+	if request.Body != "" {
+		activity.Body = request.Body
+	}
+	if request.OccurredAt != nil {
+		activity.OccurredAt = *request.OccurredAt
+	}
+
+	if err := s.repo.UpdateActivity(activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+func (s *activityService) DeleteActivity(user_id, workspace_id, contact_id, id uint) error {
+	if _, err := s.findActivity(user_id, workspace_id, contact_id, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteActivity(id, contact_id)
+}
+
+// checkContact makes sure the contact is in the workspace and user_id has
+// permission on it. Viewers of a workspace can read the timeline but not
+// log to it.
+func (s *activityService) checkContact(contact_id, user_id, workspace_id uint, permission string) error {
+	if _, err := s.repo.FindContactById(contact_id, user_id, workspace_id, permission); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("contact not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *activityService) findActivity(user_id, workspace_id, contact_id, id uint) (*Activity, error) {
+	if err := s.checkContact(contact_id, user_id, workspace_id, contacts.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	activity, err := s.repo.FindActivityById(id, contact_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("activity not found")
+		}
+		return nil, err
+	}
+	return activity, nil
+}
@@ -0,0 +1,40 @@
+package addresses
+
+import (
+	"errors"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+)
+
+// Normalize checks the address against the conventions of its country and
+// rewrites it in canonical form: the country as an ISO 3166-1 alpha-2 code,
+// the postal code in upper case and the state the way the country writes it.
+func Normalize(address *Address) error {
+	return normalizeLocation(&address.Country, &address.State, &address.PostalCode)
+}
+
+func normalizeLocation(country_value, state, postal_code *string) error {
+	c, err := country.Lookup(*country_value)
+	if err != nil {
+		return err
+	}
+	normalized_state, err := c.NormalizeSubdivision(*state)
+	if err != nil {
+		return err
+	}
+	normalized_postal_code, err := c.NormalizePostalCode(*postal_code)
+	if err != nil {
+		return err
+	}
+	*country_value, *state, *postal_code = c.Code, normalized_state, normalized_postal_code
+	return nil
+}
+
+// IsValidationError reports whether err comes from Normalize, as opposed
+// to a database error.
+func IsValidationError(err error) bool {
+	return errors.Is(err, country.ErrUnknownCountry) ||
+		errors.Is(err, country.ErrInvalidPostalCode) ||
+		errors.Is(err, country.ErrSubdivisionRequired) ||
+		errors.Is(err, country.ErrUnknownSubdivision)
+}
@@ -0,0 +1,170 @@
+package addresses
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DuplicatePolicy decides what happens when an address is saved that
+// matches another address of the same type on the same contact.
+type DuplicatePolicy string
+
+const (
+	// DuplicatesWarn saves the address and lists the addresses it
+	// duplicates in the response.
+	DuplicatesWarn DuplicatePolicy = "warn"
+	// DuplicatesReject refuses the address with ErrDuplicateAddress.
+	DuplicatesReject DuplicatePolicy = "reject"
+
+	// DefaultDuplicatePolicy is used when ADDRESS_DUPLICATES is not set.
+	DefaultDuplicatePolicy = DuplicatesWarn
+)
+
+var ErrDuplicateAddress = errors.New("the contact already has this address")
+
+// ParseDuplicatePolicy reads the ADDRESS_DUPLICATES setting. An empty
+// value is the default policy.
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return DefaultDuplicatePolicy, nil
+	case DuplicatesWarn, DuplicatesReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid duplicate policy %q, expected %s or %s", s, DuplicatesWarn, DuplicatesReject)
+	}
+}
+
+// abbreviations maps the folded abbreviations found in street lines to the
+// word they stand for. An empty word marks a house number prefix, which
+// is dropped: "No. 1" and "1" are the same house.
+var abbreviations = map[string]string{
+	// Indonesian
+	"jl":    "jalan",
+	"jln":   "jalan",
+	"gg":    "gang",
+	"kav":   "kavling",
+	"blk":   "blok",
+	"komp":  "komplek",
+	"kompl": "komplek",
+	"perum": "perumahan",
+	"kel":   "kelurahan",
+	"kec":   "kecamatan",
+	"no":    "",
+	"nomor": "",
+
+	// English
+	"st":     "street",
+	"ave":    "avenue",
+	"av":     "avenue",
+	"rd":     "road",
+	"blvd":   "boulevard",
+	"dr":     "drive",
+	"ln":     "lane",
+	"hwy":    "highway",
+	"pl":     "place",
+	"ct":     "court",
+	"sq":     "square",
+	"apt":    "apartment",
+	"ste":    "suite",
+	"fl":     "floor",
+	"n":      "north",
+	"s":      "south",
+	"e":      "east",
+	"w":      "west",
+	"ne":     "northeast",
+	"nw":     "northwest",
+	"se":     "southeast",
+	"sw":     "southwest",
+	"number": "",
+	"nr":     "",
+}
+
+// tidy trims a field and collapses the whitespace inside it.
+func tidy(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// MatchKey reduces a normalized address to the form used to find
+// duplicates: lower case, without accents or punctuation, with the
+// abbreviations of the street expanded. "Jl. Sudirman 1" and "JALAN
+// SUDIRMAN No. 1" have the same key.
+func MatchKey(address Address) string {
+	return strings.Join([]string{
+		address.Country,
+		address.PostalCode,
+		strings.Join(words(address.State), " "),
+		strings.Join(words(address.City), " "),
+		strings.Join(expand(words(address.Street)), " "),
+	}, "|")
+}
+
+// expand replaces the abbreviations among words.
+func expand(list []string) []string {
+	expanded := list[:0]
+	for _, word := range list {
+		if full, ok := abbreviations[word]; ok {
+			if full == "" {
+				continue
+			}
+			word = full
+		}
+		expanded = append(expanded, word)
+	}
+	return expanded
+}
+
+// words splits s into lower-case words of letters and digits, without
+// accents.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(stripMarks(s)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func stripMarks(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// duplicatesOf returns the ids of the other addresses of the contact that
+// match address: the same type and the same MatchKey.
+func (s *addressService) duplicatesOf(address Address) ([]uint, error) {
+	existing, err := s.repo.GetContactAddresses(address.ContactID)
+	if err != nil {
+		return nil, err
+	}
+	key := MatchKey(address)
+	var duplicates []uint
+	for _, other := range existing {
+		if other.ID == address.ID || other.Type != address.Type {
+			continue
+		}
+		if MatchKey(other) == key {
+			duplicates = append(duplicates, other.ID)
+		}
+	}
+	return duplicates, nil
+}
+
+// checkDuplicates applies the duplicate policy to address and returns the
+// addresses it duplicates when the policy lets it be saved.
+func (s *addressService) checkDuplicates(address Address) ([]uint, error) {
+	duplicates, err := s.duplicatesOf(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(duplicates) > 0 && s.duplicates == DuplicatesReject {
+		return nil, fmt.Errorf("%w: address %d", ErrDuplicateAddress, duplicates[0])
+	}
+	return duplicates, nil
+}
@@ -0,0 +1,96 @@
+package addresses
+
+import (
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+)
+
+// Recipient is who a label is addressed to.
+type Recipient struct {
+	Name         string
+	Organization string
+}
+
+// FormatLines renders the address as postal text following the conventions
+// of its country: the order of the fields, which of them are upper case and
+// where the postal code goes. The last line is the country name in upper
+// case, as international mail wants it, unless the address is in from, the
+// alpha-2 code of the sender's country.
+func FormatLines(address Address, recipient Recipient, from string) []string {
+	format, upper := country.DefaultAddressFormat, ""
+	c, err := country.Lookup(address.Country)
+	if err == nil {
+		format, upper = c.Format(), c.Upper
+	}
+
+	fields := map[byte]string{
+		'N': recipient.Name,
+		'O': recipient.Organization,
+		'A': address.Street,
+		'C': address.City,
+		'S': address.State,
+		'Z': address.PostalCode,
+	}
+	for field, value := range fields {
+		value = strings.TrimSpace(value)
+		if strings.IndexByte(upper, field) >= 0 {
+			value = strings.ToUpper(value)
+		}
+		fields[field] = value
+	}
+
+	var lines []string
+	for _, line := range strings.Split(format, "%n") {
+		for _, text := range strings.Split(formatLine(line, fields), "\n") {
+			if text = strings.TrimSpace(text); text != "" {
+				lines = append(lines, text)
+			}
+		}
+	}
+
+	switch {
+	case err != nil:
+		if address.Country != "" {
+			lines = append(lines, strings.ToUpper(address.Country))
+		}
+	case c.Code != from:
+		lines = append(lines, strings.ToUpper(c.Name))
+	}
+	return lines
+}
+
+// Formatted returns the address as multi-line text, without a recipient and
+// always with the country.
+func (a Address) Formatted() string {
+	return strings.Join(FormatLines(a, Recipient{}, ""), "\n")
+}
+
+// formatLine fills in one line of an address format. The literal text in
+// front of a field goes with it, so an empty field takes its separator
+// along: "%C, %S %Z" without a state becomes "CITY 12345".
+func formatLine(line string, fields map[byte]string) string {
+	var b strings.Builder
+	literal := ""
+	written := false
+	for i := 0; i < len(line); i++ {
+		if line[i] != '%' || i+1 == len(line) {
+			literal += string(line[i])
+			continue
+		}
+		i++
+		value := fields[line[i]]
+		if value == "" {
+			literal = ""
+			continue
+		}
+		b.WriteString(literal)
+		b.WriteString(value)
+		literal = ""
+		written = true
+	}
+	if written {
+		b.WriteString(strings.TrimRight(literal, " "))
+	}
+	return b.String()
+}
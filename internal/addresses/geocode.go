@@ -0,0 +1,93 @@
+package addresses
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+)
+
+const (
+	// DefaultRadiusKm and MaxRadiusKm bound GET /addresses/nearby.
+	DefaultRadiusKm = 10
+	MaxRadiusKm     = 20000
+
+	// DefaultNearbyLimit and MaxNearbyLimit bound the number of addresses
+	// GET /addresses/nearby returns.
+	DefaultNearbyLimit = 20
+	MaxNearbyLimit     = 100
+
+	// backfillBatchSize is the number of addresses loaded per query by
+	// BackfillCoordinates.
+	backfillBatchSize = 500
+)
+
+var ErrInvalidCoordinates = errors.New("latitude and longitude must be given together, within -90..90 and -180..180")
+
+var ErrInvalidRadius = fmt.Errorf("radius_km must be greater than 0 and at most %d", MaxRadiusKm)
+
+// setCoordinates stores the coordinates the client gave. Both or neither
+// must be set; it reports whether they were.
+func setCoordinates(address *Address, latitude, longitude *float64) (bool, error) {
+	if latitude == nil && longitude == nil {
+		return false, nil
+	}
+	if latitude == nil || longitude == nil || !(geo.Point{Lat: *latitude, Lng: *longitude}).Valid() {
+		return false, ErrInvalidCoordinates
+	}
+	address.Latitude, address.Longitude = latitude, longitude
+	return true, nil
+}
+
+// Geocode locates a normalized address. The coordinates are cleared when
+// the geocoder cannot locate it, or fails: an address is saved whether or
+// not it can be placed on a map. Without a geocoder the address is left as
+// it is. The importers use it too, so every way in stores coordinates.
+func Geocode(geocoder geo.Geocoder, address *Address) {
+	if geocoder == nil {
+		return
+	}
+	address.Latitude, address.Longitude = nil, nil
+	point, err := geocoder.Geocode(geo.Query{
+		Country:    address.Country,
+		State:      address.State,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+	})
+	if err != nil {
+		return
+	}
+	address.Latitude, address.Longitude = &point.Lat, &point.Lng
+}
+
+// BackfillCoordinates locates the addresses stored before they were
+// geocoded on save. Addresses the geocoder cannot place stay without
+// coordinates. It returns how many addresses were located.
+func (s *addressService) BackfillCoordinates() (int, error) {
+	if s.geocoder == nil {
+		return 0, nil
+	}
+	located := 0
+	var after_id uint
+	for {
+		address_list, err := s.repo.GetAddressesWithoutCoordinates(after_id, backfillBatchSize)
+		if err != nil {
+			return located, err
+		}
+		for _, address := range address_list {
+			after_id = address.ID
+
+			Geocode(s.geocoder, &address)
+			if address.Latitude == nil {
+				continue
+			}
+			if err := s.repo.SetCoordinates(address.ID, *address.Latitude, *address.Longitude); err != nil {
+				return located, err
+			}
+			located++
+		}
+		if len(address_list) < backfillBatchSize {
+			return located, nil
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package addresses
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+)
+
+// exportBatchSize is the number of addresses loaded per query while
+// streaming an export.
+const exportBatchSize = 500
+
+// Feature is a located address in a GeoJSON FeatureCollection (RFC 7946).
+type Feature struct {
+	Type       string            `json:"type"`
+	ID         uint              `json:"id"`
+	Geometry   Geometry          `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// Geometry is a GeoJSON Point. Coordinates are longitude first.
+type Geometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type FeatureProperties struct {
+	AddressID   uint   `json:"address_id"`
+	ContactID   uint   `json:"contact_id"`
+	ContactName string `json:"contact_name"`
+	Street      string `json:"street"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	PostalCode  string `json:"postal_code"`
+	Country     string `json:"country"`
+	CountryName string `json:"country_name"`
+	Type        string `json:"type"`
+	IsPrimary   bool   `json:"is_primary"`
+	Formatted   string `json:"formatted"`
+}
+
+// NewFeature returns the feature of a located address, with its contact
+// loaded.
+func NewFeature(address Address) Feature {
+	return Feature{
+		Type: "Feature",
+		ID:   address.ID,
+		Geometry: Geometry{
+			Type:        "Point",
+			Coordinates: [2]float64{*address.Longitude, *address.Latitude},
+		},
+		Properties: FeatureProperties{
+			AddressID:   address.ID,
+			ContactID:   address.ContactID,
+			ContactName: strings.TrimSpace(address.Contact.FirstName + " " + address.Contact.LastName),
+			Street:      address.Street,
+			City:        address.City,
+			State:       address.State,
+			PostalCode:  address.PostalCode,
+			Country:     address.Country,
+			CountryName: country.Name(address.Country),
+			Type:        address.Type,
+			IsPrimary:   address.IsPrimary,
+			Formatted:   address.Formatted(),
+		},
+	}
+}
+
+// featureWriter writes a FeatureCollection one feature at a time, so an
+// export never holds more than a batch of addresses in memory.
+type featureWriter struct {
+	w       io.Writer
+	started bool
+	count   int
+}
+
+func (f *featureWriter) write(feature Feature) error {
+	if err := f.start(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+	if f.count > 0 {
+		data = append([]byte(","), data...)
+	}
+	f.count++
+	_, err = f.w.Write(data)
+	return err
+}
+
+func (f *featureWriter) start() error {
+	if f.started {
+		return nil
+	}
+	f.started = true
+	_, err := io.WriteString(f.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+// flush pushes what has been written so far to the client.
+func (f *featureWriter) flush() {
+	if flusher, ok := f.w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+func (f *featureWriter) close() error {
+	if err := f.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(f.w, "]}\n")
+	return err
+}
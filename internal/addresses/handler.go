@@ -1,9 +1,12 @@
 package addresses
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,6 +16,10 @@ type AddressHandler interface {
 	UpdateAddress(c *gin.Context)
 	FindAddressById(c *gin.Context)
 	DeleteAddress(c *gin.Context)
+	GetLabel(c *gin.Context)
+	Nearby(c *gin.Context)
+	ExportGeoJSON(c *gin.Context)
+	ListAddresses(c *gin.Context)
 }
 
 type addressHandler struct {
@@ -30,22 +37,27 @@ func (h *addressHandler) CreateAddress(c *gin.Context) {
 		return
 	}
 
+	contact_id, ok := contactParam(c)
+	if !ok {
+		return
+	}
+
 	var request CreateAddressRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	response, err := h.svc.CreateAddress(user_id.(uint), request)
+	response, err := h.svc.CreateAddress(user_id.(uint), c.GetUint("workspace_id"), contact_id, request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusCreated, withDuplicateWarning(gin.H{
 		"message": "Address created successfully",
 		"data":    response,
-	})
+	}, response))
 }
 
 func (h *addressHandler) GetAddresses(c *gin.Context) {
@@ -55,15 +67,8 @@ func (h *addressHandler) GetAddresses(c *gin.Context) {
 		return
 	}
 
-	contact_id := c.Query("contact_id")
-	if contact_id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "contact_id is required"})
-		return
-	}
-
-	intContactId, err := strconv.Atoi(contact_id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact_id"})
+	contact_id, ok := contactParam(c)
+	if !ok {
 		return
 	}
 
@@ -83,9 +88,9 @@ func (h *addressHandler) GetAddresses(c *gin.Context) {
 		return
 	}
 
-	response, err := h.svc.GetAddresses(user_id.(uint), uint(intContactId), intPage, intLimit, search)
+	response, err := h.svc.GetAddresses(user_id.(uint), c.GetUint("workspace_id"), contact_id, intPage, intLimit, search)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -102,16 +107,20 @@ func (h *addressHandler) FindAddressById(c *gin.Context) {
 		return
 	}
 
-	id := c.Param("id")
-	intId, err := strconv.Atoi(id)
+	contact_id, ok := contactParam(c)
+	if !ok {
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("address_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address id"})
 		return
 	}
 
-	address, err := h.svc.FindAddressById(user_id.(uint), uint(intId))
+	address, err := h.svc.FindAddressById(user_id.(uint), c.GetUint("workspace_id"), contact_id, uint(intId))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -128,10 +137,14 @@ func (h *addressHandler) UpdateAddress(c *gin.Context) {
 		return
 	}
 
-	id := c.Param("id")
-	intId, err := strconv.Atoi(id)
+	contact_id, ok := contactParam(c)
+	if !ok {
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("address_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address id"})
 		return
 	}
 
@@ -141,16 +154,16 @@ func (h *addressHandler) UpdateAddress(c *gin.Context) {
 		return
 	}
 
-	response, err := h.svc.UpdateAddress(user_id.(uint), uint(intId), request)
+	response, err := h.svc.UpdateAddress(user_id.(uint), c.GetUint("workspace_id"), contact_id, uint(intId), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, withDuplicateWarning(gin.H{
 		"message": "Address updated successfully",
 		"data":    response,
-	})
+	}, response))
 }
 
 func (h *addressHandler) DeleteAddress(c *gin.Context) {
@@ -160,16 +173,20 @@ func (h *addressHandler) DeleteAddress(c *gin.Context) {
 		return
 	}
 
-	id := c.Param("id")
-	intId, err := strconv.Atoi(id)
+	contact_id, ok := contactParam(c)
+	if !ok {
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("address_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address id"})
 		return
 	}
 
-	err = h.svc.DeleteAddress(user_id.(uint), uint(intId))
+	err = h.svc.DeleteAddress(user_id.(uint), c.GetUint("workspace_id"), contact_id, uint(intId))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -177,3 +194,206 @@ func (h *addressHandler) DeleteAddress(c *gin.Context) {
 		"message": "Address deleted successfully",
 	})
 }
+
+// GetLabel answers with the address as plain text, one line per printed
+// line, or as JSON with ?format=json.
+func (h *addressHandler) GetLabel(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	label, err := h.svc.GetLabel(user_id.(uint), c.GetUint("workspace_id"), uint(intId), c.Query("from"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Label created successfully",
+			"data":    label,
+		})
+		return
+	}
+	c.String(http.StatusOK, strings.Join(label.Lines, "\n")+"\n")
+}
+
+// Nearby lists the addresses within radius_km (default 10) of lat and lng,
+// nearest first.
+func (h *addressHandler) Nearby(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lat"})
+		return
+	}
+
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lng"})
+		return
+	}
+
+	radius, err := strconv.ParseFloat(c.DefaultQuery("radius_km", strconv.Itoa(DefaultRadiusKm)), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radius_km"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(DefaultNearbyLimit)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	response, err := h.svc.Nearby(user_id.(uint), c.GetUint("workspace_id"), geo.Point{Lat: lat, Lng: lng}, radius, limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Nearby addresses retrieved successfully",
+		"data":    response,
+	})
+}
+
+// ExportGeoJSON streams the located addresses as a GeoJSON
+// FeatureCollection, with the filters of ListAddresses.
+func (h *addressHandler) ExportGeoJSON(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	query, ok := addressQuery(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/geo+json")
+	c.Header("Content-Disposition", `attachment; filename="addresses.geojson"`)
+
+	if err := h.svc.ExportGeoJSON(user_id.(uint), c.GetUint("workspace_id"), query, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		// Features may already be on the wire, so the status can no longer change.
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// ListAddresses lists the addresses of all the user's contacts, filtered
+// by city, state, country, postal_code prefix, type, contact_id and search,
+// and sorted by sort and order.
+func (h *addressHandler) ListAddresses(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intPage, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	intLimit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	query, ok := addressQuery(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.ListAddresses(user_id.(uint), c.GetUint("workspace_id"), intPage, intLimit, query)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Addresses retrieved successfully",
+		"data":    response,
+	})
+}
+
+// addressQuery reads the filters shared by ListAddresses and
+// ExportGeoJSON, answering 400 when contact_id is not a number.
+func addressQuery(c *gin.Context) (AddressQuery, bool) {
+	query := AddressQuery{
+		Search:     c.Query("search"),
+		City:       c.Query("city"),
+		State:      c.Query("state"),
+		Country:    c.Query("country"),
+		PostalCode: c.Query("postal_code"),
+		Type:       c.Query("type"),
+		Sort:       c.Query("sort"),
+		Order:      c.Query("order"),
+	}
+	if raw := c.Query("contact_id"); raw != "" {
+		intContactId, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact id"})
+			return query, false
+		}
+		query.ContactID = uint(intContactId)
+	}
+	return query, true
+}
+
+// withDuplicateWarning adds a warning to the body when the saved address
+// duplicates another address of the contact.
+func withDuplicateWarning(body gin.H, response *AddressResponse) gin.H {
+	if len(response.DuplicateOf) > 0 {
+		body["warning"] = "The contact already has this address"
+	}
+	return body
+}
+
+// contactParam reads the contact id of the nested /contacts/:id/addresses
+// routes, answering 400 when it is not a number.
+func contactParam(c *gin.Context) (uint, bool) {
+	intContactId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact id"})
+		return 0, false
+	}
+	return uint(intContactId), true
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, ErrContactNotFound) || errors.Is(err, ErrAddressNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, ErrDuplicateAddress) {
+		return http.StatusConflict
+	}
+	if IsValidationError(err) || errors.Is(err, ErrInvalidCoordinates) || errors.Is(err, ErrInvalidRadius) ||
+		errors.Is(err, ErrInvalidSort) || errors.Is(err, ErrInvalidType) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,159 @@
+package addresses
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sorts of GET /addresses.
+const (
+	SortCity       = "city"
+	SortState      = "state"
+	SortCountry    = "country"
+	SortPostalCode = "postal_code"
+	SortType       = "type"
+	SortContact    = "contact"
+	SortCreatedAt  = "created_at"
+)
+
+// sortColumns is the whitelist of sort= values of GET /addresses.
+var sortColumns = map[string][]string{
+	SortCity:       {"addresses.city"},
+	SortState:      {"addresses.state"},
+	SortCountry:    {"addresses.country"},
+	SortPostalCode: {"addresses.postal_code"},
+	SortType:       {"addresses.type"},
+	SortContact:    {"contacts.first_name", "contacts.last_name"},
+	SortCreatedAt:  {"addresses.created_at"},
+}
+
+// MaxLimit bounds the page size of GET /addresses.
+const MaxLimit = 100
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+var ErrInvalidType = fmt.Errorf("type must be one of %s", strings.Join(Types, ", "))
+
+// AddressQuery holds the filters of GET /addresses and GET
+// /addresses/export.geojson as sent by the client. Country may be given any
+// way country.Lookup accepts and PostalCode matches as a prefix. ContactID,
+// when not 0, limits the list to one contact.
+type AddressQuery struct {
+	ContactID  uint
+	Search     string
+	City       string
+	State      string
+	Country    string
+	PostalCode string
+	Type       string
+	Sort       string
+	Order      string
+}
+
+// AddressFilter is an AddressQuery with its values normalized the way
+// addresses are stored and its sort resolved to columns.
+type AddressFilter struct {
+	ContactID   uint
+	Search      string
+	City        string
+	State       string
+	Country     string
+	PostalCode  string
+	Type        string
+	SortColumns []string
+	Order       string
+}
+
+// resolveQuery validates the query and turns it into a filter.
+func resolveQuery(query AddressQuery) (AddressFilter, error) {
+	filter := AddressFilter{
+		ContactID: query.ContactID,
+		Search:    query.Search,
+		City:      strings.TrimSpace(query.City),
+		State:     strings.TrimSpace(query.State),
+		Type:      strings.ToLower(strings.TrimSpace(query.Type)),
+		Order:     strings.ToLower(query.Order),
+	}
+	if filter.Type != "" && !ValidType(filter.Type) {
+		return filter, fmt.Errorf("%w, got %q", ErrInvalidType, filter.Type)
+	}
+
+	filter.PostalCode = strings.ToUpper(strings.Join(strings.Fields(query.PostalCode), " "))
+	// States are stored the way their country writes them, so with
+	// country=US, state=California finds the addresses stored as CA.
+	if strings.TrimSpace(query.Country) != "" {
+		c, err := country.Lookup(query.Country)
+		if err != nil {
+			return filter, err
+		}
+		filter.Country = c.Code
+		if filter.State != "" {
+			if filter.State, err = c.NormalizeSubdivision(filter.State); err != nil {
+				return filter, err
+			}
+		}
+	}
+
+	if filter.Order == "" {
+		filter.Order = "asc"
+	}
+	if filter.Order != "asc" && filter.Order != "desc" {
+		return filter, fmt.Errorf("%w: order must be asc or desc", ErrInvalidSort)
+	}
+	if query.Sort != "" {
+		columns, ok := sortColumns[query.Sort]
+		if !ok {
+			return filter, fmt.Errorf("%w: %q", ErrInvalidSort, query.Sort)
+		}
+		filter.SortColumns = columns
+	}
+	return filter, nil
+}
+
+// filtered keeps the addresses matching every field set in the filter. The
+// query must join contacts.
+func filtered(filter AddressFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Scopes(matching(filter.Search))
+		if filter.ContactID != 0 {
+			db = db.Where("addresses.contact_id = ?", filter.ContactID)
+		}
+		if filter.City != "" {
+			db = db.Where("addresses.city = ?", filter.City)
+		}
+		if filter.State != "" {
+			db = db.Where("addresses.state = ?", filter.State)
+		}
+		if filter.Country != "" {
+			db = db.Where("addresses.country = ?", filter.Country)
+		}
+		if filter.PostalCode != "" {
+			db = db.Where("addresses.postal_code LIKE ?", escapeLike(filter.PostalCode)+"%")
+		}
+		if filter.Type != "" {
+			db = db.Where("addresses.type = ?", filter.Type)
+		}
+		return db
+	}
+}
+
+// sorted orders the query by the filter's sort columns, then by id so
+// pages stay stable when the columns tie.
+func sorted(filter AddressFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, column := range filter.SortColumns {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Raw: true, Name: column}, Desc: filter.Order == "desc"})
+		}
+		return db.Order("addresses.address_id")
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
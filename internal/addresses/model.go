@@ -1,62 +1,169 @@
 package addresses
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
 
 	"gorm.io/gorm"
 )
 
+// Address types. A contact has at most one primary address per type.
+const (
+	TypeHome     = "home"
+	TypeWork     = "work"
+	TypeBilling  = "billing"
+	TypeShipping = "shipping"
+	TypeOther    = "other"
+)
+
+// Types lists the address types in display order.
+var Types = []string{TypeHome, TypeWork, TypeBilling, TypeShipping, TypeOther}
+
+// ValidType reports whether t is one of Types.
+func ValidType(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Address is a postal address of a contact. Latitude and Longitude are set
+// by the geocoder or given by the client, and are nil when the address
+// could not be located. PrimaryType is generated by the
+// database as Type while the address is a live primary and NULL otherwise;
+// its unique index with ContactID allows one primary per type.
 type Address struct {
-	ID         uint             `gorm:"column:address_id;primaryKey" json:"id"`
-	ContactID  uint             `gorm:"not null;index" json:"contact_id"`
-	Contact    contacts.Contact `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
-	Street     string           `gorm:"type:varchar(255)" json:"street"`
-	City       string           `gorm:"type:varchar(255)" json:"city"`
-	State      string           `gorm:"type:varchar(255)" json:"state"`
-	PostalCode string           `gorm:"type:varchar(20)" json:"postal_code"`
-	Country    string           `gorm:"type:varchar(100);not null" json:"country"`
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
-	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
+	ID          uint             `gorm:"column:address_id;primaryKey" json:"id"`
+	ContactID   uint             `gorm:"not null;index;uniqueIndex:idx_addresses_primary_type,priority:1" json:"contact_id"`
+	Contact     contacts.Contact `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Street      string           `gorm:"type:varchar(255);index:ft_addresses_search,class:FULLTEXT" json:"street"`
+	City        string           `gorm:"type:varchar(255);index:ft_addresses_search,class:FULLTEXT;index:idx_addresses_country_city,priority:2" json:"city"`
+	State       string           `gorm:"type:varchar(255);index:ft_addresses_search,class:FULLTEXT" json:"state"`
+	PostalCode  string           `gorm:"type:varchar(20);index:ft_addresses_search,class:FULLTEXT;index:idx_addresses_postal_code" json:"postal_code"`
+	Country     string           `gorm:"type:varchar(100);not null;index:ft_addresses_search,class:FULLTEXT;index:idx_addresses_country_city,priority:1" json:"country"`
+	Type        string           `gorm:"type:varchar(20);not null;default:other" json:"type"`
+	IsPrimary   bool             `gorm:"not null;default:false" json:"is_primary"`
+	PrimaryType *string          `gorm:"->;type:varchar(20) GENERATED ALWAYS AS (IF(is_primary AND deleted_at IS NULL, type, NULL)) STORED;uniqueIndex:idx_addresses_primary_type,priority:2" json:"-"`
+	Latitude    *float64         `gorm:"type:decimal(9,6);index:idx_addresses_coordinates,priority:1" json:"latitude"`
+	Longitude   *float64         `gorm:"type:decimal(9,6);index:idx_addresses_coordinates,priority:2" json:"longitude"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt   `gorm:"index" json:"-"`
 }
 
 func (Address) TableName() string {
 	return "addresses"
 }
 
+// MarshalJSON adds the display name of the country next to its code and
+// the address formatted for its country.
+func (a Address) MarshalJSON() ([]byte, error) {
+	type address Address
+	return json.Marshal(struct {
+		address
+		CountryName string `json:"country_name"`
+		Formatted   string `json:"formatted"`
+	}{address(a), country.Name(a.Country), a.Formatted()})
+}
+
+// Snapshot returns the fields recorded in the contact history.
+func (a Address) Snapshot() map[string]string {
+	return map[string]string{
+		"street":      a.Street,
+		"city":        a.City,
+		"state":       a.State,
+		"postal_code": a.PostalCode,
+		"country":     a.Country,
+		"type":        a.Type,
+	}
+}
+
+// CreateAddressRequest is the body of POST /contacts/:id/addresses. ContactID
+// comes from the path.
 type CreateAddressRequest struct {
-	ContactID  uint   `json:"contact_id" binding:"required"`
-	Street     string `json:"street" binding:"omitempty,max=255"`
-	City       string `json:"city" binding:"omitempty,max=100"`
-	State      string `json:"state" binding:"omitempty,max=100"`
-	PostalCode string `json:"postal_code" binding:"omitempty,max=20"`
-	Country    string `json:"country" binding:"required,max=100"`
+	ContactID  uint     `json:"-"`
+	Street     string   `json:"street" binding:"omitempty,max=255"`
+	City       string   `json:"city" binding:"omitempty,max=100"`
+	State      string   `json:"state" binding:"omitempty,max=100"`
+	PostalCode string   `json:"postal_code" binding:"omitempty,max=20"`
+	Country    string   `json:"country" binding:"required,max=100"`
+	Type       string   `json:"type" binding:"omitempty,oneof=home work billing shipping other"`
+	IsPrimary  bool     `json:"is_primary"`
+	Latitude   *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude  *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
 }
 
 type UpdateAddressRequest struct {
-	Street     string `json:"street" binding:"omitempty,max=255"`
-	City       string `json:"city" binding:"omitempty,max=100"`
-	State      string `json:"state" binding:"omitempty,max=100"`
-	PostalCode string `json:"postal_code" binding:"omitempty,max=20"`
-	Country    string `json:"country" binding:"omitempty,max=100"`
+	Street     string   `json:"street" binding:"omitempty,max=255"`
+	City       string   `json:"city" binding:"omitempty,max=100"`
+	State      string   `json:"state" binding:"omitempty,max=100"`
+	PostalCode string   `json:"postal_code" binding:"omitempty,max=20"`
+	Country    string   `json:"country" binding:"omitempty,max=100"`
+	Type       string   `json:"type" binding:"omitempty,oneof=home work billing shipping other"`
+	IsPrimary  *bool    `json:"is_primary"`
+	Latitude   *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude  *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
 }
 
 type AddressResponse struct {
-	ID         uint   `json:"id"`
-	ContactID  uint   `json:"contact_id"`
-	Street     string `json:"street"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	PostalCode string `json:"postal_code"`
-	Country    string `json:"country"`
+	ID          uint     `json:"id"`
+	ContactID   uint     `json:"contact_id"`
+	Street      string   `json:"street"`
+	City        string   `json:"city"`
+	State       string   `json:"state"`
+	PostalCode  string   `json:"postal_code"`
+	Country     string   `json:"country"`
+	CountryName string   `json:"country_name"`
+	Type        string   `json:"type"`
+	IsPrimary   bool     `json:"is_primary"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Formatted   string   `json:"formatted"`
+	// DuplicateOf lists the addresses of the contact this one duplicates,
+	// when the duplicate policy lets it be saved anyway.
+	DuplicateOf []uint `json:"duplicate_of,omitempty"`
+}
+
+func newAddressResponse(address *Address) *AddressResponse {
+	return &AddressResponse{
+		ID:          address.ID,
+		ContactID:   address.ContactID,
+		Street:      address.Street,
+		City:        address.City,
+		State:       address.State,
+		PostalCode:  address.PostalCode,
+		Country:     address.Country,
+		CountryName: country.Name(address.Country),
+		Type:        address.Type,
+		IsPrimary:   address.IsPrimary,
+		Latitude:    address.Latitude,
+		Longitude:   address.Longitude,
+		Formatted:   address.Formatted(),
+	}
+}
+
+// Label is an address printed for an envelope or a shipping label.
+type Label struct {
+	AddressID uint     `json:"address_id"`
+	Lines     []string `json:"lines"`
+}
+
+// NearbyAddress is an address found by GET /addresses/nearby with its
+// distance from the searched point.
+type NearbyAddress struct {
+	Address    Address `json:"address"`
+	DistanceKm float64 `json:"distance_km"`
 }
 
 type GetAddressesResponse struct {
 	Addresses []Address `json:"addresses"`
-	Page      int               `json:"page"`
-	Limit     int               `json:"limit"`
-	Total     int               `json:"total"`
-	TotalPage int               `json:"total_page"`
-}
\ No newline at end of file
+	Page      int       `json:"page"`
+	Limit     int       `json:"limit"`
+	Total     int       `json:"total"`
+	TotalPage int       `json:"total_page"`
+}
@@ -1,18 +1,27 @@
 package addresses
 
 import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
 
 	"gorm.io/gorm"
 )
 
 type AddressRepository interface {
-	CreateAddress(address CreateAddressRequest) (*AddressResponse, error)
+	CreateAddress(user_id uint, address CreateAddressRequest) (*AddressResponse, error)
 	GetAddresses(contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error)
-	UpdateAddress(address_id uint, address *Address) (*AddressResponse, error)
-	FindAddressById(address_id uint) (*Address, error)
-	DeleteAddress(address_id uint) error
-	FindContactById(id, user_id uint) (*contacts.Contact, error)
+	UpdateAddress(user_id, address_id uint, address *Address) (*AddressResponse, error)
+	FindAddressById(address_id, user_id, workspace_id uint, permission string) (*Address, error)
+	FindContactAddress(contact_id, address_id uint) (*Address, error)
+	GetContactAddresses(contact_id uint) ([]Address, error)
+	DeleteAddress(user_id, address_id uint) error
+	FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	GetAddressesWithin(user_id, workspace_id uint, box geo.Box) ([]Address, error)
+	EachLocatedAddressBatch(user_id, workspace_id uint, filter AddressFilter, fn func(address_list []Address) error) error
+	ListAddresses(user_id, workspace_id uint, page, limit int, filter AddressFilter) (*GetAddressesResponse, error)
+	GetAddressesWithoutCoordinates(after_id uint, limit int) ([]Address, error)
+	SetCoordinates(address_id uint, latitude, longitude float64) error
 }
 
 type addressRepository struct {
@@ -23,7 +32,7 @@ func NewAddressRepository(db *gorm.DB) AddressRepository {
 	return &addressRepository{db: db}
 }
 
-func (a *addressRepository) CreateAddress(address CreateAddressRequest) (*AddressResponse, error) {
+func (a *addressRepository) CreateAddress(user_id uint, address CreateAddressRequest) (*AddressResponse, error) {
 	address_db := Address{
 		ContactID:  address.ContactID,
 		Street:     address.Street,
@@ -31,20 +40,25 @@ func (a *addressRepository) CreateAddress(address CreateAddressRequest) (*Addres
 		State:      address.State,
 		PostalCode: address.PostalCode,
 		Country:    address.Country,
+		Type:       address.Type,
+		IsPrimary:  address.IsPrimary,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
 	}
 
-	if err := a.db.Create(&address_db).Error; err != nil {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := demotePrimary(tx, &address_db); err != nil {
+			return err
+		}
+		if err := tx.Create(&address_db).Error; err != nil {
+			return err
+		}
+		return Record(tx, user_id, &address_db, history.ActionCreate, nil)
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &AddressResponse{
-		ID:         address_db.ID,
-		ContactID:  address_db.ContactID,
-		Street:     address_db.Street,
-		City:       address_db.City,
-		State:      address_db.State,
-		PostalCode: address_db.PostalCode,
-		Country:    address_db.Country,
-	}, nil
+	return newAddressResponse(&address_db), nil
 }
 
 func (a *addressRepository) GetAddresses(contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error) {
@@ -52,10 +66,7 @@ func (a *addressRepository) GetAddresses(contact_id uint, page int, limit int, s
 	var total int64
 
 	// Build query dengan search filter
-	query := a.db.Model(&Address{}).Where("contact_id = ?", contact_id)
-	if search != "" {
-		query = query.Where("street LIKE ? OR city LIKE ? OR state LIKE ? OR postal_code LIKE ? OR country LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
-	}
+	query := a.db.Model(&Address{}).Where("contact_id = ?", contact_id).Scopes(matching(search))
 
 	// Count total records (sebelum pagination)
 	if err := query.Count(&total).Error; err != nil {
@@ -82,44 +93,212 @@ func (a *addressRepository) GetAddresses(contact_id uint, page int, limit int, s
 	}, nil
 }
 
-func (a *addressRepository) UpdateAddress(address_id uint, address *Address) (*AddressResponse, error) {
+func (a *addressRepository) UpdateAddress(user_id, address_id uint, address *Address) (*AddressResponse, error) {
 	// Set ID to ensure update, not create
 	address.ID = address_id
 
-	if err := a.db.Save(&address).Error; err != nil {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		var address_db Address
+		if err := tx.Where("address_id = ?", address_id).First(&address_db).Error; err != nil {
+			return err
+		}
+		if err := demotePrimary(tx, address); err != nil {
+			return err
+		}
+		if err := tx.Save(&address).Error; err != nil {
+			return err
+		}
+		return Record(tx, user_id, address, history.ActionUpdate, address_db.Snapshot())
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &AddressResponse{
-		ID:         address.ID,
-		ContactID:  address.ContactID,
-		Street:     address.Street,
-		City:       address.City,
-		State:      address.State,
-		PostalCode: address.PostalCode,
-		Country:    address.Country,
-	}, nil
+	return newAddressResponse(address), nil
+}
+
+// FindAddressById finds an address whose contact is in the active workspace
+// and which user_id may access with permission. The contact is loaded with
+// its company.
+func (a *addressRepository) FindAddressById(address_id, user_id, workspace_id uint, permission string) (*Address, error) {
+	var address Address
+	err := a.db.Preload("Contact.Company").Joins("JOIN contacts ON contacts.contact_id = addresses.contact_id AND contacts.deleted_at IS NULL").
+		Scopes(contacts.AccessibleBy(user_id, workspace_id, permission)).
+		Where("addresses.address_id = ?", address_id).
+		First(&address).Error
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
 }
 
-func (a *addressRepository) FindAddressById(address_id uint) (*Address, error) {
+// FindContactAddress finds an address of the given contact. Access to the
+// contact is checked by the caller.
+func (a *addressRepository) FindContactAddress(contact_id, address_id uint) (*Address, error) {
 	var address Address
-	if err := a.db.Where("address_id = ?", address_id).First(&address).Error; err != nil {
+	if err := a.db.Where("address_id = ? AND contact_id = ?", address_id, contact_id).First(&address).Error; err != nil {
 		return nil, err
 	}
 	return &address, nil
 }
 
-func (a *addressRepository) DeleteAddress(address_id uint) error {
-	if err := a.db.Where("address_id = ?", address_id).Delete(&Address{}).Error; err != nil {
-		return err
+func (a *addressRepository) GetContactAddresses(contact_id uint) ([]Address, error) {
+	addresses := []Address{}
+	if err := a.db.Where("contact_id = ?", contact_id).Order("address_id").Find(&addresses).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return addresses, nil
+}
+
+func (a *addressRepository) DeleteAddress(user_id, address_id uint) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		var address_db Address
+		if err := tx.Where("address_id = ?", address_id).First(&address_db).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&address_db).Error; err != nil {
+			return err
+		}
+		return Record(tx, user_id, &address_db, history.ActionDelete, nil)
+	})
 }
 
-func (a *addressRepository) FindContactById(id uint, user_id uint) (*contacts.Contact, error) {
+func (a *addressRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 	contact_db := contacts.Contact{}
-	err := a.db.Where("contact_id = ? AND user_id = ?", id, user_id).First(&contact_db).Error
+	err := a.db.Scopes(contacts.AccessibleBy(user_id, workspace_id, permission)).Where("contact_id = ?", id).First(&contact_db).Error
 	if err != nil {
 		return nil, err
 	}
 	return &contact_db, nil
 }
+
+// GetAddressesWithin returns the addresses inside box whose contact is in
+// the active workspace and visible to user_id.
+func (a *addressRepository) GetAddressesWithin(user_id, workspace_id uint, box geo.Box) ([]Address, error) {
+	query := a.visible(user_id, workspace_id).
+		Where("addresses.latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.CrossesAntimeridian() {
+		query = query.Where("(addresses.longitude >= ? OR addresses.longitude <= ?)", box.MinLng, box.MaxLng)
+	} else {
+		query = query.Where("addresses.longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+	}
+
+	var addresses []Address
+	if err := query.Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// EachLocatedAddressBatch walks the addresses with coordinates whose
+// contact is in the active workspace and visible to user_id, in primary key
+// order, and calls fn with every batch. Each address has its contact
+// loaded.
+func (a *addressRepository) EachLocatedAddressBatch(user_id, workspace_id uint, filter AddressFilter, fn func(address_list []Address) error) error {
+	query := a.visible(user_id, workspace_id).Preload("Contact").
+		Scopes(filtered(filter)).
+		Where("addresses.latitude IS NOT NULL AND addresses.longitude IS NOT NULL")
+
+	var batch []Address
+	result := query.FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
+// ListAddresses pages through the addresses of every contact in the active
+// workspace that user_id can see.
+func (a *addressRepository) ListAddresses(user_id, workspace_id uint, page, limit int, filter AddressFilter) (*GetAddressesResponse, error) {
+	addresses := []Address{}
+	var total int64
+
+	query := a.visible(user_id, workspace_id).Model(&Address{}).Scopes(filtered(filter))
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	if err := query.Scopes(sorted(filter)).Offset((page - 1) * limit).Limit(limit).Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &GetAddressesResponse{
+		Addresses: addresses,
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: totalPages,
+	}, nil
+}
+
+// visible starts a query on the addresses whose contact is live, in the
+// active workspace and visible to user_id.
+func (a *addressRepository) visible(user_id, workspace_id uint) *gorm.DB {
+	return a.db.Joins("JOIN contacts ON contacts.contact_id = addresses.contact_id AND contacts.deleted_at IS NULL").
+		Scopes(contacts.AccessibleBy(user_id, workspace_id, contacts.PermissionView))
+}
+
+// matching keeps the addresses with search in any of their fields.
+func matching(search string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if search == "" {
+			return db
+		}
+		like := "%" + search + "%"
+		return db.Where("addresses.street LIKE ? OR addresses.city LIKE ? OR addresses.state LIKE ? OR addresses.postal_code LIKE ? OR addresses.country LIKE ?", like, like, like, like, like)
+	}
+}
+
+// demotePrimary unsets the current primary address of the contact for the
+// type of address when address is about to become it. It runs in the
+// transaction that saves address, so the contact is never left with two
+// primaries or none in between.
+func demotePrimary(tx *gorm.DB, address *Address) error {
+	if !address.IsPrimary {
+		return nil
+	}
+	return tx.Model(&Address{}).
+		Where("contact_id = ? AND type = ? AND is_primary = ? AND address_id <> ?", address.ContactID, address.Type, true, address.ID).
+		Update("is_primary", false).Error
+}
+
+// Record adds an address revision to the history of its contact. Creates
+// list every field as new; deletes carry no changes. The importers and the
+// merge record their address writes with it too.
+func Record(tx *gorm.DB, user_id uint, address *Address, action string, before map[string]string) error {
+	revision := history.Revision{
+		ContactID: address.ContactID,
+		Entity:    history.EntityAddress,
+		EntityID:  address.ID,
+		Action:    action,
+		AuthorID:  user_id,
+	}
+	if action != history.ActionDelete {
+		revision.Changes = history.Diff(before, address.Snapshot())
+	}
+	return history.Record(tx, &revision)
+}
+
+// GetAddressesWithoutCoordinates pages by id through the addresses, trashed
+// ones included, that have no coordinates.
+func (a *addressRepository) GetAddressesWithoutCoordinates(after_id uint, limit int) ([]Address, error) {
+	var address_list []Address
+	err := a.db.Unscoped().
+		Where("address_id > ? AND latitude IS NULL", after_id).
+		Order("address_id").
+		Limit(limit).
+		Find(&address_list).Error
+	if err != nil {
+		return nil, err
+	}
+	return address_list, nil
+}
+
+func (a *addressRepository) SetCoordinates(address_id uint, latitude, longitude float64) error {
+	return a.db.Unscoped().Model(&Address{}).Where("address_id = ?", address_id).UpdateColumns(map[string]any{
+		"latitude":  latitude,
+		"longitude": longitude,
+	}).Error
+}
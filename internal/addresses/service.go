@@ -2,59 +2,107 @@ package addresses
 
 import (
 	"errors"
+	"io"
+	"sort"
+	"strings"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
 	"gorm.io/gorm"
 )
 
 type AddressService interface {
-	CreateAddress(user_id uint, address CreateAddressRequest) (*AddressResponse, error)
-	GetAddresses(user_id, contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error)
-	UpdateAddress(user_id, address_id uint, address UpdateAddressRequest) (*AddressResponse, error)
-	FindAddressById(user_id, address_id uint) (*Address, error)
-	DeleteAddress(user_id, address_id uint) error
+	CreateAddress(user_id, workspace_id, contact_id uint, address CreateAddressRequest) (*AddressResponse, error)
+	GetAddresses(user_id, workspace_id, contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error)
+	UpdateAddress(user_id, workspace_id, contact_id, address_id uint, address UpdateAddressRequest) (*AddressResponse, error)
+	FindAddressById(user_id, workspace_id, contact_id, address_id uint) (*Address, error)
+	DeleteAddress(user_id, workspace_id, contact_id, address_id uint) error
+	IncludeAddresses(contact *contacts.Contact) (any, error)
+	GetLabel(user_id, workspace_id, address_id uint, from string) (*Label, error)
+	Nearby(user_id, workspace_id uint, center geo.Point, radius_km float64, limit int) ([]NearbyAddress, error)
+	ExportGeoJSON(user_id, workspace_id uint, query AddressQuery, w io.Writer) error
+	ListAddresses(user_id, workspace_id uint, page, limit int, query AddressQuery) (*GetAddressesResponse, error)
+	BackfillCoordinates() (int, error)
 }
 
 type addressService struct {
-	repo AddressRepository
+	repo       AddressRepository
+	geocoder   geo.Geocoder
+	duplicates DuplicatePolicy
 }
 
-func NewAddressService(repo AddressRepository) AddressService {
-	return &addressService{repo: repo}
+// NewAddressService returns the address service. Addresses saved without
+// coordinates are located with geocoder; with a nil geocoder they keep
+// only the coordinates the client gives. duplicates decides what happens
+// to an address the contact already has; empty is DefaultDuplicatePolicy.
+func NewAddressService(repo AddressRepository, geocoder geo.Geocoder, duplicates DuplicatePolicy) AddressService {
+	if duplicates == "" {
+		duplicates = DefaultDuplicatePolicy
+	}
+	return &addressService{repo: repo, geocoder: geocoder, duplicates: duplicates}
 }
 
-func (s *addressService) CreateAddress(user_id uint, address CreateAddressRequest) (*AddressResponse, error) {
-	contact_db, err := s.repo.FindContactById(address.ContactID, user_id)
+// Every method checks once that user_id may access the contact in the path
+// with the permission it needs; the address is then looked up within that
+// contact only.
+
+func (s *addressService) CreateAddress(user_id, workspace_id, contact_id uint, address CreateAddressRequest) (*AddressResponse, error) {
+	contact_db, err := s.findContact(contact_id, user_id, workspace_id, contacts.PermissionEdit)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("contact not found")
-		}
 		return nil, err
 	}
 
 	if contact_db == nil {
-		return nil, errors.New("contact not found")
+		return nil, ErrContactNotFound
 	}
 
-	result, err := s.repo.CreateAddress(address)
+	address.ContactID = contact_id
+	if address.Type == "" {
+		address.Type = TypeOther
+	}
+	address.Street, address.City = tidy(address.Street), tidy(address.City)
+	if err := normalizeLocation(&address.Country, &address.State, &address.PostalCode); err != nil {
+		return nil, err
+	}
+	located := Address{
+		ContactID:  contact_id,
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Type:       address.Type,
+	}
+	duplicates, err := s.checkDuplicates(located)
+	if err != nil {
+		return nil, err
+	}
+	given, err := setCoordinates(&located, address.Latitude, address.Longitude)
 	if err != nil {
 		return nil, err
 	}
+	if !given {
+		Geocode(s.geocoder, &located)
+	}
+	address.Latitude, address.Longitude = located.Latitude, located.Longitude
+	result, err := s.repo.CreateAddress(user_id, address)
+	if err != nil {
+		return nil, err
+	}
+	result.DuplicateOf = duplicates
 	return result, nil
 }
 
-func (s *addressService) GetAddresses(user_id, contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error) {
-	_, err := s.repo.FindContactById(contact_id, user_id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("contact not found")
-		}
+func (s *addressService) GetAddresses(user_id, workspace_id, contact_id uint, page int, limit int, search string) (*GetAddressesResponse, error) {
+	if _, err := s.findContact(contact_id, user_id, workspace_id, contacts.PermissionView); err != nil {
 		return nil, err
 	}
-	
+
 	address, err := s.repo.GetAddresses(contact_id, page, limit, search)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("contact not found")
+			return nil, ErrContactNotFound
 		}
 		return nil, err
 	}
@@ -62,14 +110,12 @@ func (s *addressService) GetAddresses(user_id, contact_id uint, page int, limit
 	return address, nil
 }
 
-func (s *addressService) UpdateAddress(user_id, address_id uint, address UpdateAddressRequest) (*AddressResponse, error) {
-	address_db, err := s.repo.FindAddressById(address_id)
+func (s *addressService) UpdateAddress(user_id, workspace_id, contact_id, address_id uint, address UpdateAddressRequest) (*AddressResponse, error) {
+	address_db, err := s.findAddress(contact_id, address_id, user_id, workspace_id, contacts.PermissionEdit)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("address not found")
-		}
 		return nil, err
 	}
+	location := [4]string{address_db.Country, address_db.State, address_db.City, address_db.PostalCode}
 
 	if address.City != "" {
 		address_db.City = address.City
@@ -91,45 +137,233 @@ func (s *addressService) UpdateAddress(user_id, address_id uint, address UpdateA
 		address_db.Street = address.Street
 	}
 
-	result, err := s.repo.UpdateAddress(address_id, address_db)
+	if address.Type != "" {
+		address_db.Type = address.Type
+	}
+
+	if address.IsPrimary != nil {
+		address_db.IsPrimary = *address.IsPrimary
+	}
+
+	address_db.Street, address_db.City = tidy(address_db.Street), tidy(address_db.City)
+	if err := Normalize(address_db); err != nil {
+		return nil, err
+	}
+	duplicates, err := s.checkDuplicates(*address_db)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address is located again when it moved, or was never located.
+	given, err := setCoordinates(address_db, address.Latitude, address.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	moved := location != [4]string{address_db.Country, address_db.State, address_db.City, address_db.PostalCode}
+	if !given && (moved || address_db.Latitude == nil) {
+		Geocode(s.geocoder, address_db)
+	}
+
+	result, err := s.repo.UpdateAddress(user_id, address_id, address_db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("address not found")
+			return nil, ErrAddressNotFound
 		}
 		return nil, err
 	}
 
+	result.DuplicateOf = duplicates
 	return result, nil
 }
 
-func (s *addressService) FindAddressById(user_id, address_id uint) (*Address, error) {
-	result, err := s.repo.FindAddressById(address_id)
+func (s *addressService) FindAddressById(user_id, workspace_id, contact_id, address_id uint) (*Address, error) {
+	return s.findAddress(contact_id, address_id, user_id, workspace_id, contacts.PermissionView)
+}
+
+func (s *addressService) DeleteAddress(user_id, workspace_id, contact_id, address_id uint) error {
+	if _, err := s.findAddress(contact_id, address_id, user_id, workspace_id, contacts.PermissionEdit); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteAddress(user_id, address_id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("address not found")
+			return ErrAddressNotFound
 		}
-		return nil, err
+		return err
 	}
 
-	return result, nil
+	return nil
 }
 
-func (s *addressService) DeleteAddress(user_id, address_id uint) error {
-	_, err := s.repo.FindAddressById(address_id)
+// IncludeAddresses lists the addresses of a contact the caller has already
+// loaded, for GET /contacts/:id?include=addresses.
+func (s *addressService) IncludeAddresses(contact *contacts.Contact) (any, error) {
+	return s.repo.GetContactAddresses(contact.ID)
+}
+
+// GetLabel formats an address for printing, addressed to its contact and
+// the contact's company. from is the country the mail is sent from, given
+// any way country.Lookup accepts; the country line is left out when it is
+// the address's own country.
+func (s *addressService) GetLabel(user_id, workspace_id, address_id uint, from string) (*Label, error) {
+	if from != "" {
+		from_country, err := country.Lookup(from)
+		if err != nil {
+			return nil, err
+		}
+		from = from_country.Code
+	}
+
+	address_db, err := s.repo.FindAddressById(address_id, user_id, workspace_id, contacts.PermissionView)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("address not found")
+			return nil, ErrAddressNotFound
 		}
+		return nil, err
+	}
+
+	recipient := Recipient{Name: strings.TrimSpace(address_db.Contact.FirstName + " " + address_db.Contact.LastName)}
+	if address_db.Contact.Company != nil {
+		recipient.Organization = address_db.Contact.Company.Name
+	}
+	return &Label{AddressID: address_db.ID, Lines: FormatLines(*address_db, recipient, from)}, nil
+}
+
+// Nearby returns the located addresses user_id can see within radius_km of
+// center, nearest first. The repository narrows the search to the
+// bounding box of the circle; the exact distance is computed here.
+func (s *addressService) Nearby(user_id, workspace_id uint, center geo.Point, radius_km float64, limit int) ([]NearbyAddress, error) {
+	if !center.Valid() {
+		return nil, ErrInvalidCoordinates
+	}
+	if radius_km == 0 {
+		radius_km = DefaultRadiusKm
+	}
+	if radius_km < 0 || radius_km > MaxRadiusKm {
+		return nil, ErrInvalidRadius
+	}
+	if limit < 1 {
+		limit = DefaultNearbyLimit
+	}
+	if limit > MaxNearbyLimit {
+		limit = MaxNearbyLimit
+	}
+
+	addresses, err := s.repo.GetAddressesWithin(user_id, workspace_id, geo.Around(center, radius_km))
+	if err != nil {
+		return nil, err
+	}
+
+	nearby := []NearbyAddress{}
+	for _, address := range addresses {
+		if address.Latitude == nil || address.Longitude == nil {
+			continue
+		}
+		distance := geo.Distance(center, geo.Point{Lat: *address.Latitude, Lng: *address.Longitude})
+		if distance <= radius_km {
+			nearby = append(nearby, NearbyAddress{Address: address, DistanceKm: distance})
+		}
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
+
+// ExportGeoJSON writes the located addresses user_id can see as a GeoJSON
+// FeatureCollection, filtered like GET /addresses. Addresses without
+// coordinates are left out. Nothing is written before the query and its
+// contact are checked, so those errors can still be answered with an error
+// status.
+func (s *addressService) ExportGeoJSON(user_id, workspace_id uint, query AddressQuery, w io.Writer) error {
+	filter, err := s.resolveQuery(user_id, workspace_id, query)
+	if err != nil {
 		return err
 	}
 
-	err = s.repo.DeleteAddress(address_id)
+	writer := &featureWriter{w: w}
+	err = s.repo.EachLocatedAddressBatch(user_id, workspace_id, filter, func(address_list []Address) error {
+		for _, address := range address_list {
+			if err := writer.write(NewFeature(address)); err != nil {
+				return err
+			}
+		}
+		writer.flush()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return writer.close()
+}
+
+// ListAddresses lists the addresses of every contact user_id can see in
+// the active workspace, for questions like "all my contacts in Bandung".
+func (s *addressService) ListAddresses(user_id, workspace_id uint, page, limit int, query AddressQuery) (*GetAddressesResponse, error) {
+	filter, err := s.resolveQuery(user_id, workspace_id, query)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return s.repo.ListAddresses(user_id, workspace_id, page, limit, filter)
+}
+
+// resolveQuery validates a list query and, when it names a contact, checks
+// that user_id can see it.
+func (s *addressService) resolveQuery(user_id, workspace_id uint, query AddressQuery) (AddressFilter, error) {
+	filter, err := resolveQuery(query)
+	if err != nil {
+		return filter, err
+	}
+	if filter.ContactID != 0 {
+		if _, err := s.findContact(filter.ContactID, user_id, workspace_id, contacts.PermissionView); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// ErrContactNotFound and ErrAddressNotFound are returned when the contact in
+// the path, or the address within it, does not exist or user_id may not
+// access it.
+var (
+	ErrContactNotFound = errors.New("contact not found")
+	ErrAddressNotFound = errors.New("address not found")
+)
+
+func (s *addressService) findContact(contact_id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	contact_db, err := s.repo.FindContactById(contact_id, user_id, workspace_id, permission)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("contact not found")
+			return nil, ErrContactNotFound
 		}
-		return err
+		return nil, err
 	}
+	return contact_db, nil
+}
 
-	return nil
-}
\ No newline at end of file
+func (s *addressService) findAddress(contact_id, address_id, user_id, workspace_id uint, permission string) (*Address, error) {
+	if _, err := s.findContact(contact_id, user_id, workspace_id, permission); err != nil {
+		return nil, err
+	}
+	address_db, err := s.repo.FindContactAddress(contact_id, address_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	return address_db, nil
+}
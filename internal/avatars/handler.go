@@ -0,0 +1,221 @@
+package avatars
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for the multipart headers around the image.
+const multipartOverhead = 64 << 10
+
+type AvatarHandler interface {
+	UploadContactAvatar(c *gin.Context)
+	GetContactAvatar(c *gin.Context)
+	DeleteContactAvatar(c *gin.Context)
+	UploadMyAvatar(c *gin.Context)
+	GetUserAvatar(c *gin.Context)
+	DeleteMyAvatar(c *gin.Context)
+}
+
+type avatarHandler struct {
+	svc AvatarService
+}
+
+func NewAvatarHandler(svc AvatarService) AvatarHandler {
+	return &avatarHandler{svc: svc}
+}
+
+func (h *avatarHandler) UploadContactAvatar(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	avatar, err := h.svc.UploadContactAvatar(uint(intId), user_id.(uint), c.GetUint("workspace_id"), data)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar uploaded successfully",
+		"data":    avatar,
+	})
+}
+
+func (h *avatarHandler) GetContactAvatar(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	image, err := h.svc.GetContactAvatar(uint(intId), user_id.(uint), c.GetUint("workspace_id"), c.Query("size"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, image.ContentType, image.Data)
+}
+
+func (h *avatarHandler) DeleteContactAvatar(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	if err := h.svc.DeleteContactAvatar(uint(intId), user_id.(uint), c.GetUint("workspace_id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar deleted successfully",
+	})
+}
+
+func (h *avatarHandler) UploadMyAvatar(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	avatar, err := h.svc.UploadUserAvatar(user_id.(uint), data)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar uploaded successfully",
+		"data":    avatar,
+	})
+}
+
+func (h *avatarHandler) GetUserAvatar(c *gin.Context) {
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	image, err := h.svc.GetUserAvatar(uint(intId), c.Query("size"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, image.ContentType, image.Data)
+}
+
+func (h *avatarHandler) DeleteMyAvatar(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.svc.DeleteUserAvatar(user_id.(uint)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar deleted successfully",
+	})
+}
+
+// readUpload reads the image from the "file" field of a multipart form or,
+// for other content types, from the raw body. It writes the error response
+// itself and reports whether the caller should continue.
+func readUpload(c *gin.Context) ([]byte, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxUploadSize+multipartOverhead)
+
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			var too_large *http.MaxBytesError
+			if errors.As(err, &too_large) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": ErrTooLarge.Error()})
+				return nil, false
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return nil, false
+		}
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		defer opened.Close()
+		body = opened
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var too_large *http.MaxBytesError
+		if errors.As(err, &too_large) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": ErrTooLarge.Error()})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return data, true
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, contacts.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrInvalidImage), errors.Is(err, ErrInvalidSize):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,110 @@
+package avatars
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+var ErrTooLarge = fmt.Errorf("image must not be larger than %d MB", MaxUploadSize>>20)
+
+var ErrUnsupportedType = errors.New("image must be a JPEG, PNG or GIF")
+
+var ErrInvalidImage = errors.New("image could not be read")
+
+// decoders are the formats accepted, keyed by sniffed content type.
+var decoders = map[string]func(data []byte) (image.Image, error){
+	"image/jpeg": func(data []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) },
+	"image/png":  func(data []byte) (image.Image, error) { return png.Decode(bytes.NewReader(data)) },
+	"image/gif":  func(data []byte) (image.Image, error) { return gif.Decode(bytes.NewReader(data)) },
+}
+
+// Decode checks size, type and dimensions before decoding the image. The
+// content type comes from the data itself, never from the upload headers.
+func Decode(data []byte) (image.Image, string, error) {
+	if len(data) > MaxUploadSize {
+		return nil, "", ErrTooLarge
+	}
+
+	content_type := http.DetectContentType(data)
+	decode, ok := decoders[content_type]
+	if !ok {
+		return nil, "", ErrUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrInvalidImage
+	}
+	if config.Width < 1 || config.Height < 1 || config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, "", fmt.Errorf("%w: dimensions must be at most %dx%d", ErrInvalidImage, MaxDimension, MaxDimension)
+	}
+
+	img, err := decode(data)
+	if err != nil {
+		return nil, "", ErrInvalidImage
+	}
+	return img, content_type, nil
+}
+
+// Thumbnail crops the centre square of src and scales it down to size by
+// averaging the source pixels each target pixel covers. Images smaller than
+// size are not enlarged.
+func Thumbnail(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+	size = min(size, side)
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), src, crop.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, (y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, (x+1)*side/size
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					offset := square.PixOffset(sx, sy)
+					pix := square.Pix[offset : offset+4]
+					r += uint32(pix[0])
+					g += uint32(pix[1])
+					b += uint32(pix[2])
+					a += uint32(pix[3])
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+// Encode writes a thumbnail as JPEG, or as PNG when the source format can
+// carry transparency.
+func Encode(img image.Image, content_type string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if content_type == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
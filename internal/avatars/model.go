@@ -0,0 +1,66 @@
+package avatars
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+const (
+	// MaxUploadSize is the largest image accepted, in bytes.
+	MaxUploadSize = 5 << 20
+	// MaxDimension bounds width and height so a small file cannot decode
+	// into a huge bitmap.
+	MaxDimension = 6000
+
+	SizeOriginal = "original"
+	SizeMedium   = "medium"
+	SizeThumb    = "thumb"
+)
+
+// thumbnailSizes are the square sizes rendered on upload, in pixels.
+var thumbnailSizes = map[string]int{
+	SizeMedium: 512,
+	SizeThumb:  128,
+}
+
+// Avatar is the image of either a contact or a user. The blobs live in the
+// BlobStore below Key, one per size; the row only describes them.
+type Avatar struct {
+	ID          uint              `gorm:"column:avatar_id;primaryKey" json:"id"`
+	ContactID   *uint             `gorm:"uniqueIndex" json:"contact_id,omitempty"`
+	Contact     *contacts.Contact `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	UserID      *uint             `gorm:"uniqueIndex" json:"user_id,omitempty"`
+	User        *users.User       `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Key         string            `gorm:"type:varchar(255);not null" json:"-"`
+	ContentType string            `gorm:"type:varchar(50);not null" json:"content_type"`
+	Width       int               `gorm:"not null" json:"width"`
+	Height      int               `gorm:"not null" json:"height"`
+	Size        int               `gorm:"not null" json:"size"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+func (Avatar) TableName() string {
+	return "avatars"
+}
+
+// BlobKey returns the key of one size of the avatar.
+func (a Avatar) BlobKey(size string) string {
+	return a.Key + "/" + size
+}
+
+// Owner identifies whose avatar is meant: exactly one of the ids is set.
+type Owner struct {
+	ContactID *uint
+	UserID    *uint
+}
+
+func ContactOwner(contact_id uint) Owner {
+	return Owner{ContactID: &contact_id}
+}
+
+func UserOwner(user_id uint) Owner {
+	return Owner{UserID: &user_id}
+}
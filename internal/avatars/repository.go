@@ -0,0 +1,65 @@
+package avatars
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AvatarRepository interface {
+	FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	FindAvatar(owner Owner) (*Avatar, error)
+	FindContactAvatars(contact_ids []uint) ([]Avatar, error)
+	SaveAvatar(avatar *Avatar) error
+	DeleteAvatar(id uint) error
+}
+
+type avatarRepository struct {
+	db *gorm.DB
+}
+
+func NewAvatarRepository(db *gorm.DB) AvatarRepository {
+	return &avatarRepository{db: db}
+}
+
+func (r *avatarRepository) FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.AccessibleBy(user_id, workspace_id, permission)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *avatarRepository) FindAvatar(owner Owner) (*Avatar, error) {
+	var avatar Avatar
+	query := r.db
+	if owner.ContactID != nil {
+		query = query.Where("contact_id = ?", *owner.ContactID)
+	} else {
+		query = query.Where("user_id = ?", *owner.UserID)
+	}
+	if err := query.First(&avatar).Error; err != nil {
+		return nil, err
+	}
+	return &avatar, nil
+}
+
+func (r *avatarRepository) FindContactAvatars(contact_ids []uint) ([]Avatar, error) {
+	var avatar_list []Avatar
+	if err := r.db.Where("contact_id IN ?", contact_ids).Find(&avatar_list).Error; err != nil {
+		return nil, err
+	}
+	return avatar_list, nil
+}
+
+// SaveAvatar creates the avatar or replaces the one its owner already has.
+func (r *avatarRepository) SaveAvatar(avatar *Avatar) error {
+	return r.db.Omit("Contact", "User").Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"key", "content_type", "width", "height", "size", "updated_at"}),
+	}).Create(avatar).Error
+}
+
+func (r *avatarRepository) DeleteAvatar(id uint) error {
+	return r.db.Where("avatar_id = ?", id).Delete(&Avatar{}).Error
+}
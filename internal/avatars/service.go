@@ -0,0 +1,216 @@
+package avatars
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/blob"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/utils"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidSize = fmt.Errorf("size must be %s, %s or %s", SizeOriginal, SizeMedium, SizeThumb)
+
+type AvatarService interface {
+	UploadContactAvatar(id, user_id, workspace_id uint, data []byte) (*Avatar, error)
+	GetContactAvatar(id, user_id, workspace_id uint, size string) (*blob.Blob, error)
+	DeleteContactAvatar(id, user_id, workspace_id uint) error
+	DeleteContactAvatars(contact_ids []uint) error
+	UploadUserAvatar(user_id uint, data []byte) (*Avatar, error)
+	GetUserAvatar(user_id uint, size string) (*blob.Blob, error)
+	DeleteUserAvatar(user_id uint) error
+}
+
+type avatarService struct {
+	repo  AvatarRepository
+	store blob.BlobStore
+}
+
+func NewAvatarService(repo AvatarRepository, store blob.BlobStore) AvatarService {
+	return &avatarService{repo: repo, store: store}
+}
+
+func (s *avatarService) UploadContactAvatar(id, user_id, workspace_id uint, data []byte) (*Avatar, error) {
+	if err := s.checkContact(id, user_id, workspace_id, contacts.PermissionEdit); err != nil {
+		return nil, err
+	}
+	return s.upload(ContactOwner(id), fmt.Sprintf("avatars/contacts/%d", id), data)
+}
+
+func (s *avatarService) GetContactAvatar(id, user_id, workspace_id uint, size string) (*blob.Blob, error) {
+	if err := s.checkContact(id, user_id, workspace_id, contacts.PermissionView); err != nil {
+		return nil, err
+	}
+	return s.get(ContactOwner(id), size)
+}
+
+func (s *avatarService) DeleteContactAvatar(id, user_id, workspace_id uint) error {
+	if err := s.checkContact(id, user_id, workspace_id, contacts.PermissionEdit); err != nil {
+		return err
+	}
+	return s.remove(ContactOwner(id))
+}
+
+// DeleteContactAvatars removes the avatars of contacts that are about to be
+// deleted for good. The caller has checked access already; the row would go
+// with the contact, but the files would stay in the store.
+func (s *avatarService) DeleteContactAvatars(contact_ids []uint) error {
+	if len(contact_ids) == 0 {
+		return nil
+	}
+	avatar_list, err := s.repo.FindContactAvatars(contact_ids)
+	if err != nil {
+		return err
+	}
+	for _, avatar := range avatar_list {
+		if err := s.repo.DeleteAvatar(avatar.ID); err != nil {
+			return err
+		}
+		s.deleteBlobs(avatar)
+	}
+	return nil
+}
+
+func (s *avatarService) UploadUserAvatar(user_id uint, data []byte) (*Avatar, error) {
+	return s.upload(UserOwner(user_id), fmt.Sprintf("avatars/users/%d", user_id), data)
+}
+
+func (s *avatarService) GetUserAvatar(user_id uint, size string) (*blob.Blob, error) {
+	return s.get(UserOwner(user_id), size)
+}
+
+func (s *avatarService) DeleteUserAvatar(user_id uint) error {
+	return s.remove(UserOwner(user_id))
+}
+
+// checkContact makes sure the contact is visible to user_id and, for edit,
+// that they may change it.
+func (s *avatarService) checkContact(id, user_id, workspace_id uint, permission string) error {
+	if _, err := s.repo.FindContact(id, user_id, workspace_id, contacts.PermissionView); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("contact not found")
+		}
+		return err
+	}
+	if permission == contacts.PermissionView {
+		return nil
+	}
+	if _, err := s.repo.FindContact(id, user_id, workspace_id, permission); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return contacts.ErrForbidden
+		}
+		return err
+	}
+	return nil
+}
+
+// upload stores the original and every thumbnail under a fresh key, then
+// points the avatar row at it. The previous blobs are removed only once the
+// row no longer references them.
+func (s *avatarService) upload(owner Owner, prefix string, data []byte) (*Avatar, error) {
+	img, content_type, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	avatar := Avatar{
+		ContactID:   owner.ContactID,
+		UserID:      owner.UserID,
+		Key:         prefix + "/" + utils.GenerateToken(),
+		ContentType: content_type,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Size:        len(data),
+	}
+
+	if err := s.store.Put(avatar.BlobKey(SizeOriginal), blob.Blob{Data: data, ContentType: content_type}); err != nil {
+		return nil, err
+	}
+	for size, pixels := range thumbnailSizes {
+		thumb, thumb_type, err := Encode(Thumbnail(img, pixels), content_type)
+		if err == nil {
+			err = s.store.Put(avatar.BlobKey(size), blob.Blob{Data: thumb, ContentType: thumb_type})
+		}
+		if err != nil {
+			s.deleteBlobs(avatar)
+			return nil, err
+		}
+	}
+
+	previous, err := s.repo.FindAvatar(owner)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.deleteBlobs(avatar)
+		return nil, err
+	}
+	if err := s.repo.SaveAvatar(&avatar); err != nil {
+		s.deleteBlobs(avatar)
+		return nil, err
+	}
+	if previous != nil {
+		s.deleteBlobs(*previous)
+	}
+
+	saved, err := s.repo.FindAvatar(owner)
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *avatarService) get(owner Owner, size string) (*blob.Blob, error) {
+	if size == "" {
+		size = SizeOriginal
+	}
+	if _, ok := thumbnailSizes[size]; !ok && size != SizeOriginal {
+		return nil, ErrInvalidSize
+	}
+
+	avatar, err := s.repo.FindAvatar(owner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("avatar not found")
+		}
+		return nil, err
+	}
+
+	data, err := s.store.Get(avatar.BlobKey(size))
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, errors.New("avatar not found")
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *avatarService) remove(owner Owner) error {
+	avatar, err := s.repo.FindAvatar(owner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("avatar not found")
+		}
+		return err
+	}
+	if err := s.repo.DeleteAvatar(avatar.ID); err != nil {
+		return err
+	}
+	s.deleteBlobs(*avatar)
+	return nil
+}
+
+// deleteBlobs removes every size of an avatar. Failures only leave unused
+// files behind, so they are logged rather than returned.
+func (s *avatarService) deleteBlobs(avatar Avatar) {
+	keys := []string{avatar.BlobKey(SizeOriginal)}
+	for size := range thumbnailSizes {
+		keys = append(keys, avatar.BlobKey(size))
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(key); err != nil {
+			log.Printf("avatars: deleting %s failed: %v", key, err)
+		}
+	}
+}
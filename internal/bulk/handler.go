@@ -0,0 +1,52 @@
+package bulk
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/gin-gonic/gin"
+)
+
+type BulkHandler interface {
+	Run(c *gin.Context)
+}
+
+type bulkHandler struct {
+	svc BulkService
+}
+
+func NewBulkHandler(svc BulkService) BulkHandler {
+	return &bulkHandler{svc: svc}
+}
+
+func (h *bulkHandler) Run(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request BulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.Run(user_id.(uint), c.GetUint("workspace_id"), request)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRequest) || errors.Is(err, ErrBatchTooLarge) || errors.Is(err, contacts.ErrInvalidTag) ||
+			errors.Is(err, contacts.ErrInvalidSort) || errors.Is(err, customfields.ErrInvalidValue) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bulk request processed",
+		"data":    response,
+	})
+}
@@ -0,0 +1,62 @@
+package bulk
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// MaxBatchSize is the most contacts one request may change.
+const MaxBatchSize = 500
+
+const (
+	ActionDelete     = "delete"
+	ActionRestore    = "restore"
+	ActionTag        = "tag"
+	ActionUntag      = "untag"
+	ActionAddToGroup = "add_to_group"
+	ActionUpdate     = "update"
+)
+
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+	// StatusRolledBack marks an item that succeeded but was undone because
+	// another item of an all-or-nothing batch failed.
+	StatusRolledBack = "rolled_back"
+)
+
+// Filter selects contacts the way GET /contacts does. Custom fields are
+// matched by key.
+type Filter struct {
+	Search       string            `json:"search"`
+	CompanyID    uint              `json:"company_id"`
+	Tag          string            `json:"tag"`
+	CustomFields map[string]string `json:"cf"`
+}
+
+// BulkRequest applies one action to the contacts in IDs or, instead, to the
+// contacts matching Filter. Tags is used by tag and untag, GroupID by
+// add_to_group and Fields by update, with the semantics of PUT /contacts/:id.
+type BulkRequest struct {
+	Action       string                         `json:"action" binding:"required,oneof=delete restore tag untag add_to_group update"`
+	IDs          []uint                         `json:"ids" binding:"max=500"`
+	Filter       *Filter                        `json:"filter"`
+	Tags         []string                       `json:"tags"`
+	GroupID      uint                           `json:"group_id"`
+	Fields       *contacts.UpdateContactRequest `json:"fields"`
+	AllOrNothing bool                           `json:"all_or_nothing"`
+}
+
+type ItemResult struct {
+	ID     uint   `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Action     string       `json:"action"`
+	Total      int          `json:"total"`
+	Succeeded  int          `json:"succeeded"`
+	Failed     int          `json:"failed"`
+	RolledBack bool         `json:"rolled_back"`
+	Results    []ItemResult `json:"results"`
+}
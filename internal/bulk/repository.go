@@ -0,0 +1,55 @@
+package bulk
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/trash"
+
+	"gorm.io/gorm"
+)
+
+// Services are what an item of a batch runs against. They are bound to the
+// batch transaction, so every rule of the single-contact endpoints applies.
+type Services struct {
+	Contacts contacts.ContactService
+	Trash    trash.TrashService
+	Groups   groups.GroupService
+}
+
+// ItemRunner runs one item of a batch in a savepoint of the batch
+// transaction, so a failing item leaves nothing half done.
+type ItemRunner func(item func(services Services) error) error
+
+type BulkRepository interface {
+	Transaction(fn func(run ItemRunner) error) error
+}
+
+type bulkRepository struct {
+	db        *gorm.DB
+	avatars   trash.AvatarRemover
+	retention time.Duration
+}
+
+// NewBulkRepository returns the bulk repository. avatars and retention are
+// passed on to the trash service of each item, as configured for /trash.
+func NewBulkRepository(db *gorm.DB, avatars trash.AvatarRemover, retention time.Duration) BulkRepository {
+	return &bulkRepository{db: db, avatars: avatars, retention: retention}
+}
+
+// Transaction runs fn in one database transaction. Everything is rolled back
+// when fn returns an error.
+func (r *bulkRepository) Transaction(fn func(run ItemRunner) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(func(item func(services Services) error) error {
+			return tx.Transaction(func(savepoint *gorm.DB) error {
+				return item(Services{
+					Contacts: contacts.NewContactService(contacts.NewContactRepository(savepoint)),
+					Trash:    trash.NewTrashService(trash.NewTrashRepository(savepoint), r.avatars, r.retention),
+					Groups:   groups.NewGroupService(groups.NewGroupRepository(savepoint)),
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/trash"
+)
+
+var ErrInvalidRequest = errors.New("invalid bulk request")
+
+var ErrBatchTooLarge = fmt.Errorf("a bulk request can change at most %d contacts", MaxBatchSize)
+
+// errRollBack makes Transaction undo an all-or-nothing batch.
+var errRollBack = errors.New("bulk request rolled back")
+
+type BulkService interface {
+	Run(user_id, workspace_id uint, request BulkRequest) (*BulkResponse, error)
+}
+
+type bulkService struct {
+	repo     BulkRepository
+	contacts contacts.ContactService
+}
+
+// NewBulkService takes the contact service to resolve filters with.
+func NewBulkService(repo BulkRepository, contact_svc contacts.ContactService) BulkService {
+	return &bulkService{repo: repo, contacts: contact_svc}
+}
+
+// Run applies the action to every selected contact in one transaction and
+// reports the outcome per contact. Contacts that fail, e.g. because they are
+// not found or the user may not change them, are skipped; with AllOrNothing
+// a single failure undoes the whole batch.
+func (s *bulkService) Run(user_id, workspace_id uint, request BulkRequest) (*BulkResponse, error) {
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+	ids, err := s.targets(user_id, workspace_id, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BulkResponse{Action: request.Action, Total: len(ids), Results: make([]ItemResult, 0, len(ids))}
+	err = s.repo.Transaction(func(run ItemRunner) error {
+		if request.Action == ActionAddToGroup {
+			err := run(func(services Services) error {
+				_, err := services.Groups.FindGroupById(request.GroupID, user_id)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range ids {
+			err := run(func(services Services) error {
+				return apply(services, user_id, workspace_id, id, request)
+			})
+			if err != nil {
+				response.Failed++
+				response.Results = append(response.Results, ItemResult{ID: id, Status: StatusFailed, Error: err.Error()})
+				continue
+			}
+			response.Succeeded++
+			response.Results = append(response.Results, ItemResult{ID: id, Status: StatusOK})
+		}
+
+		if request.AllOrNothing && response.Failed > 0 {
+			return errRollBack
+		}
+		return nil
+	})
+	if errors.Is(err, errRollBack) {
+		response.RolledBack = true
+		response.Succeeded = 0
+		for i := range response.Results {
+			if response.Results[i].Status == StatusOK {
+				response.Results[i].Status = StatusRolledBack
+			}
+		}
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func apply(services Services, user_id, workspace_id, id uint, request BulkRequest) error {
+	switch request.Action {
+	case ActionDelete:
+		return services.Contacts.DeleteContact(id, user_id, workspace_id)
+	case ActionRestore:
+		return services.Trash.Restore(user_id, workspace_id, trash.TypeContact, id)
+	case ActionTag:
+		return services.Contacts.AddTags(id, user_id, workspace_id, request.Tags)
+	case ActionUntag:
+		return services.Contacts.RemoveTags(id, user_id, workspace_id, request.Tags)
+	case ActionAddToGroup:
+		return services.Groups.AddContacts(request.GroupID, user_id, workspace_id, groups.MembersRequest{ContactIDs: []uint{id}})
+	case ActionUpdate:
+		return services.Contacts.UpdateContact(id, user_id, workspace_id, request.Fields.Contact())
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidRequest, request.Action)
+	}
+}
+
+// validate checks the parameters the action needs before anything is
+// changed.
+func validate(request *BulkRequest) error {
+	switch {
+	case len(request.IDs) == 0 && request.Filter == nil:
+		return fmt.Errorf("%w: ids or filter is required", ErrInvalidRequest)
+	case len(request.IDs) > 0 && request.Filter != nil:
+		return fmt.Errorf("%w: send either ids or filter", ErrInvalidRequest)
+	case len(request.IDs) > MaxBatchSize:
+		return ErrBatchTooLarge
+	case request.Action == ActionRestore && request.Filter != nil:
+		// Filters match live contacts only.
+		return fmt.Errorf("%w: restore needs ids", ErrInvalidRequest)
+	case request.Action == ActionAddToGroup && request.GroupID == 0:
+		return fmt.Errorf("%w: group_id is required", ErrInvalidRequest)
+	case request.Action == ActionUpdate && request.Fields == nil:
+		return fmt.Errorf("%w: fields is required", ErrInvalidRequest)
+	}
+
+	if request.Action == ActionTag || request.Action == ActionUntag {
+		tags, err := contacts.NormalizeTags(request.Tags)
+		if err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("%w: tags is required", ErrInvalidRequest)
+		}
+		request.Tags = tags
+	}
+	return nil
+}
+
+// targets returns the ids of the contacts to change, in request order and
+// without repeats.
+func (s *bulkService) targets(user_id, workspace_id uint, request BulkRequest) ([]uint, error) {
+	if request.Filter == nil {
+		seen := make(map[uint]bool, len(request.IDs))
+		ids := make([]uint, 0, len(request.IDs))
+		for _, id := range request.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	query := contacts.ContactQuery{
+		WorkspaceID:  workspace_id,
+		CompanyID:    request.Filter.CompanyID,
+		Tag:          request.Filter.Tag,
+		CustomFields: request.Filter.CustomFields,
+	}
+	matches, err := s.contacts.GetContacts(1, MaxBatchSize, int(user_id), request.Filter.Search, query)
+	if err != nil {
+		return nil, err
+	}
+	if matches.Total > MaxBatchSize {
+		return nil, fmt.Errorf("%w: the filter matches %d", ErrBatchTooLarge, matches.Total)
+	}
+	ids := make([]uint, len(matches.Data))
+	for i, contact := range matches.Data {
+		ids[i] = contact.ID
+	}
+	return ids, nil
+}
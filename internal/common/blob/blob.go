@@ -0,0 +1,37 @@
+package blob
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore is implemented by every storage backend. Keys are
+// slash-separated paths chosen by the caller. Put overwrites an existing
+// blob; Delete of a missing key is not an error.
+type BlobStore interface {
+	Put(key string, blob Blob) error
+	Get(key string) (*Blob, error)
+	Delete(key string) error
+}
+
+type Blob struct {
+	Data        []byte
+	ContentType string
+}
+
+// checkKey rejects keys that could escape the store's root.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package blob
+
+import (
+	"sync"
+)
+
+// MemoryStore keeps blobs in memory. It is meant for tests and local runs;
+// everything is lost when the process exits.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string]Blob
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: make(map[string]Blob)}
+}
+
+func (s *MemoryStore) Put(key string, blob Blob) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	data := make([]byte, len(blob.Data))
+	copy(data, blob.Data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = Blob{Data: data, ContentType: blob.ContentType}
+	return nil
+}
+
+func (s *MemoryStore) Get(key string) (*Blob, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blob, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &Blob{Data: blob.Data, ContentType: blob.ContentType}, nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Len returns the number of stored blobs.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.blobs)
+}
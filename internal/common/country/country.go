@@ -0,0 +1,194 @@
+package country
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrUnknownCountry      = errors.New("unknown country")
+	ErrInvalidPostalCode   = errors.New("invalid postal code")
+	ErrSubdivisionRequired = errors.New("state or province is required")
+	ErrUnknownSubdivision  = errors.New("unknown state or province")
+)
+
+// Subdivision styles: how a country writes its states or provinces in an
+// address.
+const (
+	StyleCode = "code"
+	StyleName = "name"
+)
+
+// DefaultAddressFormat is used for countries without an address_format.
+const DefaultAddressFormat = "%N%n%O%n%A%n%C%n%S %Z"
+
+// countries.json holds every ISO 3166-1 country with its alpha-3 code,
+// display name and common aliases. Countries with postal codes have a
+// pattern and an example; countries whose addresses name a state or
+// province list their subdivisions, keyed by the ISO 3166-2 code without
+// the country prefix.
+//
+// address_format gives the order of the address fields on an envelope:
+// %N recipient name, %O organization, %A street, %C city, %S state,
+// %Z postal code and %n a line break. upper lists the fields the country
+// writes in upper case.
+//
+//go:embed countries.json
+var data []byte
+
+type Subdivision struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type Country struct {
+	Code                string        `json:"code"`
+	Alpha3              string        `json:"alpha3"`
+	Name                string        `json:"name"`
+	Aliases             []string      `json:"aliases"`
+	PostalCode          string        `json:"postal_code"`
+	PostalCodeExample   string        `json:"postal_code_example"`
+	SubdivisionStyle    string        `json:"subdivision_style"`
+	SubdivisionRequired bool          `json:"subdivision_required"`
+	Subdivisions        []Subdivision `json:"subdivisions"`
+	AddressFormat       string        `json:"address_format"`
+	Upper               string        `json:"upper"`
+
+	postalCode   *regexp.Regexp
+	subdivisions map[string]*Subdivision
+}
+
+var (
+	all    []*Country
+	byCode = map[string]*Country{}
+	byKey  = map[string]*Country{}
+)
+
+func init() {
+	if err := json.Unmarshal(data, &all); err != nil {
+		panic(fmt.Sprintf("country: invalid countries.json: %v", err))
+	}
+	for _, c := range all {
+		if c.PostalCode != "" {
+			c.postalCode = regexp.MustCompile(c.PostalCode)
+		}
+		c.subdivisions = make(map[string]*Subdivision)
+		for i := range c.Subdivisions {
+			s := &c.Subdivisions[i]
+			for _, key := range append([]string{s.Code, s.Name}, s.Aliases...) {
+				c.subdivisions[fold(key)] = s
+			}
+		}
+		byCode[c.Code] = c
+		for _, key := range append([]string{c.Code, c.Alpha3, c.Name}, c.Aliases...) {
+			byKey[fold(key)] = c
+		}
+	}
+}
+
+// All returns the countries sorted by display name.
+func All() []Country {
+	list := make([]Country, len(all))
+	for i, c := range all {
+		list[i] = *c
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Lookup finds a country by alpha-2 or alpha-3 code, name or alias. Case,
+// accents and punctuation are ignored, so "usa", "U.S.A." and "United
+// States of America" all find US.
+func Lookup(s string) (*Country, error) {
+	if c, ok := byKey[fold(s)]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownCountry, strings.TrimSpace(s))
+}
+
+// Name returns the display name of an alpha-2 code. Values that are not a
+// code, such as countries stored before codes were introduced, are
+// returned as they are.
+func Name(code string) string {
+	if c, ok := byCode[code]; ok {
+		return c.Name
+	}
+	return code
+}
+
+// NormalizePostalCode upper-cases the postal code, collapses its spaces and
+// checks it against the country's pattern. An empty postal code is valid.
+func (c *Country) NormalizePostalCode(s string) (string, error) {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), " "))
+	if s == "" || c.postalCode == nil {
+		return s, nil
+	}
+	if !c.postalCode.MatchString(s) {
+		return "", fmt.Errorf("%w for %s: %q, expected a code like %s", ErrInvalidPostalCode, c.Name, s, c.PostalCodeExample)
+	}
+	return s, nil
+}
+
+// NormalizeSubdivision resolves a state or province given by code, name or
+// alias and returns it as the country writes it in addresses: the code for
+// StyleCode, the name for StyleName. Countries without a subdivision list
+// accept any value.
+func (c *Country) NormalizeSubdivision(s string) (string, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		if c.SubdivisionRequired {
+			return "", fmt.Errorf("%w for %s", ErrSubdivisionRequired, c.Name)
+		}
+		return "", nil
+	}
+	if len(c.Subdivisions) == 0 {
+		return s, nil
+	}
+	subdivision, ok := c.subdivisions[fold(s)]
+	if !ok {
+		return "", fmt.Errorf("%w for %s: %q", ErrUnknownSubdivision, c.Name, s)
+	}
+	if c.SubdivisionStyle == StyleCode {
+		return subdivision.Code, nil
+	}
+	return subdivision.Name, nil
+}
+
+// Format returns the address format of the country.
+func (c *Country) Format() string {
+	if c.AddressFormat == "" {
+		return DefaultAddressFormat
+	}
+	return c.AddressFormat
+}
+
+// fold reduces a name to lower-case letters and digits separated by single
+// spaces, without accents.
+func fold(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package date
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layout is the format dates are read and written in.
+const Layout = "2006-01-02"
+
+// Date is a calendar date without a time of day or timezone, stored in a
+// DATE column and encoded as "YYYY-MM-DD" in JSON.
+type Date struct {
+	time.Time
+}
+
+// New returns the date of y-m-d.
+func New(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Parse reads a date in Layout.
+func Parse(value string) (Date, error) {
+	t, err := time.Parse(Layout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return Date{t}, nil
+}
+
+// Of returns the calendar date of t in its own location.
+func Of(t time.Time) Date {
+	return New(t.Year(), t.Month(), t.Day())
+}
+
+func (d Date) String() string {
+	return d.Format(Layout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (d *Date) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = New(v.Year(), v.Month(), v.Day())
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into date.Date", value)
+	}
+	return nil
+}
+
+func (d *Date) scanString(value string) error {
+	if len(value) > len(Layout) {
+		value = value[:len(Layout)]
+	}
+	parsed, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNotFound = errors.New("location not found")
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0088
+
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Valid reports whether the point lies within the latitude and longitude
+// ranges.
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// Query is the part of an address a Geocoder locates. Country is an ISO
+// 3166-1 alpha-2 code.
+type Query struct {
+	Country    string
+	State      string
+	City       string
+	PostalCode string
+}
+
+// Geocoder turns an address into coordinates. It returns ErrNotFound when
+// the address cannot be located.
+type Geocoder interface {
+	Geocode(q Query) (*Point, error)
+}
+
+// Distance returns the great-circle distance between a and b in kilometres.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Box is a latitude/longitude rectangle. When MinLng is greater than
+// MaxLng the box crosses the antimeridian.
+type Box struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// Around returns a box holding every point within radiusKm of center, for
+// narrowing a search before Distance is computed. Near a pole the box
+// spans all longitudes.
+func Around(center Point, radiusKm float64) Box {
+	dLat := degrees(radiusKm / earthRadiusKm)
+	box := Box{
+		MinLat: math.Max(-90, center.Lat-dLat),
+		MaxLat: math.Min(90, center.Lat+dLat),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+	dLng := degrees(math.Asin(math.Min(1, math.Sin(radiusKm/earthRadiusKm)/math.Cos(radians(center.Lat)))))
+	if dLng >= 180 {
+		return box
+	}
+	box.MinLng = wrap(center.Lng - dLng)
+	box.MaxLng = wrap(center.Lng + dLng)
+	return box
+}
+
+// CrossesAntimeridian reports whether the box wraps from 180 to -180.
+func (b Box) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+func radians(d float64) float64 { return d * math.Pi / 180 }
+
+func degrees(r float64) float64 { return r * 180 / math.Pi }
+
+// wrap brings a longitude back into [-180, 180].
+func wrap(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	}
+	return lng
+}
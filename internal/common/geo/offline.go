@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// places.csv lists the centroids of cities and of some postal codes, one
+// place per line: country code, postal code (empty for a city), city,
+// latitude and longitude.
+//
+//go:embed places.csv
+var places []byte
+
+type offlineGeocoder struct {
+	byPostalCode map[string]Point
+	byCity       map[string]Point
+}
+
+// NewOfflineGeocoder returns a Geocoder backed by the embedded place list.
+// It needs no network access; addresses are located by postal code when
+// the code is listed and by city otherwise, at the centroid of either.
+func NewOfflineGeocoder() Geocoder {
+	g := &offlineGeocoder{byPostalCode: map[string]Point{}, byCity: map[string]Point{}}
+	records, err := csv.NewReader(bytes.NewReader(places)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("geo: invalid places.csv: %v", err))
+	}
+	for i, record := range records[1:] {
+		lat, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			panic(fmt.Sprintf("geo: places.csv line %d: %v", i+2, err))
+		}
+		lng, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			panic(fmt.Sprintf("geo: places.csv line %d: %v", i+2, err))
+		}
+		point := Point{Lat: lat, Lng: lng}
+		if record[1] != "" {
+			g.byPostalCode[key(record[0], record[1])] = point
+			continue
+		}
+		g.byCity[key(record[0], record[2])] = point
+	}
+	return g
+}
+
+func (g *offlineGeocoder) Geocode(q Query) (*Point, error) {
+	if q.PostalCode != "" {
+		if point, ok := g.byPostalCode[key(q.Country, q.PostalCode)]; ok {
+			return &point, nil
+		}
+	}
+	if q.City != "" {
+		if point, ok := g.byCity[key(q.Country, q.City)]; ok {
+			return &point, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// key combines a country code with a postal code or city name folded to
+// lower-case letters and digits, so "Sao Paulo" finds "São Paulo" and
+// "sw1a1aa" finds "SW1A 1AA".
+func key(country, s string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(strings.TrimSpace(country)))
+	b.WriteByte(':')
+	for _, r := range norm.NFKD.String(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceMiddleware stores the active workspace as "workspace_id". It must
+// run after AuthMiddleware.
+func WorkspaceMiddleware(workspaceSvc workspaces.WorkspaceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user_id, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		workspace_id, err := workspaceSvc.ResolveWorkspace(user_id.(uint), c.GetHeader(workspaces.Header))
+		if err != nil {
+			if errors.Is(err, workspaces.ErrNotMember) {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("workspace_id", workspace_id)
+		c.Next()
+	}
+}
@@ -0,0 +1,239 @@
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultRegion is used for users that have not picked a region yet.
+const DefaultRegion = "ID"
+
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+type Number struct {
+	CountryCode string
+	National    string
+	E164        string
+	Display     string
+}
+
+type region struct {
+	code      string
+	trunk     string
+	minLength int
+	maxLength int
+	groups    []int
+}
+
+// regions holds the calling code, trunk prefix and national significant
+// number length for the regions we expect to see most. Other regions can
+// still be parsed when the number is written in international form.
+var regions = map[string]region{
+	"AE": {code: "971", trunk: "0", minLength: 8, maxLength: 9},
+	"AU": {code: "61", trunk: "0", minLength: 9, maxLength: 9, groups: []int{3, 3, 3}},
+	"BR": {code: "55", trunk: "0", minLength: 10, maxLength: 11, groups: []int{2, 5, 4}},
+	"CA": {code: "1", trunk: "1", minLength: 10, maxLength: 10, groups: []int{3, 3, 4}},
+	"CN": {code: "86", trunk: "0", minLength: 10, maxLength: 11, groups: []int{3, 4, 4}},
+	"DE": {code: "49", trunk: "0", minLength: 6, maxLength: 13},
+	"ES": {code: "34", minLength: 9, maxLength: 9, groups: []int{3, 3, 3}},
+	"FR": {code: "33", trunk: "0", minLength: 9, maxLength: 9, groups: []int{1, 2, 2, 2, 2}},
+	"GB": {code: "44", trunk: "0", minLength: 9, maxLength: 10, groups: []int{2, 4, 4}},
+	"HK": {code: "852", minLength: 8, maxLength: 8, groups: []int{4, 4}},
+	"ID": {code: "62", trunk: "0", minLength: 7, maxLength: 12, groups: []int{3, 4, 4}},
+	"IN": {code: "91", trunk: "0", minLength: 10, maxLength: 10, groups: []int{5, 5}},
+	"IT": {code: "39", minLength: 6, maxLength: 11},
+	"JP": {code: "81", trunk: "0", minLength: 9, maxLength: 10, groups: []int{2, 4, 4}},
+	"KR": {code: "82", trunk: "0", minLength: 8, maxLength: 10, groups: []int{2, 4, 4}},
+	"MX": {code: "52", minLength: 10, maxLength: 10, groups: []int{2, 4, 4}},
+	"MY": {code: "60", trunk: "0", minLength: 8, maxLength: 10, groups: []int{2, 4, 4}},
+	"NL": {code: "31", trunk: "0", minLength: 9, maxLength: 9, groups: []int{1, 8}},
+	"NZ": {code: "64", trunk: "0", minLength: 8, maxLength: 10},
+	"PH": {code: "63", trunk: "0", minLength: 8, maxLength: 10, groups: []int{3, 3, 4}},
+	"SA": {code: "966", trunk: "0", minLength: 8, maxLength: 9},
+	"SG": {code: "65", minLength: 8, maxLength: 8, groups: []int{4, 4}},
+	"TH": {code: "66", trunk: "0", minLength: 8, maxLength: 9, groups: []int{2, 3, 4}},
+	"TW": {code: "886", trunk: "0", minLength: 8, maxLength: 9},
+	"US": {code: "1", trunk: "1", minLength: 10, maxLength: 10, groups: []int{3, 3, 4}},
+	"VN": {code: "84", trunk: "0", minLength: 9, maxLength: 10, groups: []int{3, 3, 4}},
+}
+
+// callingCodes lists the assigned country calling codes so international
+// numbers from any country can be split into code and national number. The
+// codes are prefix-free, so the first match is the only match.
+var callingCodes = map[string]bool{
+	"1": true, "7": true,
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true, "34": true, "36": true, "39": true,
+	"40": true, "41": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "52": true, "53": true, "54": true, "55": true, "56": true, "57": true, "58": true,
+	"60": true, "61": true, "62": true, "63": true, "64": true, "65": true, "66": true,
+	"81": true, "82": true, "84": true, "86": true,
+	"90": true, "91": true, "92": true, "93": true, "94": true, "95": true, "98": true,
+	"211": true, "212": true, "213": true, "216": true, "218": true,
+	"220": true, "221": true, "222": true, "223": true, "224": true, "225": true, "226": true, "227": true, "228": true, "229": true,
+	"230": true, "231": true, "232": true, "233": true, "234": true, "235": true, "236": true, "237": true, "238": true, "239": true,
+	"240": true, "241": true, "242": true, "243": true, "244": true, "245": true, "246": true, "248": true, "249": true,
+	"250": true, "251": true, "252": true, "253": true, "254": true, "255": true, "256": true, "257": true, "258": true,
+	"260": true, "261": true, "262": true, "263": true, "264": true, "265": true, "266": true, "267": true, "268": true, "269": true,
+	"290": true, "291": true, "297": true, "298": true, "299": true,
+	"350": true, "351": true, "352": true, "353": true, "354": true, "355": true, "356": true, "357": true, "358": true, "359": true,
+	"370": true, "371": true, "372": true, "373": true, "374": true, "375": true, "376": true, "377": true, "378": true, "379": true,
+	"380": true, "381": true, "382": true, "383": true, "385": true, "386": true, "387": true, "389": true,
+	"420": true, "421": true, "423": true,
+	"500": true, "501": true, "502": true, "503": true, "504": true, "505": true, "506": true, "507": true, "508": true, "509": true,
+	"590": true, "591": true, "592": true, "593": true, "594": true, "595": true, "596": true, "597": true, "598": true, "599": true,
+	"670": true, "672": true, "673": true, "674": true, "675": true, "676": true, "677": true, "678": true, "679": true,
+	"680": true, "681": true, "682": true, "683": true, "685": true, "686": true, "687": true, "688": true, "689": true,
+	"690": true, "691": true, "692": true,
+	"850": true, "852": true, "853": true, "855": true, "856": true,
+	"880": true, "886": true,
+	"960": true, "961": true, "962": true, "963": true, "964": true, "965": true, "966": true, "967": true, "968": true,
+	"970": true, "971": true, "972": true, "973": true, "974": true, "975": true, "976": true, "977": true,
+	"992": true, "993": true, "994": true, "995": true, "996": true, "998": true,
+}
+
+// IsSupportedRegion reports whether numbers written in national form can be
+// parsed for the region.
+func IsSupportedRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// Parse normalizes a phone number to E.164. Numbers starting with "+" or the
+// international "00" prefix are parsed as international numbers; anything else
+// is read as a national number of the given default region.
+func Parse(raw, default_region string) (*Number, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: number is empty", ErrInvalidNumber)
+	}
+
+	for _, r := range trimmed {
+		if !strings.ContainsRune("0123456789+-.() /", r) {
+			return nil, fmt.Errorf("%w: %q contains unexpected character %q", ErrInvalidNumber, raw, r)
+		}
+	}
+	if strings.LastIndex(trimmed, "+") > 0 {
+		return nil, fmt.Errorf("%w: %q has a misplaced '+'", ErrInvalidNumber, raw)
+	}
+
+	digits := Digits(trimmed)
+	international := strings.HasPrefix(trimmed, "+")
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	var code, national string
+	var info region
+	if international {
+		code = splitCallingCode(digits)
+		if code == "" {
+			return nil, fmt.Errorf("%w: %q has an unknown country calling code", ErrInvalidNumber, raw)
+		}
+		national = digits[len(code):]
+		info = regionForCode(code, default_region)
+		// Some people keep the trunk prefix after the calling code, as in
+		// "+62 (0)812...".
+		if info.trunk == "0" && strings.HasPrefix(national, "0") {
+			national = national[1:]
+		}
+	} else {
+		var ok bool
+		info, ok = regions[strings.ToUpper(default_region)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not in international format and region %q is not supported", ErrInvalidNumber, raw, default_region)
+		}
+		code = info.code
+		national = digits
+		if info.trunk != "" && strings.HasPrefix(national, info.trunk) && len(national) > info.minLength {
+			national = national[len(info.trunk):]
+		} else if strings.HasPrefix(national, info.code) && len(national)-len(info.code) >= info.minLength {
+			// Written with the calling code but without the "+".
+			national = national[len(info.code):]
+		}
+	}
+
+	minLength, maxLength := 4, 14
+	if info.minLength > 0 {
+		minLength, maxLength = info.minLength, info.maxLength
+	}
+	if len(national) < minLength || len(national) > maxLength || len(code)+len(national) > 15 {
+		return nil, fmt.Errorf("%w: %q should have %d to %d digits after the country code", ErrInvalidNumber, raw, minLength, maxLength)
+	}
+
+	return &Number{
+		CountryCode: code,
+		National:    national,
+		E164:        "+" + code + national,
+		Display:     "+" + code + " " + group(national, info.groups),
+	}, nil
+}
+
+// Digits strips everything except ASCII digits.
+func Digits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SearchDigits turns a search term into the digits to look for inside stored
+// E.164 numbers. A leading trunk zero is dropped so "0812-3456" still finds
+// "+628123456...". It returns "" when the term does not look like a number.
+func SearchDigits(term string) string {
+	digits := Digits(term)
+	if len(digits) < 3 || len(digits)*2 <= len(strings.TrimSpace(term)) {
+		return ""
+	}
+	return strings.TrimLeft(digits, "0")
+}
+
+func splitCallingCode(digits string) string {
+	for length := 1; length <= 3 && length <= len(digits); length++ {
+		if callingCodes[digits[:length]] {
+			return digits[:length]
+		}
+	}
+	return ""
+}
+
+// regionForCode prefers the default region when it shares the calling code,
+// which matters for codes used by several regions such as "1".
+func regionForCode(code, default_region string) region {
+	if info, ok := regions[strings.ToUpper(default_region)]; ok && info.code == code {
+		return info
+	}
+	for _, info := range regions {
+		if info.code == code {
+			return info
+		}
+	}
+	return region{code: code}
+}
+
+// group splits the national number into the region's digit groups. Digits
+// left over after the last group are appended in blocks of four.
+func group(national string, sizes []int) string {
+	if len(sizes) == 0 {
+		sizes = []int{3, 4}
+	}
+
+	var parts []string
+	rest := national
+	for _, size := range sizes {
+		if len(rest) <= size {
+			break
+		}
+		parts = append(parts, rest[:size])
+		rest = rest[size:]
+	}
+	for len(rest) > 4 {
+		parts = append(parts, rest[:4])
+		rest = rest[4:]
+	}
+	return strings.Join(append(parts, rest), " ")
+}
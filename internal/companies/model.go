@@ -0,0 +1,35 @@
+package companies
+
+import (
+	"time"
+)
+
+// Company is an employer of a user's contacts.
+type Company struct {
+	ID        uint      `gorm:"column:company_id;primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_companies_user_name" json:"user_id"`
+	Name      string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_companies_user_name" json:"name"`
+	Domain    string    `gorm:"type:varchar(255);index" json:"domain"`
+	Industry  string    `gorm:"type:varchar(100)" json:"industry"`
+	Address   string    `gorm:"type:varchar(500)" json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Company) TableName() string {
+	return "companies"
+}
+
+type CreateCompanyRequest struct {
+	Name     string `json:"name" binding:"required,max=255"`
+	Domain   string `json:"domain" binding:"omitempty,max=255"`
+	Industry string `json:"industry" binding:"omitempty,max=100"`
+	Address  string `json:"address" binding:"omitempty,max=500"`
+}
+
+type UpdateCompanyRequest struct {
+	Name     string `json:"name" binding:"omitempty,max=255"`
+	Domain   string `json:"domain" binding:"omitempty,max=255"`
+	Industry string `json:"industry" binding:"omitempty,max=100"`
+	Address  string `json:"address" binding:"omitempty,max=500"`
+}
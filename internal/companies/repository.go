@@ -0,0 +1,69 @@
+package companies
+
+import (
+	"gorm.io/gorm"
+)
+
+type CompanyRepository interface {
+	GetCompanies(user_id uint, search string) ([]Company, error)
+	FindCompanyById(id, user_id uint) (*Company, error)
+	FindCompanyByName(user_id uint, name string) (*Company, error)
+	CreateCompany(company *Company) error
+	UpdateCompany(company *Company) error
+	DeleteCompany(id uint) error
+}
+
+type companyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &companyRepository{db: db}
+}
+
+func (r *companyRepository) GetCompanies(user_id uint, search string) ([]Company, error) {
+	var company_list []Company
+	query := r.db.Where("user_id = ?", user_id)
+	if search != "" {
+		query = query.Where("name LIKE ? OR domain LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if err := query.Order("name").Find(&company_list).Error; err != nil {
+		return nil, err
+	}
+	return company_list, nil
+}
+
+func (r *companyRepository) FindCompanyById(id, user_id uint) (*Company, error) {
+	var company Company
+	if err := r.db.Where("company_id = ? AND user_id = ?", id, user_id).First(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) FindCompanyByName(user_id uint, name string) (*Company, error) {
+	var company Company
+	if err := r.db.Where("user_id = ? AND name = ?", user_id, name).First(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) CreateCompany(company *Company) error {
+	return r.db.Create(company).Error
+}
+
+func (r *companyRepository) UpdateCompany(company *Company) error {
+	return r.db.Save(company).Error
+}
+
+func (r *companyRepository) DeleteCompany(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Contacts stay, without an employer. Trashed contacts are
+		// unlinked too so restoring them does not point at a missing row.
+		if err := tx.Exec("UPDATE contacts SET company_id = NULL WHERE company_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Where("company_id = ?", id).Delete(&Company{}).Error
+	})
+}
@@ -0,0 +1,124 @@
+package companies
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CompanyService interface {
+	GetCompanies(user_id uint, search string) ([]Company, error)
+	FindCompanyById(id, user_id uint) (*Company, error)
+	CreateCompany(user_id uint, request CreateCompanyRequest) (*Company, error)
+	UpdateCompany(id, user_id uint, request UpdateCompanyRequest) (*Company, error)
+	DeleteCompany(id, user_id uint) error
+}
+
+type companyService struct {
+	repo CompanyRepository
+}
+
+func NewCompanyService(repo CompanyRepository) CompanyService {
+	return &companyService{repo: repo}
+}
+
+func (s *companyService) GetCompanies(user_id uint, search string) ([]Company, error) {
+	company_list, err := s.repo.GetCompanies(user_id, search)
+	if err != nil {
+		return nil, err
+	}
+	return company_list, nil
+}
+
+func (s *companyService) FindCompanyById(id, user_id uint) (*Company, error) {
+	company, err := s.repo.FindCompanyById(id, user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+func (s *companyService) CreateCompany(user_id uint, request CreateCompanyRequest) (*Company, error) {
+	name := strings.TrimSpace(request.Name)
+	if err := s.checkName(user_id, 0, name); err != nil {
+		return nil, err
+	}
+
+	company := Company{
+		UserID:   user_id,
+		Name:     name,
+		Domain:   NormalizeDomain(request.Domain),
+		Industry: strings.TrimSpace(request.Industry),
+		Address:  strings.TrimSpace(request.Address),
+	}
+	if err := s.repo.CreateCompany(&company); err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (s *companyService) UpdateCompany(id, user_id uint, request UpdateCompanyRequest) (*Company, error) {
+	company, err := s.FindCompanyById(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(request.Name); name != "" {
+		if err := s.checkName(user_id, id, name); err != nil {
+			return nil, err
+		}
+		company.Name = name
+	}
+	if request.Domain != "" {
+		company.Domain = NormalizeDomain(request.Domain)
+	}
+	if request.Industry != "" {
+		company.Industry = strings.TrimSpace(request.Industry)
+	}
+	if request.Address != "" {
+		company.Address = strings.TrimSpace(request.Address)
+	}
+
+	if err := s.repo.UpdateCompany(company); err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+func (s *companyService) DeleteCompany(id, user_id uint) error {
+	if _, err := s.FindCompanyById(id, user_id); err != nil {
+		return err
+	}
+	return s.repo.DeleteCompany(id)
+}
+
+func (s *companyService) checkName(user_id, id uint, name string) error {
+	existing, err := s.repo.FindCompanyByName(user_id, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return errors.New("company with this name already exists")
+	}
+	return nil
+}
+
+// NormalizeDomain reduces a website or e-mail domain to its bare host name,
+// so "https://www.Acme.com/about" is stored as "acme.com".
+func NormalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if _, rest, ok := strings.Cut(domain, "://"); ok {
+		domain = rest
+	}
+	if _, rest, ok := strings.Cut(domain, "@"); ok {
+		domain = rest
+	}
+	if host, _, ok := strings.Cut(domain, "/"); ok {
+		domain = host
+	}
+	return strings.TrimPrefix(domain, "www.")
+}
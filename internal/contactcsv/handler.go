@@ -0,0 +1,110 @@
+package contactcsv
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize caps the size of an uploaded CSV file.
+const maxImportSize = 10 << 20
+
+type ContactCSVHandler interface {
+	ExportContacts(c *gin.Context)
+	ImportContacts(c *gin.Context)
+}
+
+type contactCSVHandler struct {
+	svc ContactCSVService
+}
+
+func NewContactCSVHandler(svc ContactCSVService) ContactCSVHandler {
+	return &contactCSVHandler{svc: svc}
+}
+
+func (h *contactCSVHandler) ExportContacts(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	search := c.DefaultQuery("search", "")
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="contacts.csv"`)
+	c.Status(http.StatusOK)
+
+	if err := h.svc.ExportContacts(user_id.(uint), c.GetUint("workspace_id"), search, c.Writer); err != nil {
+		// Rows may already be on the wire, so the status can no longer change.
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+func (h *contactCSVHandler) ImportContacts(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	dry_run, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	request := ImportRequest{DryRun: dry_run, Preset: c.Query("preset")}
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		if preset := c.PostForm("preset"); preset != "" {
+			request.Preset = preset
+		}
+		if mapping := c.PostForm("mapping"); mapping != "" {
+			if err := json.Unmarshal([]byte(mapping), &request.Mapping); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping"})
+				return
+			}
+		}
+
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer opened.Close()
+		body = opened
+	}
+
+	report, err := h.svc.ImportContacts(user_id.(uint), c.GetUint("workspace_id"), body, request)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, contacts.ErrForbidden) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Contacts imported"
+	if report.DryRun {
+		message = "Dry run completed, no contacts were saved"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    report,
+	})
+}
@@ -0,0 +1,87 @@
+package contactcsv
+
+const (
+	FieldContactID  = "contact_id"
+	FieldFirstName  = "first_name"
+	FieldLastName   = "last_name"
+	FieldEmail      = "email"
+	FieldPhone      = "phone"
+	FieldStreet     = "street"
+	FieldCity       = "city"
+	FieldState      = "state"
+	FieldPostalCode = "postal_code"
+	FieldCountry    = "country"
+)
+
+const (
+	PresetGoogle  = "google"
+	PresetOutlook = "outlook"
+)
+
+const (
+	RowCreated      = "created"
+	RowAddressAdded = "address_added"
+	RowSkipped      = "skipped"
+	RowFailed       = "failed"
+)
+
+// ExportHeader is the column layout of an export. It is also the default
+// mapping for imports, so an exported file can be imported back unchanged.
+var ExportHeader = []string{FieldContactID, FieldFirstName, FieldLastName, FieldEmail, FieldPhone, "address_id", FieldStreet, FieldCity, FieldState, FieldPostalCode, FieldCountry}
+
+// Presets map the column headers written by common address book exports to
+// contact fields. Several headers may point at the same field; the first
+// non-empty one wins.
+var Presets = map[string]map[string]string{
+	PresetGoogle: {
+		"Given Name":              FieldFirstName,
+		"First Name":              FieldFirstName,
+		"Family Name":             FieldLastName,
+		"Last Name":               FieldLastName,
+		"E-mail 1 - Value":        FieldEmail,
+		"Phone 1 - Value":         FieldPhone,
+		"Address 1 - Street":      FieldStreet,
+		"Address 1 - City":        FieldCity,
+		"Address 1 - Region":      FieldState,
+		"Address 1 - Postal Code": FieldPostalCode,
+		"Address 1 - Country":     FieldCountry,
+	},
+	PresetOutlook: {
+		"First Name":          FieldFirstName,
+		"Last Name":           FieldLastName,
+		"E-mail Address":      FieldEmail,
+		"Mobile Phone":        FieldPhone,
+		"Primary Phone":       FieldPhone,
+		"Home Phone":          FieldPhone,
+		"Business Phone":      FieldPhone,
+		"Home Street":         FieldStreet,
+		"Home City":           FieldCity,
+		"Home State":          FieldState,
+		"Home Postal Code":    FieldPostalCode,
+		"Home Country/Region": FieldCountry,
+		"Home Country":        FieldCountry,
+	},
+}
+
+type ImportRequest struct {
+	Mapping map[string]string
+	Preset  string
+	DryRun  bool
+}
+
+type ImportRow struct {
+	Row       int    `json:"row"`
+	Status    string `json:"status"`
+	ContactID uint   `json:"contact_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun         bool        `json:"dry_run"`
+	Total          int         `json:"total"`
+	Created        int         `json:"created"`
+	AddressesAdded int         `json:"addresses_added"`
+	Skipped        int         `json:"skipped"`
+	Failed         int         `json:"failed"`
+	Rows           []ImportRow `json:"rows"`
+}
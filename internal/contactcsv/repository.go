@@ -0,0 +1,123 @@
+package contactcsv
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+)
+
+// exportBatchSize is the number of contacts loaded per query while streaming
+// an export.
+const exportBatchSize = 500
+
+type ContactCSVRepository interface {
+	EachContactBatch(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error
+	EmailExists(user_id, workspace_id uint, email string) (bool, error)
+	CreateContact(contact *contacts.Contact, address *addresses.Address) error
+	CreateAddress(user_id uint, address *addresses.Address) error
+	FindUserRegion(user_id uint) (string, error)
+	GetCustomFields(user_id uint) ([]customfields.Field, error)
+	FindWorkspaceRole(workspace_id, user_id uint) (string, error)
+}
+
+type contactCSVRepository struct {
+	db *gorm.DB
+}
+
+func NewContactCSVRepository(db *gorm.DB) ContactCSVRepository {
+	return &contactCSVRepository{db: db}
+}
+
+// EachContactBatch walks the contacts of the workspace in primary key order
+// and calls fn with every batch together with the addresses of the contacts
+// in it.
+func (r *contactCSVRepository) EachContactBatch(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error {
+	query := r.db.Model(&contacts.Contact{}).Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView))
+	if search != "" {
+		query = query.Where("first_name LIKE ? OR last_name LIKE ? OR email LIKE ? OR phone LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	var batch []contacts.Contact
+	result := query.FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		contact_ids := make([]uint, len(batch))
+		for i, contact := range batch {
+			contact_ids[i] = contact.ID
+		}
+
+		var address_list []addresses.Address
+		if err := r.db.Where("contact_id IN ?", contact_ids).Order("address_id").Find(&address_list).Error; err != nil {
+			return err
+		}
+		return fn(batch, address_list)
+	})
+	return result.Error
+}
+
+func (r *contactCSVRepository) EmailExists(user_id, workspace_id uint, email string) (bool, error) {
+	var total int64
+	if err := r.db.Model(&contacts.Contact{}).Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).Where("email = ?", email).Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// CreateContact stores the contact and its address, recording both in the
+// contact history as created by the importing user.
+func (r *contactCSVRepository) CreateContact(contact *contacts.Contact, address *addresses.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contact).Error; err != nil {
+			return err
+		}
+		if err := contacts.RecordChanges(tx, contact.ID, contact.UserID, history.ActionCreate, nil); err != nil {
+			return err
+		}
+		if address == nil {
+			return nil
+		}
+		address.ContactID = contact.ID
+		return createAddress(tx, contact.UserID, address)
+	})
+}
+
+func (r *contactCSVRepository) CreateAddress(user_id uint, address *addresses.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createAddress(tx, user_id, address)
+	})
+}
+
+func (r *contactCSVRepository) FindUserRegion(user_id uint) (string, error) {
+	var user users.User
+	if err := r.db.Select("region").Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return "", err
+	}
+	return user.Region, nil
+}
+
+func (r *contactCSVRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	var fields []customfields.Field
+	if err := r.db.Where("user_id = ?", user_id).Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// FindWorkspaceRole returns user_id's role in the workspace, or "" when
+// they are not a member.
+func (r *contactCSVRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	var role string
+	err := r.db.Table("workspace_members").Select("role").
+		Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).
+		Limit(1).Scan(&role).Error
+	return role, err
+}
+
+func createAddress(tx *gorm.DB, user_id uint, address *addresses.Address) error {
+	if err := tx.Create(address).Error; err != nil {
+		return err
+	}
+	return addresses.Record(tx, user_id, address, history.ActionCreate, nil)
+}
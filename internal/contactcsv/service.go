@@ -0,0 +1,372 @@
+package contactcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+	"github.com/gin-gonic/gin/binding"
+
+	"gorm.io/gorm"
+)
+
+type ContactCSVService interface {
+	ExportContacts(user_id, workspace_id uint, search string, w io.Writer) error
+	ImportContacts(user_id, workspace_id uint, r io.Reader, request ImportRequest) (*ImportReport, error)
+}
+
+type contactCSVService struct {
+	repo     ContactCSVRepository
+	geocoder geo.Geocoder
+}
+
+// NewContactCSVService returns the CSV service. Imported addresses are
+// located with geocoder, like the ones saved through the address endpoints.
+func NewContactCSVService(repo ContactCSVRepository, geocoder geo.Geocoder) ContactCSVService {
+	return &contactCSVService{repo: repo, geocoder: geocoder}
+}
+
+// ExportContacts writes one row per address, repeating the contact columns.
+// Contacts without an address get a single row with empty address columns.
+func (s *contactCSVService) ExportContacts(user_id, workspace_id uint, search string, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ExportHeader); err != nil {
+		return err
+	}
+
+	err := s.repo.EachContactBatch(user_id, workspace_id, search, func(contact_list []contacts.Contact, address_list []addresses.Address) error {
+		by_contact := make(map[uint][]addresses.Address)
+		for _, address := range address_list {
+			by_contact[address.ContactID] = append(by_contact[address.ContactID], address)
+		}
+
+		for _, contact := range contact_list {
+			row := []string{strconv.FormatUint(uint64(contact.ID), 10), contact.FirstName, contact.LastName, contact.Email, contact.Phone}
+			if len(by_contact[contact.ID]) == 0 {
+				if err := writer.Write(append(row, "", "", "", "", "", "")); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, address := range by_contact[contact.ID] {
+				record := append(row[:5:5], strconv.FormatUint(uint64(address.ID), 10), address.Street, address.City, address.State, address.PostalCode, address.Country)
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		writer.Flush()
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *contactCSVService) ImportContacts(user_id, workspace_id uint, r io.Reader, request ImportRequest) (*ImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv file is empty")
+		}
+		return nil, err
+	}
+
+	columns, err := resolveColumns(header, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkWorkspace(user_id, workspace_id); err != nil {
+		return nil, err
+	}
+
+	region, err := s.repo.FindUserRegion(user_id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	fields, err := s.customFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: request.DryRun, Rows: []ImportRow{}}
+	seen := make(map[string]ImportRow)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		report.Total++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			report.add(ImportRow{Row: row, Status: RowFailed, Reason: parseErr.Err.Error()})
+			continue
+		}
+
+		report.add(s.importRow(user_id, workspace_id, region, fields, row, rowValues(columns, record), seen, request.DryRun))
+	}
+
+	return report, nil
+}
+
+// importRow creates the contact of a row. An export repeats the contact on
+// one row per address, so a later row of a contact seen before, by
+// contact_id or else by email, adds its address to the contact created from
+// the first row. seen holds the outcome of those first rows. The file has no
+// custom field columns, so a row fails while the user has required fields.
+func (s *contactCSVService) importRow(user_id, workspace_id uint, region string, fields map[string]customfields.Field, row int, values map[string]string, seen map[string]ImportRow, dry_run bool) ImportRow {
+	if len(values) == 0 {
+		return ImportRow{Row: row, Status: RowSkipped, Reason: "empty row"}
+	}
+
+	request := contacts.CreateContactRequest{
+		FirstName: values[FieldFirstName],
+		LastName:  values[FieldLastName],
+		Email:     values[FieldEmail],
+		Phone:     values[FieldPhone],
+	}
+	if err := binding.Validator.ValidateStruct(request); err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+
+	contact := contacts.Contact{
+		UserID:    user_id,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		Email:     request.Email,
+		Phone:     request.Phone,
+	}
+	if workspace_id != workspaces.Personal {
+		contact.WorkspaceID = &workspace_id
+	}
+	if err := contacts.NormalizePhone(&contact, region); err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+	if err := contacts.ParseCustomFields(&contact, fields, true); err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+
+	var address *addresses.Address
+	if values[FieldStreet] != "" || values[FieldCity] != "" || values[FieldState] != "" || values[FieldPostalCode] != "" || values[FieldCountry] != "" {
+		update := addresses.UpdateAddressRequest{
+			Street:     values[FieldStreet],
+			City:       values[FieldCity],
+			State:      values[FieldState],
+			PostalCode: values[FieldPostalCode],
+			Country:    values[FieldCountry],
+		}
+		if update.Country == "" {
+			return ImportRow{Row: row, Status: RowFailed, Reason: "address country is required"}
+		}
+		if err := binding.Validator.ValidateStruct(update); err != nil {
+			return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+		}
+		address = &addresses.Address{
+			Street:     update.Street,
+			City:       update.City,
+			State:      update.State,
+			PostalCode: update.PostalCode,
+			Country:    update.Country,
+			Type:       addresses.TypeOther,
+		}
+		if err := addresses.Normalize(address); err != nil {
+			return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+		}
+		if !dry_run {
+			addresses.Geocode(s.geocoder, address)
+		}
+	}
+
+	email_key := FieldEmail + ":" + strings.ToLower(request.Email)
+	key := email_key
+	if contact_id := values[FieldContactID]; contact_id != "" {
+		key = FieldContactID + ":" + contact_id
+	}
+	if first, ok := seen[key]; ok {
+		return s.addAddress(user_id, row, first, address, dry_run)
+	}
+	if _, ok := seen[email_key]; ok {
+		return ImportRow{Row: row, Status: RowSkipped, Reason: "duplicate email in file"}
+	}
+
+	result := s.createContact(user_id, workspace_id, row, &contact, address, dry_run)
+	seen[key] = result
+	seen[email_key] = result
+	return result
+}
+
+func (s *contactCSVService) createContact(user_id, workspace_id uint, row int, contact *contacts.Contact, address *addresses.Address, dry_run bool) ImportRow {
+	exists, err := s.repo.EmailExists(user_id, workspace_id, contact.Email)
+	if err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+	if exists {
+		return ImportRow{Row: row, Status: RowSkipped, Reason: "contact with this email already exists"}
+	}
+
+	if dry_run {
+		return ImportRow{Row: row, Status: RowCreated}
+	}
+
+	if err := s.repo.CreateContact(contact, address); err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+	return ImportRow{Row: row, Status: RowCreated, ContactID: contact.ID}
+}
+
+// addAddress adds the address of a row to the contact created from an
+// earlier row of the file.
+func (s *contactCSVService) addAddress(user_id uint, row int, first ImportRow, address *addresses.Address, dry_run bool) ImportRow {
+	switch {
+	case first.Status != RowCreated:
+		return ImportRow{Row: row, Status: RowSkipped, Reason: fmt.Sprintf("contact of row %d was not imported", first.Row)}
+	case address == nil:
+		return ImportRow{Row: row, Status: RowSkipped, Reason: "duplicate contact in file"}
+	case dry_run:
+		return ImportRow{Row: row, Status: RowAddressAdded}
+	}
+
+	address.ContactID = first.ContactID
+	if err := s.repo.CreateAddress(user_id, address); err != nil {
+		return ImportRow{Row: row, Status: RowFailed, Reason: err.Error()}
+	}
+	return ImportRow{Row: row, Status: RowAddressAdded, ContactID: first.ContactID}
+}
+
+func (s *contactCSVService) customFields(user_id uint) (map[string]customfields.Field, error) {
+	fields, err := s.repo.GetCustomFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+	by_key := make(map[string]customfields.Field, len(fields))
+	for _, field := range fields {
+		by_key[field.Key] = field
+	}
+	return by_key, nil
+}
+
+// checkWorkspace makes sure user_id may add contacts to the workspace the
+// file is imported into.
+func (s *contactCSVService) checkWorkspace(user_id, workspace_id uint) error {
+	if workspace_id == workspaces.Personal {
+		return nil
+	}
+	role, err := s.repo.FindWorkspaceRole(workspace_id, user_id)
+	if err != nil {
+		return err
+	}
+	if !contacts.CanEdit(role) {
+		return contacts.ErrForbidden
+	}
+	return nil
+}
+
+func (report *ImportReport) add(row ImportRow) {
+	switch row.Status {
+	case RowCreated:
+		report.Created++
+	case RowAddressAdded:
+		report.AddressesAdded++
+	case RowSkipped:
+		report.Skipped++
+	case RowFailed:
+		report.Failed++
+	}
+	report.Rows = append(report.Rows, row)
+}
+
+// resolveColumns returns the contact field for every column of the header.
+// Without a preset or mapping, columns are matched against the field names
+// themselves.
+func resolveColumns(header []string, request ImportRequest) ([]string, error) {
+	mapping := make(map[string]string)
+	if request.Preset != "" {
+		preset, ok := Presets[strings.ToLower(request.Preset)]
+		if !ok {
+			return nil, fmt.Errorf("unknown preset %q", request.Preset)
+		}
+		for column, field := range preset {
+			mapping[strings.ToLower(column)] = field
+		}
+	}
+	for column, field := range request.Mapping {
+		if !isField(field) {
+			return nil, fmt.Errorf("unknown field %q in mapping", field)
+		}
+		mapping[strings.ToLower(strings.TrimSpace(column))] = field
+	}
+	if request.Preset == "" && len(request.Mapping) == 0 {
+		for _, field := range ExportHeader {
+			if isField(field) {
+				mapping[field] = field
+			}
+		}
+	}
+
+	columns := make([]string, len(header))
+	mapped := make(map[string]bool)
+	for i, column := range header {
+		column = strings.TrimPrefix(column, "\ufeff")
+		columns[i] = mapping[strings.ToLower(strings.TrimSpace(column))]
+		mapped[columns[i]] = true
+	}
+
+	for _, required := range []string{FieldFirstName, FieldEmail} {
+		if !mapped[required] {
+			return nil, fmt.Errorf("no column is mapped to %s", required)
+		}
+	}
+	return columns, nil
+}
+
+// rowValues collects the trimmed, non-empty values of a record keyed by
+// field. When several columns map to one field the first non-empty one wins.
+func rowValues(columns []string, record []string) map[string]string {
+	values := make(map[string]string)
+	for i, value := range record {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := values[columns[i]]; !ok {
+			values[columns[i]] = value
+		}
+	}
+	return values
+}
+
+func isField(field string) bool {
+	switch field {
+	case FieldContactID, FieldFirstName, FieldLastName, FieldEmail, FieldPhone, FieldStreet, FieldCity, FieldState, FieldPostalCode, FieldCountry:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package contacts
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PermissionView = "view"
+	PermissionEdit = "edit"
+)
+
+// rolesFor lists the workspace roles that carry permission. Viewers can
+// read the workspace's contacts but not change them.
+func rolesFor(permission string) []string {
+	if permission == PermissionEdit {
+		return []string{workspaces.RoleOwner, workspaces.RoleAdmin, workspaces.RoleMember}
+	}
+	return []string{workspaces.RoleOwner, workspaces.RoleAdmin, workspaces.RoleMember, workspaces.RoleViewer}
+}
+
+// CanEdit reports whether a workspace role may add and change contacts.
+func CanEdit(role string) bool {
+	for _, allowed := range rolesFor(PermissionEdit) {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// InWorkspace limits a contacts query to the active workspace. In the
+// personal workspace these are the contacts user_id owns; in an
+// organization workspace these are the organization's contacts, provided
+// user_id is a member whose role carries permission.
+func InWorkspace(user_id, workspace_id uint, permission string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspace_id == workspaces.Personal {
+			return db.Where("contacts.workspace_id IS NULL AND contacts.user_id = ?", user_id)
+		}
+		return db.Where(`contacts.workspace_id = ? AND EXISTS (SELECT 1 FROM workspace_members wm
+			WHERE wm.workspace_id = contacts.workspace_id AND wm.user_id = ? AND wm.role IN ?)`,
+			workspace_id, user_id, rolesFor(permission))
+	}
+}
+
+// AccessibleBy is InWorkspace plus, in the personal workspace, the contacts
+// other users have shared with user_id at permission or above, either
+// directly or through a shared group. Grants live in contact_shares; group
+// membership in contact_group_members. Organization contacts in a shared
+// group stay with the organization's members.
+func AccessibleBy(user_id, workspace_id uint, permission string) func(db *gorm.DB) *gorm.DB {
+	if workspace_id != workspaces.Personal {
+		return InWorkspace(user_id, workspace_id, permission)
+	}
+
+	permissions := []string{PermissionView, PermissionEdit}
+	if permission == PermissionEdit {
+		permissions = []string{PermissionEdit}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`((contacts.workspace_id IS NULL AND contacts.user_id = ?)
+			OR contacts.contact_id IN (SELECT s.contact_id FROM contact_shares s WHERE s.grantee_id = ? AND s.contact_id IS NOT NULL AND s.permission IN ?)
+			OR (contacts.workspace_id IS NULL AND contacts.contact_id IN (SELECT m.contact_id FROM contact_group_members m JOIN contact_shares s ON s.group_id = m.group_id WHERE s.grantee_id = ? AND s.permission IN ?)))`,
+			user_id, user_id, permissions, user_id, permissions)
+	}
+}
@@ -1,29 +1,69 @@
 package contacts
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/phone"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 	"github.com/gin-gonic/gin"
 )
 
 type ContactHandler interface {
 	GetContacts(c *gin.Context)
+	GetCompanyContacts(c *gin.Context)
 	CreateContact(c *gin.Context)
 	FindContactById(c *gin.Context)
 	UpdateContact(c *gin.Context)
 	DeleteContact(c *gin.Context)
+	GetHistory(c *gin.Context)
+	RestoreContact(c *gin.Context)
+	FavoriteContact(c *gin.Context)
+	UnfavoriteContact(c *gin.Context)
+	GetSortPreference(c *gin.Context)
+	UpdateSortPreference(c *gin.Context)
 }
 
 type contactHandler struct {
-	svc ContactService
+	svc      ContactService
+	includes map[string]IncludeFunc
 }
 
-func NewContactHandler(svc ContactService) ContactHandler {
-	return &contactHandler{svc: svc}
+// NewContactHandler creates the contact handler. includes lists what GET
+// /contacts/:id can embed through ?include=; it may be nil.
+func NewContactHandler(svc ContactService, includes map[string]IncludeFunc) ContactHandler {
+	return &contactHandler{svc: svc, includes: includes}
 }
 
 func (h *contactHandler) GetContacts(c *gin.Context) {
+	var company_id uint
+	if raw := c.Query("company_id"); raw != "" {
+		intCompanyId, err := strconv.Atoi(raw)
+		if err != nil || intCompanyId <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company_id"})
+			return
+		}
+		company_id = uint(intCompanyId)
+	}
+	h.listContacts(c, company_id, false)
+}
+
+// GetCompanyContacts lists the people of the company in the :id param.
+func (h *contactHandler) GetCompanyContacts(c *gin.Context) {
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+	h.listContacts(c, uint(intId), true)
+}
+
+// listContacts serves GET /contacts and GET /companies/:id/contacts. With
+// check_company a company that is not the user's is an error; as a plain
+// filter it just matches no contacts.
+func (h *contactHandler) listContacts(c *gin.Context, company_id uint, check_company bool) {
 	page := c.DefaultQuery("page", "1")
 	limit := c.DefaultQuery("limit", "10")
 	user_id, ok := c.Get("user_id")
@@ -45,8 +85,26 @@ func (h *contactHandler) GetContacts(c *gin.Context) {
 		return
 	}
 
-	response, err := h.svc.GetContacts(intPage, intLimit, user_id.(int), search)
+	query := ContactQuery{
+		CustomFields: c.QueryMap("cf"),
+		Sort:         c.Query("sort"),
+		Order:        c.Query("order"),
+		WorkspaceID:  c.GetUint("workspace_id"),
+		CompanyID:    company_id,
+		Tag:          c.Query("tag"),
+	}
+
+	var response *GetContactsResponse
+	if check_company {
+		response, err = h.svc.GetCompanyContacts(company_id, intPage, intLimit, int(user_id.(uint)), search, query)
+	} else {
+		response, err = h.svc.GetContacts(intPage, intLimit, int(user_id.(uint)), search, query)
+	}
 	if err != nil {
+		if errors.Is(err, customfields.ErrInvalidValue) || errors.Is(err, ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -59,13 +117,32 @@ func (h *contactHandler) GetContacts(c *gin.Context) {
 
 
 func (h *contactHandler) CreateContact(c *gin.Context) {
-	var contact Contact
-	if err := c.ShouldBindJSON(&contact); err != nil {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request CreateContactRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	contact := request.Contact(user_id.(uint))
+	if workspace_id := c.GetUint("workspace_id"); workspace_id != workspaces.Personal {
+		contact.WorkspaceID = &workspace_id
+	}
+
 	contact_db, err := h.svc.CreateContact(contact)
 	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, phone.ErrInvalidNumber) || errors.Is(err, customfields.ErrInvalidValue) || errors.Is(err, ErrInvalidTag) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -89,14 +166,31 @@ func (h *contactHandler) FindContactById(c *gin.Context) {
 		return
 	}
 
-	contact, err := h.svc.FindContactById(uint(intId), user_id.(uint))
+	names, err := parseIncludes(c.Query("include"), h.includes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contact, err := h.svc.FindContactById(uint(intId), user_id.(uint), c.GetUint("workspace_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+
+	detail := ContactDetail{Contact: contact, Included: map[string]any{}}
+	for _, name := range names {
+		included, err := h.includes[name](contact)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		detail.Included[name] = included
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Contact found successfully",
-		"data":    contact,
+		"data":    detail,
 	})
 }
 
@@ -114,14 +208,23 @@ func (h *contactHandler) UpdateContact(c *gin.Context) {
 		return
 	}
 
-	var contact Contact
-	if err := c.ShouldBindJSON(&contact); err != nil {
+	var request UpdateContactRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	contact := request.Contact()
 
-	err = h.svc.UpdateContact(uint(intId), user_id.(uint), contact)
+	err = h.svc.UpdateContact(uint(intId), user_id.(uint), c.GetUint("workspace_id"), contact)
 	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, phone.ErrInvalidNumber) || errors.Is(err, customfields.ErrInvalidValue) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -145,8 +248,12 @@ func (h *contactHandler) DeleteContact(c *gin.Context) {
 		return
 	}
 
-	err = h.svc.DeleteContact(uint(intId), user_id.(uint))
+	err = h.svc.DeleteContact(uint(intId), user_id.(uint), c.GetUint("workspace_id"))
 	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -154,3 +261,160 @@ func (h *contactHandler) DeleteContact(c *gin.Context) {
 		"message": "Contact deleted successfully",
 	})
 }
+
+func (h *contactHandler) GetHistory(c *gin.Context) {
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intPage, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || intPage < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	intLimit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || intLimit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	response, err := h.svc.GetHistory(uint(intId), user_id.(uint), c.GetUint("workspace_id"), intPage, intLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact history retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *contactHandler) RestoreContact(c *gin.Context) {
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	revisionId, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision id"})
+		return
+	}
+
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	contact, err := h.svc.RestoreContact(uint(intId), user_id.(uint), c.GetUint("workspace_id"), uint(revisionId))
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact restored successfully",
+		"data":    contact,
+	})
+}
+
+func (h *contactHandler) FavoriteContact(c *gin.Context) {
+	h.setFavorite(c, true)
+}
+
+func (h *contactHandler) UnfavoriteContact(c *gin.Context) {
+	h.setFavorite(c, false)
+}
+
+func (h *contactHandler) setFavorite(c *gin.Context, favorite bool) {
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	contact, err := h.svc.SetFavorite(uint(intId), user_id.(uint), c.GetUint("workspace_id"), favorite)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Contact added to favorites"
+	if !favorite {
+		message = "Contact removed from favorites"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    contact,
+	})
+}
+
+func (h *contactHandler) GetSortPreference(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	preference, err := h.svc.GetSortPreference(user_id.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sort preference retrieved successfully",
+		"data":    preference,
+	})
+}
+
+func (h *contactHandler) UpdateSortPreference(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var preference SortPreference
+	if err := c.ShouldBindJSON(&preference); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.SaveSortPreference(user_id.(uint), preference)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sort preference updated successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,170 @@
+package contacts
+
+import (
+	"strconv"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+)
+
+// contactColumns are the contact columns recorded in the history. Birthday
+// and anniversary are nullable dates, company_id is a nullable reference and
+// the others are strings.
+var contactColumns = map[string]bool{
+	"first_name":  true,
+	"last_name":   true,
+	"email":       true,
+	"phone":       true,
+	"phone_e164":  true,
+	"job_title":   true,
+	"company_id":  true,
+	"birthday":    true,
+	"anniversary": true,
+}
+
+// addressColumns are the address columns recorded in the history by the
+// addresses package.
+var addressColumns = map[string]bool{
+	"street":      true,
+	"city":        true,
+	"state":       true,
+	"postal_code": true,
+	"country":     true,
+	"type":        true,
+}
+
+const customFieldPrefix = "cf."
+
+// RestorePlan holds the writes that take a contact and its addresses back to
+// an earlier revision.
+type RestorePlan struct {
+	Contact   []history.Change
+	Addresses []AddressRestore
+}
+
+// AddressRestore reverts one address. Action is history.ActionRestore when
+// only fields change, history.ActionDelete when the address did not exist
+// yet and history.ActionCreate when it was deleted since.
+type AddressRestore struct {
+	AddressID uint
+	Action    string
+	Changes   []history.Change
+}
+
+// Snapshot returns the recorded fields of the contact keyed by column, with
+// custom fields keyed cf.<key>. CustomValues must be preloaded with Field.
+func (c Contact) Snapshot() map[string]string {
+	fields := map[string]string{
+		"first_name": c.FirstName,
+		"last_name":  c.LastName,
+		"email":      c.Email,
+		"phone":      c.Phone,
+		"phone_e164": c.PhoneE164,
+		"job_title":  c.JobTitle,
+	}
+	if c.CompanyID != nil {
+		fields["company_id"] = strconv.FormatUint(uint64(*c.CompanyID), 10)
+	}
+	if c.Birthday != nil {
+		fields["birthday"] = c.Birthday.String()
+	}
+	if c.Anniversary != nil {
+		fields["anniversary"] = c.Anniversary.String()
+	}
+	for _, value := range c.CustomValues {
+		if value.Field.Key != "" {
+			fields[customFieldPrefix+value.Field.Key] = value.Value
+		}
+	}
+	return fields
+}
+
+// planRestore works back through the revisions recorded after the target
+// revision, newest first, and collects the value every field had at the
+// target. Each change in the plan goes from the current value to that one.
+func planRestore(revisions []history.Revision) RestorePlan {
+	contact := newFieldPlan()
+	addresses := make(map[uint]*addressPlan)
+	var address_order []uint
+
+	for _, revision := range revisions {
+		if revision.Entity == history.EntityContact {
+			contact.revert(revision.Changes)
+			continue
+		}
+
+		address, ok := addresses[revision.EntityID]
+		if !ok {
+			address = &addressPlan{fields: newFieldPlan(), newest: revision.Action}
+			addresses[revision.EntityID] = address
+			address_order = append(address_order, revision.EntityID)
+		}
+		address.oldest = revision.Action
+		address.fields.revert(revision.Changes)
+	}
+
+	plan := RestorePlan{Contact: contact.changes()}
+	for _, address_id := range address_order {
+		address := addresses[address_id]
+		// An address created or taken out of the trash after the target did
+		// not exist at the target.
+		existed := address.oldest != history.ActionCreate && address.oldest != history.ActionUndelete
+		exists := address.newest != history.ActionDelete
+
+		restore := AddressRestore{AddressID: address_id, Action: history.ActionRestore}
+		switch {
+		case !existed && !exists:
+			continue
+		case !existed:
+			restore.Action = history.ActionDelete
+		case !exists:
+			restore.Action = history.ActionCreate
+		}
+		if existed {
+			restore.Changes = address.fields.changes()
+		}
+		if restore.Action == history.ActionRestore && len(restore.Changes) == 0 {
+			continue
+		}
+		plan.Addresses = append(plan.Addresses, restore)
+	}
+	return plan
+}
+
+type addressPlan struct {
+	fields *fieldPlan
+	newest string
+	oldest string
+}
+
+// fieldPlan tracks, per field, the current value and the value at the
+// target revision.
+type fieldPlan struct {
+	order   []string
+	current map[string]*string
+	target  map[string]*string
+}
+
+func newFieldPlan() *fieldPlan {
+	return &fieldPlan{current: make(map[string]*string), target: make(map[string]*string)}
+}
+
+func (p *fieldPlan) revert(changes []history.Change) {
+	for _, change := range changes {
+		if _, ok := p.current[change.Field]; !ok {
+			p.order = append(p.order, change.Field)
+			p.current[change.Field] = change.NewValue
+		}
+		p.target[change.Field] = change.OldValue
+	}
+}
+
+func (p *fieldPlan) changes() []history.Change {
+	var changes []history.Change
+	for _, field := range p.order {
+		if history.Value(p.current[field]) == history.Value(p.target[field]) {
+			continue
+		}
+		changes = append(changes, history.Change{Field: field, OldValue: p.current[field], NewValue: p.target[field]})
+	}
+	return changes
+}
@@ -0,0 +1,55 @@
+package contacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IncludeFunc loads records of another package that GET /contacts/:id
+// embeds when they are named in ?include=. The contact has already been
+// checked to be visible to the caller.
+type IncludeFunc func(contact *Contact) (any, error)
+
+// ContactDetail is a contact with the included records added next to its
+// own fields, keyed by include name.
+type ContactDetail struct {
+	*Contact
+	Included map[string]any
+}
+
+func (d ContactDetail) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(d.Contact)
+	if err != nil || len(d.Included) == 0 {
+		return data, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range d.Included {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = raw
+	}
+	return json.Marshal(fields)
+}
+
+// parseIncludes splits a comma separated ?include= value and checks every
+// name is registered.
+func parseIncludes(raw string, includes map[string]IncludeFunc) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := includes[name]; !ok {
+			return nil, fmt.Errorf("unknown include %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
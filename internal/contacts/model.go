@@ -3,6 +3,9 @@ package contacts
 import (
 	"time"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/date"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
 
 	"gorm.io/gorm"
@@ -11,39 +14,160 @@ type Contact struct {
 	ID		uint		`gorm:"column:contact_id;primaryKey" json:"id"`
 	UserID    uint       `gorm:"not null;index" json:"user_id"`
 	User      users.User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
-	FirstName string     `gorm:"type:varchar(255);not null" json:"first_name"`
-	LastName  string     `gorm:"type:varchar(255)" json:"last_name"`
-	Email     string     `gorm:"type:varchar(255);not null" json:"email"`
-	Phone     string     `gorm:"type:varchar(255)" json:"phone"`
+	WorkspaceID *uint    `gorm:"index" json:"workspace_id"`
+	FirstName string     `gorm:"type:varchar(255);not null;index:ft_contacts_search,class:FULLTEXT" json:"first_name"`
+	LastName  string     `gorm:"type:varchar(255);index:ft_contacts_search,class:FULLTEXT" json:"last_name"`
+	Email     string     `gorm:"type:varchar(255);not null;index:ft_contacts_search,class:FULLTEXT" json:"email"`
+	Phone     string     `gorm:"type:varchar(255);index:ft_contacts_search,class:FULLTEXT" json:"phone"`
+	PhoneE164 string     `gorm:"column:phone_e164;type:varchar(16);index" json:"phone_e164"`
+	CompanyID *uint      `gorm:"index" json:"company_id"`
+	Company   *companies.Company `gorm:"foreignKey:CompanyID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"company,omitempty"`
+	// CompanyName links the contact to the owner's company of that name,
+	// creating it when there is none. It is never stored.
+	CompanyName string   `gorm:"-" json:"-"`
+	JobTitle  string     `gorm:"type:varchar(100);index:ft_contacts_search,class:FULLTEXT" json:"job_title"`
+	Birthday    *date.Date `gorm:"type:date" json:"birthday"`
+	Anniversary *date.Date `gorm:"type:date" json:"anniversary"`
+	Favorite  bool       `gorm:"not null;default:false;index" json:"favorite"`
+	LastInteractionAt *time.Time `gorm:"index" json:"last_interaction_at"`
 	CreatedAt time.Time      `json:"created_at"`
     UpdatedAt time.Time      `json:"updated_at"`
     DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	CustomValues []customfields.Value `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	CustomFields map[string]any       `gorm:"-" json:"custom_fields,omitempty"`
+	Relationships []Relationship      `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"relationships,omitempty"`
+	Tags         []Tag                `gorm:"foreignKey:ContactID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"tags,omitempty"`
 }
 
 func (Contact) TableName() string {
 	return "contacts"
 }
+// CreateContactRequest is the body of POST /contacts. Only these fields can
+// be set by the client; ids, favorites, interaction times and associations
+// such as relationships are managed by their own endpoints.
 type CreateContactRequest struct {
-	FirstName string `json:"first_name" binding:"required,min=2,max=100"`
-	LastName  string `json:"last_name" binding:"omitempty,max=100"`
-	Email     string `json:"email" binding:"required,email"`
-	Phone     string `json:"phone" binding:"omitempty,max=20"`
+	FirstName    string         `json:"first_name" binding:"required,min=2,max=100"`
+	LastName     string         `json:"last_name" binding:"omitempty,max=100"`
+	Email        string         `json:"email" binding:"required,email"`
+	Phone        string         `json:"phone" binding:"omitempty,max=20"`
+	CompanyID    *uint          `json:"company_id"`
+	CompanyName  string         `json:"company_name" binding:"omitempty,max=255"`
+	JobTitle     string         `json:"job_title" binding:"omitempty,max=100"`
+	Birthday     *date.Date     `json:"birthday"`
+	Anniversary  *date.Date     `json:"anniversary"`
+	Tags         []string       `json:"tags"`
+	CustomFields map[string]any `json:"custom_fields"`
 }
 
+// Contact returns the contact the request creates for user_id.
+func (r CreateContactRequest) Contact(user_id uint) Contact {
+	contact := Contact{
+		UserID:       user_id,
+		FirstName:    r.FirstName,
+		LastName:     r.LastName,
+		Email:        r.Email,
+		Phone:        r.Phone,
+		CompanyID:    r.CompanyID,
+		CompanyName:  r.CompanyName,
+		JobTitle:     r.JobTitle,
+		Birthday:     r.Birthday,
+		Anniversary:  r.Anniversary,
+		CustomFields: r.CustomFields,
+	}
+	for _, name := range r.Tags {
+		contact.Tags = append(contact.Tags, Tag{Name: name})
+	}
+	return contact
+}
+
+// UpdateContactRequest is the body of PUT /contacts/:id. Empty fields keep
+// their stored value; a company_id of 0 unlinks the company. The company is
+// given by company_id or by company_name, never as an object.
 type UpdateContactRequest struct {
-	FirstName string `json:"first_name" binding:"omitempty,min=2,max=100"`
-	LastName  string `json:"last_name" binding:"omitempty,max=100"`
-	Email     string `json:"email" binding:"omitempty,email"`
-	Phone     string `json:"phone" binding:"omitempty,max=20"`
+	FirstName    string         `json:"first_name" binding:"omitempty,min=2,max=100"`
+	LastName     string         `json:"last_name" binding:"omitempty,max=100"`
+	Email        string         `json:"email" binding:"omitempty,email"`
+	Phone        string         `json:"phone" binding:"omitempty,max=20"`
+	CompanyID    *uint          `json:"company_id"`
+	CompanyName  string         `json:"company_name" binding:"omitempty,max=255"`
+	JobTitle     string         `json:"job_title" binding:"omitempty,max=100"`
+	Birthday     *date.Date     `json:"birthday"`
+	Anniversary  *date.Date     `json:"anniversary"`
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// Contact returns the changes of the request as a contact.
+func (r UpdateContactRequest) Contact() Contact {
+	return Contact{
+		FirstName:    r.FirstName,
+		LastName:     r.LastName,
+		Email:        r.Email,
+		Phone:        r.Phone,
+		CompanyID:    r.CompanyID,
+		CompanyName:  r.CompanyName,
+		JobTitle:     r.JobTitle,
+		Birthday:     r.Birthday,
+		Anniversary:  r.Anniversary,
+		CustomFields: r.CustomFields,
+	}
 }
 
 type ContactResponse struct {
 	ID        uint   `json:"id"`
 	UserID    uint   `json:"user_id"`
+	WorkspaceID *uint `json:"workspace_id"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
 	Phone     string `json:"phone"`
+	PhoneE164 string `json:"phone_e164"`
+	CompanyID *uint  `json:"company_id"`
+	JobTitle  string `json:"job_title"`
+	Tags      []Tag  `json:"tags,omitempty"`
+	Birthday    *date.Date `json:"birthday"`
+	Anniversary *date.Date `json:"anniversary"`
+	Favorite  bool   `json:"favorite"`
+	LastInteractionAt *time.Time `json:"last_interaction_at"`
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+// ContactQuery holds the list options of GET /contacts as sent by the client.
+// Custom fields are addressed by key, e.g. cf[company]=Acme and sort=cf.company.
+// Sort also accepts the keys of sortColumns and favorites. Without a sort the
+// user's stored preference applies. WorkspaceID is the active workspace and
+// CompanyID and Tag, when set, limit the list to the people of that company
+// and the contacts with that tag.
+type ContactQuery struct {
+	WorkspaceID  uint
+	CompanyID    uint
+	Tag          string
+	CustomFields map[string]string
+	Sort         string
+	Order        string
+}
+
+// ContactFilter is a ContactQuery with custom field keys resolved against the
+// user's field definitions.
+type ContactFilter struct {
+	WorkspaceID  uint
+	CompanyID    uint
+	Tag          string
+	CustomFields []CustomFieldFilter
+	SortField    *customfields.Field
+	SortColumns  []string
+	FavoritesFirst bool
+	Order        string
+}
+
+// SortPreference is the sort GET /contacts uses when the request has none.
+type SortPreference struct {
+	Sort  string `json:"sort"`
+	Order string `json:"order" binding:"omitempty,oneof=asc desc ASC DESC"`
+}
+
+type CustomFieldFilter struct {
+	FieldID uint
+	Value   string
 }
 
 type GetContactsResponse struct {
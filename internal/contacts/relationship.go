@@ -0,0 +1,30 @@
+package contacts
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Relationship says that Related is the Type of Contact, e.g. with Type
+// manager, Related is Contact's manager. A bidirectional relationship is
+// stored as two rows, one in each direction.
+type Relationship struct {
+	ID        uint      `gorm:"column:relationship_id;primaryKey" json:"id"`
+	ContactID uint      `gorm:"not null;uniqueIndex:idx_contact_relationships_pair" json:"contact_id"`
+	RelatedID uint      `gorm:"not null;uniqueIndex:idx_contact_relationships_pair;index" json:"related_id"`
+	Related   *Contact  `gorm:"foreignKey:RelatedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"related,omitempty"`
+	Type      string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_contact_relationships_pair" json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Relationship) TableName() string {
+	return "contact_relationships"
+}
+
+// WithLiveRelated limits relationships to those whose related contact is not
+// in the trash. The rows themselves go when the contact is purged.
+func WithLiveRelated(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN contacts related_contact ON related_contact.contact_id = contact_relationships.related_id AND related_contact.deleted_at IS NULL").
+		Order("contact_relationships.relationship_id")
+}
@@ -1,13 +1,43 @@
 package contacts
 
-import "gorm.io/gorm"
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/phone"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 type ContactRepository interface {
-	GetContacts(page, limit, user_id int, search string) (*GetContactsResponse, error)
+	GetContacts(page, limit, user_id int, search string, filter ContactFilter) (*GetContactsResponse, error)
 	CreateContact(contact Contact) (*ContactResponse, error)
-	FindContactById(id, user_id uint) (*Contact, error)
-	UpdateContact(id, user_id uint, contact *Contact) error
-	DeleteContact(id, user_id uint) error
+	FindContactById(id, user_id, workspace_id uint) (*Contact, error)
+	UpdateContact(id, user_id, workspace_id uint, contact *Contact) error
+	DeleteContact(id, user_id, workspace_id uint) error
+	FindUserRegion(user_id uint) (string, error)
+	GetContactsWithoutPhoneE164(after_id uint, limit int) ([]Contact, error)
+	SetPhoneE164(id uint, phone_e164 string) error
+	GetCustomFields(user_id uint) ([]customfields.Field, error)
+	FindCompany(id, user_id uint) (*companies.Company, error)
+	FindOrCreateCompany(user_id uint, name string) (*companies.Company, error)
+	AddTags(id uint, tags []string) error
+	RemoveTags(id uint, tags []string) error
+	CanEditContact(id, user_id, workspace_id uint) (bool, error)
+	FindWorkspaceRole(workspace_id, user_id uint) (string, error)
+	GetRevisions(contact_id uint, page, limit int) (*history.GetRevisionsResponse, error)
+	FindRevision(contact_id, revision_id uint) (*history.Revision, error)
+	GetRevisionsAfter(contact_id, revision_id uint) ([]history.Revision, error)
+	RestoreContact(id, user_id uint, plan RestorePlan) error
+	SetFavorite(id uint, favorite bool) error
+	FindSortPreference(user_id uint) (*SortPreference, error)
+	SaveSortPreference(user_id uint, preference SortPreference) error
 }
 
 type contactRepository struct {
@@ -18,14 +48,31 @@ func NewContactRepository(db *gorm.DB) ContactRepository {
 	return &contactRepository{db: db}
 }
 
-func (c *contactRepository) GetContacts(page, limit, user_id int, search string) (*GetContactsResponse, error) {
+func (c *contactRepository) GetContacts(page, limit, user_id int, search string, filter ContactFilter) (*GetContactsResponse, error) {
 	var contacts []Contact
 	var total int64
 
 	// Build query dengan search filter
-	query := c.db.Model(&Contact{}).Where("user_id = ?", user_id)
+	query := c.db.Model(&Contact{}).Scopes(AccessibleBy(uint(user_id), filter.WorkspaceID, PermissionView))
 	if search != "" {
-		query = query.Where("first_name LIKE ? OR last_name LIKE ? OR email LIKE ? OR phone LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+		conditions := "first_name LIKE ? OR last_name LIKE ? OR email LIKE ? OR phone LIKE ?"
+		args := []interface{}{"%" + search + "%", "%" + search + "%", "%" + search + "%", "%" + search + "%"}
+		// Phone numbers are matched on their digits so the formatting used
+		// in the search term does not matter.
+		if digits := phone.SearchDigits(search); digits != "" {
+			conditions += " OR phone_e164 LIKE ?"
+			args = append(args, "%"+digits+"%")
+		}
+		query = query.Where(conditions, args...)
+	}
+	if filter.CompanyID != 0 {
+		query = query.Where("contacts.company_id = ?", filter.CompanyID)
+	}
+	if filter.Tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM contact_tags ct WHERE ct.contact_id = contacts.contact_id AND ct.name = ?)", filter.Tag)
+	}
+	for _, custom := range filter.CustomFields {
+		query = query.Where("EXISTS (SELECT 1 FROM contact_custom_values cv WHERE cv.contact_id = contacts.contact_id AND cv.field_id = ? AND cv.value = ?)", custom.FieldID, custom.Value)
 	}
 
 	// Count total records (sebelum pagination)
@@ -33,10 +80,41 @@ func (c *contactRepository) GetContacts(page, limit, user_id int, search string)
 		return nil, err
 	}
 
+	// Favorites come first; SortColumns then orders each part by name.
+	if filter.FavoritesFirst {
+		query = query.Order("contacts.favorite DESC")
+	}
+	if filter.SortField != nil {
+		column := "sort_cv.value"
+		switch filter.SortField.Type {
+		case customfields.TypeNumber:
+			column = "sort_cv.number_value"
+		case customfields.TypeDate:
+			column = "sort_cv.date_value"
+		}
+		// Contacts without a value for the field are listed last in both
+		// directions.
+		query = query.Select("contacts.*").
+			Joins("LEFT JOIN contact_custom_values sort_cv ON sort_cv.contact_id = contacts.contact_id AND sort_cv.field_id = ?", filter.SortField.ID).
+			Order(column + " IS NULL").
+			Order(clause.OrderByColumn{Column: clause.Column{Raw: true, Name: column}, Desc: filter.Order == "desc"})
+	}
+	for _, sort_column := range filter.SortColumns {
+		column := "contacts." + sort_column
+		query = query.Order(column + " IS NULL").
+			Order(clause.OrderByColumn{Column: clause.Column{Raw: true, Name: column}, Desc: filter.Order == "desc"})
+	}
+	// The id keeps pages stable when the sort columns tie, and is the order
+	// when there is no sort at all.
+	query = query.Order("contacts.contact_id")
+
 	// Get paginated data
-	if err := query.Offset((page - 1) * limit).Limit(limit).Find(&contacts).Error; err != nil {
+	if err := query.Preload("CustomValues.Field").Preload("Company").Preload("Tags").Offset((page - 1) * limit).Limit(limit).Find(&contacts).Error; err != nil {
 		return nil, err
 	}
+	for i := range contacts {
+		attachCustomFields(&contacts[i])
+	}
 
 	// Hitung total pages
 	totalPages := int(total) / limit
@@ -54,31 +132,71 @@ func (c *contactRepository) GetContacts(page, limit, user_id int, search string)
 }
 
 func (c *contactRepository) CreateContact(contact Contact) (*ContactResponse, error) {
-	if err := c.db.Create(&contact).Error; err != nil {
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		// Tags and custom values are created with the contact; the other
+		// associations are never written through it.
+		if err := tx.Omit("User", "Company", "Relationships").Create(&contact).Error; err != nil {
+			return err
+		}
+		var created Contact
+		if err := tx.Preload("CustomValues.Field").Where("contact_id = ?", contact.ID).First(&created).Error; err != nil {
+			return err
+		}
+		return history.Record(tx, &history.Revision{
+			ContactID: contact.ID,
+			Entity:    history.EntityContact,
+			EntityID:  contact.ID,
+			Action:    history.ActionCreate,
+			AuthorID:  contact.UserID,
+			Changes:   history.Diff(nil, created.Snapshot()),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &ContactResponse{
 		ID:    contact.ID,
+		UserID: contact.UserID,
+		WorkspaceID: contact.WorkspaceID,
 		FirstName:  contact.FirstName,
 		LastName: contact.LastName,
 		Email: contact.Email,
 		Phone: contact.Phone,
+		PhoneE164: contact.PhoneE164,
+		CompanyID: contact.CompanyID,
+		JobTitle: contact.JobTitle,
+		Tags: contact.Tags,
+		Birthday: contact.Birthday,
+		Anniversary: contact.Anniversary,
+		Favorite: contact.Favorite,
+		CustomFields: contact.CustomFields,
 	}, nil
 }
 
-func (c *contactRepository) FindContactById(id, user_id uint) (*Contact, error) {
+func (c *contactRepository) FindContactById(id, user_id, workspace_id uint) (*Contact, error) {
 	var contact Contact
-	if err := c.db.Where("contact_id = ? AND user_id = ?", id, user_id).First(&contact).Error; err != nil {
+	err := c.db.Preload("CustomValues.Field").
+		Preload("Company").
+		Preload("Tags").
+		Preload("Relationships", WithLiveRelated).
+		Preload("Relationships.Related").
+		Scopes(AccessibleBy(user_id, workspace_id, PermissionView)).
+		Where("contact_id = ?", id).
+		First(&contact).Error
+	if err != nil {
 		return nil, err
 	}
+	attachCustomFields(&contact)
 	return &contact, nil
 }
 
-func (c *contactRepository) UpdateContact(id uint, user_id uint, contact *Contact) error {
+func (c *contactRepository) UpdateContact(id uint, user_id uint, workspace_id uint, contact *Contact) error {
 	var contact_db Contact
-	if err := c.db.Where("contact_id = ? AND user_id = ?", id, user_id).First(&contact_db).Error; err != nil {
+	if err := c.db.Preload("CustomValues.Field").Scopes(AccessibleBy(user_id, workspace_id, PermissionEdit)).Where("contact_id = ?", id).First(&contact_db).Error; err != nil {
 		return err
 	}
+	before := contact_db.Snapshot()
+	contact_db.CustomValues = nil
 
 	if contact.FirstName != "" {
 		contact_db.FirstName = contact.FirstName
@@ -91,17 +209,457 @@ func (c *contactRepository) UpdateContact(id uint, user_id uint, contact *Contac
 	}
 	if contact.Phone != "" {
 		contact_db.Phone = contact.Phone
+		contact_db.PhoneE164 = contact.PhoneE164
+	}
+	if contact.Birthday != nil {
+		contact_db.Birthday = contact.Birthday
+	}
+	if contact.Anniversary != nil {
+		contact_db.Anniversary = contact.Anniversary
+	}
+	// The service has resolved the company already; nil unlinks it.
+	contact_db.CompanyID = contact.CompanyID
+	if contact.JobTitle != "" {
+		contact_db.JobTitle = contact.JobTitle
+	}
+
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Company", "Relationships", "Tags").Save(&contact_db).Error; err != nil {
+			return err
+		}
+		// contact.CustomValues only carries the fields that changed.
+		if err := saveCustomValues(tx, id, contact.CustomValues); err != nil {
+			return err
+		}
+		return RecordChanges(tx, id, user_id, history.ActionUpdate, before)
+	})
+}
+
+// DeleteContact moves the contact and its addresses to the trash. They share
+// one deleted_at so restoring the contact brings back exactly the addresses
+// deleted with it.
+func (c *contactRepository) DeleteContact(id uint, user_id uint, workspace_id uint) error {
+	deleted_at := time.Now()
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Contact{}).Scopes(InWorkspace(user_id, workspace_id, PermissionEdit)).Where("contact_id = ?", id).Update("deleted_at", deleted_at)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := history.Record(tx, &history.Revision{
+			ContactID: id,
+			Entity:    history.EntityContact,
+			EntityID:  id,
+			Action:    history.ActionDelete,
+			AuthorID:  user_id,
+		}); err != nil {
+			return err
+		}
+
+		var address_ids []uint
+		if err := tx.Table("addresses").Where("contact_id = ? AND deleted_at IS NULL", id).Pluck("address_id", &address_ids).Error; err != nil {
+			return err
+		}
+		if len(address_ids) == 0 {
+			return nil
+		}
+		if err := tx.Table("addresses").Where("address_id IN ?", address_ids).Update("deleted_at", deleted_at).Error; err != nil {
+			return err
+		}
+		for _, address_id := range address_ids {
+			if err := history.Record(tx, &history.Revision{
+				ContactID: id,
+				Entity:    history.EntityAddress,
+				EntityID:  address_id,
+				Action:    history.ActionDelete,
+				AuthorID:  user_id,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (c *contactRepository) FindUserRegion(user_id uint) (string, error) {
+	var user users.User
+	if err := c.db.Select("region").Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return "", err
+	}
+	return user.Region, nil
+}
+
+// GetContactsWithoutPhoneE164 pages by id through the contacts, trashed ones
+// included, that have a phone number but no E.164 form.
+func (c *contactRepository) GetContactsWithoutPhoneE164(after_id uint, limit int) ([]Contact, error) {
+	var contacts []Contact
+	err := c.db.Unscoped().
+		Select("contact_id", "user_id", "phone").
+		Where("contact_id > ? AND phone_e164 IS NULL AND phone <> ''", after_id).
+		Order("contact_id").
+		Limit(limit).
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (c *contactRepository) SetPhoneE164(id uint, phone_e164 string) error {
+	return c.db.Unscoped().Model(&Contact{}).Where("contact_id = ?", id).UpdateColumn("phone_e164", phone_e164).Error
+}
+
+func (c *contactRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	var fields []customfields.Field
+	if err := c.db.Where("user_id = ?", user_id).Find(&fields).Error; err != nil {
+		return nil, err
 	}
+	return fields, nil
+}
+
+func (c *contactRepository) FindCompany(id, user_id uint) (*companies.Company, error) {
+	var company companies.Company
+	if err := c.db.Where("company_id = ? AND user_id = ?", id, user_id).First(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// FindOrCreateCompany returns the user's company with the given name,
+// creating it when there is none.
+func (c *contactRepository) FindOrCreateCompany(user_id uint, name string) (*companies.Company, error) {
+	company := companies.Company{UserID: user_id, Name: name}
+	if err := c.db.Where(companies.Company{UserID: user_id, Name: name}).FirstOrCreate(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (c *contactRepository) CanEditContact(id, user_id, workspace_id uint) (bool, error) {
+	var count int64
+	err := c.db.Model(&Contact{}).Scopes(AccessibleBy(user_id, workspace_id, PermissionEdit)).Where("contact_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// FindWorkspaceRole returns user_id's role in the workspace, or "" when
+// they are not a member.
+func (c *contactRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	var role string
+	err := c.db.Table("workspace_members").Select("role").
+		Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).
+		Limit(1).Scan(&role).Error
+	return role, err
+}
+
+// SetFavorite leaves updated_at alone; pinning a contact is not an edit.
+func (c *contactRepository) SetFavorite(id uint, favorite bool) error {
+	return c.db.Model(&Contact{}).Where("contact_id = ?", id).UpdateColumn("favorite", favorite).Error
+}
+
+func (c *contactRepository) AddTags(id uint, tags []string) error {
+	rows := make([]Tag, len(tags))
+	for i, tag := range tags {
+		rows[i] = Tag{ContactID: id, Name: tag}
+	}
+	return c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (c *contactRepository) RemoveTags(id uint, tags []string) error {
+	return c.db.Where("contact_id = ? AND name IN ?", id, tags).Delete(&Tag{}).Error
+}
+
+func (c *contactRepository) FindSortPreference(user_id uint) (*SortPreference, error) {
+	var user users.User
+	if err := c.db.Select("contact_sort", "contact_order").Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &SortPreference{Sort: user.ContactSort, Order: user.ContactOrder}, nil
+}
+
+func (c *contactRepository) SaveSortPreference(user_id uint, preference SortPreference) error {
+	return c.db.Model(&users.User{}).Where("user_id = ?", user_id).Updates(map[string]any{
+		"contact_sort":  preference.Sort,
+		"contact_order": preference.Order,
+	}).Error
+}
+
+// attachCustomFields exposes the preloaded custom values as a key/value map.
+func attachCustomFields(contact *Contact) {
+	if len(contact.CustomValues) == 0 {
+		return
+	}
+	contact.CustomFields = make(map[string]any, len(contact.CustomValues))
+	for _, value := range contact.CustomValues {
+		if value.Field.Key != "" {
+			contact.CustomFields[value.Field.Key] = value.Typed()
+		}
+	}
+}
+
+func (c *contactRepository) GetRevisions(contact_id uint, page, limit int) (*history.GetRevisionsResponse, error) {
+	var revisions []history.Revision
+	var total int64
 
-	if err := c.db.Save(&contact_db).Error; err != nil {
+	query := c.db.Model(&history.Revision{}).Where("contact_id = ?", contact_id)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := query.Preload("Author").Preload("Changes").
+		Order("revision_id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &history.GetRevisionsResponse{
+		Data:       revisions,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (c *contactRepository) FindRevision(contact_id, revision_id uint) (*history.Revision, error) {
+	var revision history.Revision
+	if err := c.db.Where("revision_id = ? AND contact_id = ?", revision_id, contact_id).First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetRevisionsAfter returns the revisions recorded after revision_id, newest
+// first.
+func (c *contactRepository) GetRevisionsAfter(contact_id, revision_id uint) ([]history.Revision, error) {
+	var revisions []history.Revision
+	err := c.db.Preload("Changes").
+		Where("contact_id = ? AND revision_id > ?", contact_id, revision_id).
+		Order("revision_id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// RestoreContact applies a restore plan and records it as new revisions, so
+// a restore can itself be undone.
+func (c *contactRepository) RestoreContact(id, user_id uint, plan RestorePlan) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var contact_db Contact
+		if err := tx.Preload("CustomValues.Field").Where("contact_id = ?", id).First(&contact_db).Error; err != nil {
+			return err
+		}
+		before := contact_db.Snapshot()
+
+		columns := make(map[string]any)
+		var custom []history.Change
+		for _, change := range plan.Contact {
+			switch {
+			case strings.HasPrefix(change.Field, customFieldPrefix):
+				custom = append(custom, change)
+			case change.Field == "birthday" || change.Field == "anniversary":
+				columns[change.Field] = change.NewValue
+			case change.Field == "company_id":
+				// A company deleted since cannot be linked again.
+				if change.NewValue != nil {
+					err := tx.Where("company_id = ? AND user_id = ?", *change.NewValue, contact_db.UserID).First(&companies.Company{}).Error
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						continue
+					}
+					if err != nil {
+						return err
+					}
+				}
+				columns[change.Field] = change.NewValue
+			case contactColumns[change.Field]:
+				columns[change.Field] = history.Value(change.NewValue)
+			}
+		}
+		if len(columns) > 0 {
+			if err := tx.Model(&Contact{}).Where("contact_id = ?", id).Updates(columns).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(custom) > 0 {
+			// Values are parsed again so the typed columns are filled in; a
+			// field that has been deleted since cannot be restored.
+			var fields []customfields.Field
+			if err := tx.Where("user_id = ?", contact_db.UserID).Find(&fields).Error; err != nil {
+				return err
+			}
+			by_key := make(map[string]customfields.Field, len(fields))
+			for _, field := range fields {
+				by_key[field.Key] = field
+			}
+
+			var values []customfields.Value
+			for _, change := range custom {
+				field, ok := by_key[strings.TrimPrefix(change.Field, customFieldPrefix)]
+				if !ok {
+					continue
+				}
+				value, err := field.Parse(history.Value(change.NewValue))
+				if err != nil {
+					return err
+				}
+				values = append(values, value)
+			}
+			if err := saveCustomValues(tx, id, values); err != nil {
+				return err
+			}
+		}
+		if err := RecordChanges(tx, id, user_id, history.ActionRestore, before); err != nil {
+			return err
+		}
+
+		for _, address := range plan.Addresses {
+			if err := restoreAddress(tx, id, user_id, address); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// restoreAddress writes the addresses table directly; the addresses package
+// imports this one, not the other way round.
+func restoreAddress(tx *gorm.DB, contact_id, user_id uint, address AddressRestore) error {
+	query := tx.Table("addresses").Where("address_id = ? AND contact_id = ?", address.AddressID, contact_id)
+
+	columns := make(map[string]any)
+	for _, change := range address.Changes {
+		if addressColumns[change.Field] {
+			columns[change.Field] = history.Value(change.NewValue)
+		}
+	}
+	switch address.Action {
+	case history.ActionDelete:
+		columns["deleted_at"] = time.Now()
+	case history.ActionCreate:
+		columns["deleted_at"] = nil
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	if err := yieldPrimary(tx, contact_id, address.AddressID, columns); err != nil {
 		return err
 	}
-	return nil
+	columns["updated_at"] = time.Now()
+	// The address may have been purged from the trash since.
+	result := query.Updates(columns)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	return history.Record(tx, &history.Revision{
+		ContactID: contact_id,
+		Entity:    history.EntityAddress,
+		EntityID:  address.AddressID,
+		Action:    address.Action,
+		AuthorID:  user_id,
+		Changes:   address.Changes,
+	})
 }
 
-func (c *contactRepository) DeleteContact(id uint, user_id uint) error {
-	if err := c.db.Where("contact_id = ? AND user_id = ?", id, user_id).Delete(&Contact{}).Error; err != nil {
+// yieldPrimary keeps a restored address from becoming a second primary of
+// its type: when another live address of the contact took over as primary
+// in the meantime, the restored one is no longer primary.
+func yieldPrimary(tx *gorm.DB, contact_id, address_id uint, columns map[string]any) error {
+	var current struct {
+		Type      string
+		IsPrimary bool
+		DeletedAt gorm.DeletedAt
+	}
+	err := tx.Table("addresses").Select("type, is_primary, deleted_at").
+		Where("address_id = ? AND contact_id = ?", address_id, contact_id).
+		Take(&current).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	live := !current.DeletedAt.Valid
+	if deleted_at, ok := columns["deleted_at"]; ok {
+		live = deleted_at == nil
+	}
+	if !current.IsPrimary || !live {
+		return nil
+	}
+	address_type := current.Type
+	if restored, ok := columns["type"].(string); ok {
+		address_type = restored
+	}
+
+	var others int64
+	err = tx.Table("addresses").
+		Where("contact_id = ? AND type = ? AND is_primary = ? AND address_id <> ? AND deleted_at IS NULL", contact_id, address_type, true, address_id).
+		Count(&others).Error
+	if err != nil {
 		return err
 	}
+	if others > 0 {
+		columns["is_primary"] = false
+	}
+	return nil
+}
+
+// saveCustomValues upserts custom values of a contact; an empty value
+// removes the field from the contact.
+func saveCustomValues(tx *gorm.DB, contact_id uint, values []customfields.Value) error {
+	for _, value := range values {
+		if value.Value == "" {
+			if err := tx.Where("contact_id = ? AND field_id = ?", contact_id, value.FieldID).Delete(&customfields.Value{}).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		value.ContactID = contact_id
+		err := tx.Omit("Field").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "contact_id"}, {Name: "field_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "number_value", "date_value"}),
+		}).Create(&value).Error
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// LoadSnapshot reads the recorded fields of a contact within tx, to pass as
+// before to RecordChanges.
+func LoadSnapshot(tx *gorm.DB, contact_id uint) (map[string]string, error) {
+	var contact Contact
+	if err := tx.Preload("CustomValues.Field").Where("contact_id = ?", contact_id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return contact.Snapshot(), nil
+}
+
+// RecordChanges reloads the contact and records how it differs from before;
+// a nil before records every field as new. The importers and the merge of
+// duplicates record their contact writes with it too.
+func RecordChanges(tx *gorm.DB, contact_id, user_id uint, action string, before map[string]string) error {
+	var after Contact
+	if err := tx.Preload("CustomValues.Field").Where("contact_id = ?", contact_id).First(&after).Error; err != nil {
+		return err
+	}
+	return history.Record(tx, &history.Revision{
+		ContactID: contact_id,
+		Entity:    history.EntityContact,
+		EntityID:  contact_id,
+		Action:    action,
+		AuthorID:  user_id,
+		Changes:   history.Diff(before, after.Snapshot()),
+	})
+}
@@ -2,16 +2,63 @@ package contacts
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/phone"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 	"gorm.io/gorm"
 )
 
+const (
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	// SortLastInteraction sorts contacts by their most recent activity.
+	SortLastInteraction = "last_interaction_at"
+	// SortFavorites lists favorites first, each part sorted by name.
+	SortFavorites = "favorites"
+	// SortFavoritesFirst is another name for SortFavorites.
+	SortFavoritesFirst = "favorites-first"
+)
+
+// sortColumns is the whitelist of sort= values that map to contact columns.
+// Custom fields are sorted with cf.<key> instead.
+var sortColumns = map[string][]string{
+	SortName:            {"first_name", "last_name"},
+	SortCreatedAt:       {"created_at"},
+	SortUpdatedAt:       {"updated_at"},
+	SortLastInteraction: {"last_interaction_at"},
+	"last_interaction":  {"last_interaction_at"},
+	SortFavorites:       {"first_name", "last_name"},
+	SortFavoritesFirst:  {"first_name", "last_name"},
+}
+
+// phoneBackfillBatchSize is the number of contacts loaded per query by
+// BackfillPhoneE164.
+const phoneBackfillBatchSize = 500
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+var ErrForbidden = errors.New("you do not have permission to change this contact")
+
 type ContactService interface {
-	GetContacts(page, limit, user_id int, search string) (*GetContactsResponse, error)
+	GetContacts(page, limit, user_id int, search string, query ContactQuery) (*GetContactsResponse, error)
+	GetCompanyContacts(company_id uint, page, limit, user_id int, search string, query ContactQuery) (*GetContactsResponse, error)
+	SetFavorite(id, user_id, workspace_id uint, favorite bool) (*Contact, error)
+	AddTags(id, user_id, workspace_id uint, tags []string) error
+	RemoveTags(id, user_id, workspace_id uint, tags []string) error
+	GetSortPreference(user_id uint) (*SortPreference, error)
+	SaveSortPreference(user_id uint, preference SortPreference) (*SortPreference, error)
 	CreateContact(contact Contact) (*ContactResponse, error)
-	FindContactById(id, user_id uint) (*Contact, error)
-	UpdateContact(id, user_id uint, contact Contact) error
-	DeleteContact(id, user_id uint) error
+	FindContactById(id, user_id, workspace_id uint) (*Contact, error)
+	UpdateContact(id, user_id, workspace_id uint, contact Contact) error
+	DeleteContact(id, user_id, workspace_id uint) error
+	GetHistory(id, user_id, workspace_id uint, page, limit int) (*history.GetRevisionsResponse, error)
+	RestoreContact(id, user_id, workspace_id, revision_id uint) (*Contact, error)
+	BackfillPhoneE164() (int, error)
 }
 
 type contactService struct {
@@ -22,15 +69,125 @@ func NewContactService(repo ContactRepository) ContactService {
 	return &contactService{repo: repo}
 }
 
-func (s *contactService) GetContacts(page, limit, user_id int, search string) (*GetContactsResponse, error) {
-	response, err := s.repo.GetContacts(page, limit, user_id, search)
+func (s *contactService) GetContacts(page, limit, user_id int, search string, query ContactQuery) (*GetContactsResponse, error) {
+	filter := ContactFilter{WorkspaceID: query.WorkspaceID, CompanyID: query.CompanyID, Tag: strings.ToLower(strings.TrimSpace(query.Tag))}
+
+	preference := SortPreference{Sort: query.Sort, Order: query.Order}
+	from_preference := false
+	if preference.Sort == "" {
+		stored, err := s.repo.FindSortPreference(uint(user_id))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if stored != nil && stored.Sort != "" {
+			preference.Sort = stored.Sort
+			if preference.Order == "" {
+				preference.Order = stored.Order
+			}
+			from_preference = true
+		}
+	}
+
+	var fields map[string]customfields.Field
+	if len(query.CustomFields) > 0 || strings.HasPrefix(preference.Sort, customFieldPrefix) {
+		var err error
+		fields, err = s.customFields(uint(user_id))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for key, raw := range query.CustomFields {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", customfields.ErrInvalidValue, key)
+		}
+		value, err := field.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		filter.CustomFields = append(filter.CustomFields, CustomFieldFilter{FieldID: field.ID, Value: value.Value})
+	}
+
+	if err := applySort(&filter, preference, fields); err != nil {
+		// A stored preference goes stale when its custom field is deleted;
+		// the list then falls back to the default order.
+		if !from_preference {
+			return nil, err
+		}
+		filter.SortField = nil
+		if err := applySort(&filter, SortPreference{Order: query.Order}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := s.repo.GetContacts(page, limit, user_id, search, filter)
 	if err != nil {
 		return nil, err
 	}
 	return response, nil
 }
 
+// GetCompanyContacts lists the people of one of the user's companies.
+func (s *contactService) GetCompanyContacts(company_id uint, page, limit, user_id int, search string, query ContactQuery) (*GetContactsResponse, error) {
+	if _, err := s.repo.FindCompany(company_id, uint(user_id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+	query.CompanyID = company_id
+	return s.GetContacts(page, limit, user_id, search, query)
+}
+
+// applySort validates a sort against the whitelist and the user's custom
+// fields and sets it on the filter.
+func applySort(filter *ContactFilter, preference SortPreference, fields map[string]customfields.Field) error {
+	filter.Order = strings.ToLower(preference.Order)
+	if filter.Order == "" {
+		filter.Order = "asc"
+	}
+	if filter.Order != "asc" && filter.Order != "desc" {
+		return fmt.Errorf("%w: order must be asc or desc", ErrInvalidSort)
+	}
+
+	if preference.Sort == "" {
+		return nil
+	}
+	if columns, ok := sortColumns[preference.Sort]; ok {
+		filter.SortColumns = columns
+		filter.FavoritesFirst = preference.Sort == SortFavorites || preference.Sort == SortFavoritesFirst
+		return nil
+	}
+
+	key, ok := strings.CutPrefix(preference.Sort, customFieldPrefix)
+	field, known := fields[key]
+	if !ok || !known {
+		return fmt.Errorf("%w: %q", ErrInvalidSort, preference.Sort)
+	}
+	filter.SortField = &field
+	return nil
+}
+
 func (s *contactService) CreateContact(contact Contact) (*ContactResponse, error) {
+	if contact.WorkspaceID != nil {
+		if err := s.checkWorkspaceRole(*contact.WorkspaceID, contact.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.normalizePhone(contact.UserID, &contact); err != nil {
+		return nil, err
+	}
+	if err := s.resolveCompany(contact.UserID, &contact); err != nil {
+		return nil, err
+	}
+	if err := normalizeContactTags(&contact); err != nil {
+		return nil, err
+	}
+	if err := s.parseCustomFields(contact.UserID, &contact, true); err != nil {
+		return nil, err
+	}
+
 	contact_db, err := s.repo.CreateContact(contact)
 	if err != nil {
 		return nil, err
@@ -38,8 +195,8 @@ func (s *contactService) CreateContact(contact Contact) (*ContactResponse, error
 	return contact_db, nil
 }
 
-func (s *contactService) FindContactById(id, user_id uint) (*Contact, error) {
-	contact_db, err := s.repo.FindContactById(id, user_id)
+func (s *contactService) FindContactById(id, user_id, workspace_id uint) (*Contact, error) {
+	contact_db, err := s.repo.FindContactById(id, user_id, workspace_id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("contact not found")
@@ -49,14 +206,17 @@ func (s *contactService) FindContactById(id, user_id uint) (*Contact, error) {
 	return contact_db, nil
 }
 
-func (s *contactService) UpdateContact(id, user_id uint, contact Contact) error {
-	contact_db, err := s.repo.FindContactById(id, user_id)
+func (s *contactService) UpdateContact(id, user_id, workspace_id uint, contact Contact) error {
+	contact_db, err := s.repo.FindContactById(id, user_id, workspace_id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("contact not found")
 		}
 		return err
 	}
+	if err := s.checkEdit(contact_db, user_id, workspace_id); err != nil {
+		return err
+	}
 
 	if contact.FirstName != "" {
 		contact_db.FirstName = contact.FirstName
@@ -68,25 +228,409 @@ func (s *contactService) UpdateContact(id, user_id uint, contact Contact) error
 		contact_db.Email = contact.Email
 	}
 	if contact.Phone != "" {
+		if err := s.normalizePhone(user_id, &contact); err != nil {
+			return err
+		}
 		contact_db.Phone = contact.Phone
+		contact_db.PhoneE164 = contact.PhoneE164
+	}
+	if contact.Birthday != nil {
+		contact_db.Birthday = contact.Birthday
 	}
+	if contact.Anniversary != nil {
+		contact_db.Anniversary = contact.Anniversary
+	}
+	// Like custom fields, companies belong to the contact's owner.
+	if contact.CompanyID != nil || contact.CompanyName != "" {
+		if err := s.resolveCompany(contact_db.UserID, &contact); err != nil {
+			return err
+		}
+		contact_db.CompanyID = contact.CompanyID
+		contact_db.Company = nil
+	}
+	if contact.JobTitle != "" {
+		contact_db.JobTitle = contact.JobTitle
+	}
+	// Custom fields are defined by the owner, also when a colleague edits a
+	// shared contact.
+	if err := s.parseCustomFields(contact_db.UserID, &contact, false); err != nil {
+		return err
+	}
+	// Only the fields sent in the request are written; the others keep
+	// their stored value.
+	contact_db.CustomValues = contact.CustomValues
 
-	if err := s.repo.UpdateContact(id, user_id, contact_db); err != nil {
+	if err := s.repo.UpdateContact(id, user_id, workspace_id, contact_db); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *contactService) DeleteContact(id, user_id uint) error {
-	_, err := s.repo.FindContactById(id, user_id)
+func (s *contactService) DeleteContact(id, user_id, workspace_id uint) error {
+	contact_db, err := s.repo.FindContactById(id, user_id, workspace_id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("contact not found")
 		}
 		return err
 	}
-	if err := s.repo.DeleteContact(id, user_id); err != nil {
+	// Shares grant view or edit access; deleting stays with the owner. In an
+	// organization every member who can edit may delete.
+	if workspace_id == workspaces.Personal && contact_db.UserID != user_id {
+		return ErrForbidden
+	}
+	if workspace_id != workspaces.Personal {
+		can_edit, err := s.repo.CanEditContact(id, user_id, workspace_id)
+		if err != nil {
+			return err
+		}
+		if !can_edit {
+			return ErrForbidden
+		}
+	}
+	if err := s.repo.DeleteContact(id, user_id, workspace_id); err != nil {
 		return err
 	}
 	return nil
 }
+
+// SetFavorite marks or unmarks a contact as favorite. The flag lives on the
+// contact, so everyone who can see the contact sees it pinned.
+func (s *contactService) SetFavorite(id, user_id, workspace_id uint, favorite bool) (*Contact, error) {
+	contact_db, err := s.FindContactById(id, user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEdit(contact_db, user_id, workspace_id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetFavorite(id, favorite); err != nil {
+		return nil, err
+	}
+	contact_db.Favorite = favorite
+	return contact_db, nil
+}
+
+// AddTags tags the contact. Tags it already has are left as they are.
+func (s *contactService) AddTags(id, user_id, workspace_id uint, tags []string) error {
+	return s.changeTags(id, user_id, workspace_id, tags, s.repo.AddTags)
+}
+
+func (s *contactService) RemoveTags(id, user_id, workspace_id uint, tags []string) error {
+	return s.changeTags(id, user_id, workspace_id, tags, s.repo.RemoveTags)
+}
+
+func (s *contactService) changeTags(id, user_id, workspace_id uint, tags []string, change func(id uint, tags []string) error) error {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("%w: no tags given", ErrInvalidTag)
+	}
+	contact_db, err := s.FindContactById(id, user_id, workspace_id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEdit(contact_db, user_id, workspace_id); err != nil {
+		return err
+	}
+	return change(id, tags)
+}
+
+func (s *contactService) GetSortPreference(user_id uint) (*SortPreference, error) {
+	preference, err := s.repo.FindSortPreference(user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return preference, nil
+}
+
+// SaveSortPreference stores the default sort of GET /contacts. An empty sort
+// clears it.
+func (s *contactService) SaveSortPreference(user_id uint, preference SortPreference) (*SortPreference, error) {
+	var fields map[string]customfields.Field
+	if strings.HasPrefix(preference.Sort, customFieldPrefix) {
+		var err error
+		fields, err = s.customFields(user_id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var filter ContactFilter
+	if err := applySort(&filter, preference, fields); err != nil {
+		return nil, err
+	}
+	preference.Order = filter.Order
+	if preference.Sort == "" {
+		preference.Order = ""
+	}
+
+	if err := s.repo.SaveSortPreference(user_id, preference); err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (s *contactService) GetHistory(id, user_id, workspace_id uint, page, limit int) (*history.GetRevisionsResponse, error) {
+	if _, err := s.FindContactById(id, user_id, workspace_id); err != nil {
+		return nil, err
+	}
+	response, err := s.repo.GetRevisions(id, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RestoreContact puts the contact and its addresses back the way they were
+// right after the given revision.
+func (s *contactService) RestoreContact(id, user_id, workspace_id, revision_id uint) (*Contact, error) {
+	contact_db, err := s.FindContactById(id, user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEdit(contact_db, user_id, workspace_id); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.FindRevision(id, revision_id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("revision not found")
+		}
+		return nil, err
+	}
+
+	revisions, err := s.repo.GetRevisionsAfter(id, revision_id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RestoreContact(id, user_id, planRestore(revisions)); err != nil {
+		return nil, err
+	}
+	return s.FindContactById(id, user_id, workspace_id)
+}
+
+// checkEdit makes sure user_id may change a contact they can see. Viewers of
+// an organization and view-only shares can read the contact but not change
+// it.
+func (s *contactService) checkEdit(contact_db *Contact, user_id, workspace_id uint) error {
+	if workspace_id == workspaces.Personal && contact_db.UserID == user_id {
+		return nil
+	}
+	can_edit, err := s.repo.CanEditContact(contact_db.ID, user_id, workspace_id)
+	if err != nil {
+		return err
+	}
+	if !can_edit {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// checkWorkspaceRole makes sure user_id may add contacts to the workspace.
+func (s *contactService) checkWorkspaceRole(workspace_id, user_id uint) error {
+	role, err := s.repo.FindWorkspaceRole(workspace_id, user_id)
+	if err != nil {
+		return err
+	}
+	if !CanEdit(role) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// normalizePhone replaces the phone number with its display form and fills
+// in the E.164 form, reading national numbers in the user's region.
+func (s *contactService) normalizePhone(user_id uint, contact *Contact) error {
+	if contact.Phone == "" {
+		contact.PhoneE164 = ""
+		return nil
+	}
+
+	region, err := s.repo.FindUserRegion(user_id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return NormalizePhone(contact, region)
+}
+
+// BackfillPhoneE164 fills in the E.164 form of contacts stored before
+// phone_e164 existed, so the digit search finds them. Numbers are read in
+// the owner's region; ones that do not parse are left alone. It returns how
+// many contacts were updated.
+func (s *contactService) BackfillPhoneE164() (int, error) {
+	regions := make(map[uint]string)
+	filled := 0
+	var after_id uint
+	for {
+		contact_list, err := s.repo.GetContactsWithoutPhoneE164(after_id, phoneBackfillBatchSize)
+		if err != nil {
+			return filled, err
+		}
+		for _, contact := range contact_list {
+			after_id = contact.ID
+
+			region, ok := regions[contact.UserID]
+			if !ok {
+				region, err = s.repo.FindUserRegion(contact.UserID)
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return filled, err
+				}
+				regions[contact.UserID] = region
+			}
+			if err := NormalizePhone(&contact, region); err != nil {
+				continue
+			}
+			if err := s.repo.SetPhoneE164(contact.ID, contact.PhoneE164); err != nil {
+				return filled, err
+			}
+			filled++
+		}
+		if len(contact_list) < phoneBackfillBatchSize {
+			return filled, nil
+		}
+	}
+}
+
+// NormalizePhone parses contact.Phone for the given region and stores both
+// the display and the E.164 form on the contact.
+func NormalizePhone(contact *Contact, region string) error {
+	if contact.Phone == "" {
+		contact.PhoneE164 = ""
+		return nil
+	}
+	if region == "" {
+		region = phone.DefaultRegion
+	}
+
+	number, err := phone.Parse(contact.Phone, region)
+	if err != nil {
+		return err
+	}
+	contact.Phone = number.Display
+	contact.PhoneE164 = number.E164
+	return nil
+}
+
+func normalizeContactTags(contact *Contact) error {
+	names := make([]string, len(contact.Tags))
+	for i, tag := range contact.Tags {
+		names[i] = tag.Name
+	}
+	names, err := NormalizeTags(names)
+	if err != nil {
+		return err
+	}
+	contact.Tags = nil
+	for _, name := range names {
+		contact.Tags = append(contact.Tags, Tag{Name: name})
+	}
+	return nil
+}
+
+// resolveCompany checks that the company sent for the contact belongs to
+// owner_id. A company_id of 0 means no company. Without a company_id,
+// company_name links the owner's company of that name, created when
+// missing.
+func (s *contactService) resolveCompany(owner_id uint, contact *Contact) error {
+	contact.Company = nil
+	if contact.CompanyID == nil {
+		name := strings.TrimSpace(contact.CompanyName)
+		if name == "" {
+			return nil
+		}
+		company, err := s.repo.FindOrCreateCompany(owner_id, name)
+		if err != nil {
+			return err
+		}
+		contact.CompanyID = &company.ID
+		return nil
+	}
+	if *contact.CompanyID == 0 {
+		contact.CompanyID = nil
+		return nil
+	}
+	if _, err := s.repo.FindCompany(*contact.CompanyID, owner_id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("company not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *contactService) customFields(user_id uint) (map[string]customfields.Field, error) {
+	fields, err := s.repo.GetCustomFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+	by_key := make(map[string]customfields.Field, len(fields))
+	for _, field := range fields {
+		by_key[field.Key] = field
+	}
+	return by_key, nil
+}
+
+// parseCustomFields validates contact.CustomFields against the user's field
+// definitions and fills contact.CustomValues.
+func (s *contactService) parseCustomFields(user_id uint, contact *Contact, create bool) error {
+	if len(contact.CustomFields) == 0 && !create {
+		return nil
+	}
+
+	fields, err := s.customFields(user_id)
+	if err != nil {
+		return err
+	}
+	return ParseCustomFields(contact, fields, create)
+}
+
+// ParseCustomFields validates contact.CustomFields against fields, the
+// user's field definitions keyed by key, and fills contact.CustomValues. On
+// create every required field must have a value; on update a required field
+// cannot be cleared. The importers call it too, so their contacts meet the
+// same rules.
+func ParseCustomFields(contact *Contact, fields map[string]customfields.Field, create bool) error {
+	values := make([]customfields.Value, 0, len(contact.CustomFields))
+	normalized := make(map[string]any, len(contact.CustomFields))
+	for key, raw := range contact.CustomFields {
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("%w: unknown field %q", customfields.ErrInvalidValue, key)
+		}
+		value, err := field.Parse(raw)
+		if err != nil {
+			return err
+		}
+		if value.Value == "" {
+			if field.Required {
+				return fmt.Errorf("%w: %s is required", customfields.ErrInvalidValue, key)
+			}
+			if create {
+				continue
+			}
+		} else {
+			normalized[key] = value.Typed()
+		}
+		values = append(values, value)
+	}
+
+	if create {
+		for key, field := range fields {
+			if _, ok := normalized[key]; field.Required && !ok {
+				return fmt.Errorf("%w: %s is required", customfields.ErrInvalidValue, key)
+			}
+		}
+	}
+
+	contact.CustomValues = values
+	contact.CustomFields = normalized
+	if len(normalized) == 0 {
+		contact.CustomFields = nil
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package contacts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxTagLength is the longest tag name.
+const MaxTagLength = 50
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// Tag is a free-form label on a contact. Tags are lowercase and written as
+// plain strings in JSON.
+type Tag struct {
+	ContactID uint   `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(50);primaryKey;index"`
+}
+
+func (Tag) TableName() string {
+	return "contact_tags"
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Name)
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Name)
+}
+
+// NormalizeTags trims and lowercases the tags and drops empty and repeated
+// ones.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTag, tag, MaxTagLength)
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package customfields
+
+import (
+	"time"
+)
+
+const (
+	TypeText   = "text"
+	TypeNumber = "number"
+	TypeDate   = "date"
+	TypeEnum   = "enum"
+	TypeURL    = "url"
+)
+
+// Field is a custom attribute a user defines for their contacts.
+type Field struct {
+	ID        uint      `gorm:"column:field_id;primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_custom_fields_user_key" json:"user_id"`
+	Key       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_custom_fields_user_key" json:"key"`
+	Label     string    `gorm:"type:varchar(100);not null" json:"label"`
+	Type      string    `gorm:"type:varchar(10);not null" json:"type"`
+	Options   []string  `gorm:"type:text;serializer:json" json:"options,omitempty"`
+	Required  bool      `gorm:"not null;default:false" json:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Field) TableName() string {
+	return "custom_fields"
+}
+
+// Value is the value of a custom field for one contact. Numbers and dates
+// are also stored in typed columns so they sort correctly.
+type Value struct {
+	ID          uint       `gorm:"column:value_id;primaryKey" json:"-"`
+	ContactID   uint       `gorm:"not null;uniqueIndex:idx_contact_custom_values_contact_field" json:"-"`
+	FieldID     uint       `gorm:"not null;uniqueIndex:idx_contact_custom_values_contact_field;index" json:"field_id"`
+	Field       Field      `gorm:"foreignKey:FieldID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Value       string     `gorm:"type:varchar(1000);not null" json:"value"`
+	NumberValue *float64   `gorm:"type:decimal(20,6)" json:"-"`
+	DateValue   *time.Time `gorm:"type:date" json:"-"`
+}
+
+func (Value) TableName() string {
+	return "contact_custom_values"
+}
+
+type CreateFieldRequest struct {
+	Key      string   `json:"key" binding:"required,max=50"`
+	Label    string   `json:"label" binding:"required,max=100"`
+	Type     string   `json:"type" binding:"required,oneof=text number date enum url"`
+	Options  []string `json:"options" binding:"omitempty,dive,required,max=100"`
+	Required bool     `json:"required"`
+}
+
+type UpdateFieldRequest struct {
+	Label    string   `json:"label" binding:"omitempty,max=100"`
+	Options  []string `json:"options" binding:"omitempty,dive,required,max=100"`
+	Required *bool    `json:"required"`
+}
@@ -0,0 +1,64 @@
+package customfields
+
+import "gorm.io/gorm"
+
+type CustomFieldRepository interface {
+	GetFields(user_id uint) ([]Field, error)
+	FindFieldById(id, user_id uint) (*Field, error)
+	FindFieldByKey(user_id uint, key string) (*Field, error)
+	CreateField(field *Field) error
+	UpdateField(field *Field) error
+	DeleteField(id, user_id uint) error
+}
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db: db}
+}
+
+func (r *customFieldRepository) GetFields(user_id uint) ([]Field, error) {
+	var fields []Field
+	if err := r.db.Where("user_id = ?", user_id).Order("field_id").Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func (r *customFieldRepository) FindFieldById(id, user_id uint) (*Field, error) {
+	var field Field
+	if err := r.db.Where("field_id = ? AND user_id = ?", id, user_id).First(&field).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) FindFieldByKey(user_id uint, key string) (*Field, error) {
+	var field Field
+	if err := r.db.Where("user_id = ? AND `key` = ?", user_id, key).First(&field).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) CreateField(field *Field) error {
+	return r.db.Create(field).Error
+}
+
+func (r *customFieldRepository) UpdateField(field *Field) error {
+	return r.db.Save(field).Error
+}
+
+// DeleteField removes the definition together with every value stored for
+// it. Definitions are configuration rather than contact data, so they are
+// deleted permanently.
+func (r *customFieldRepository) DeleteField(id, user_id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&Value{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("field_id = ? AND user_id = ?", id, user_id).Delete(&Field{}).Error
+	})
+}
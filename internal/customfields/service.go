@@ -0,0 +1,115 @@
+package customfields
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type CustomFieldService interface {
+	GetFields(user_id uint) ([]Field, error)
+	FindFieldById(id, user_id uint) (*Field, error)
+	CreateField(user_id uint, request CreateFieldRequest) (*Field, error)
+	UpdateField(id, user_id uint, request UpdateFieldRequest) (*Field, error)
+	DeleteField(id, user_id uint) error
+}
+
+type customFieldService struct {
+	repo CustomFieldRepository
+}
+
+func NewCustomFieldService(repo CustomFieldRepository) CustomFieldService {
+	return &customFieldService{repo: repo}
+}
+
+func (s *customFieldService) GetFields(user_id uint) ([]Field, error) {
+	fields, err := s.repo.GetFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func (s *customFieldService) FindFieldById(id, user_id uint) (*Field, error) {
+	field, err := s.repo.FindFieldById(id, user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("custom field not found")
+		}
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *customFieldService) CreateField(user_id uint, request CreateFieldRequest) (*Field, error) {
+	if !ValidKey(request.Key) {
+		return nil, fmt.Errorf("%w: key must start with a letter and contain only lower-case letters, digits and underscores", ErrInvalidValue)
+	}
+	if err := checkOptions(request.Type, request.Options); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindFieldByKey(user_id, request.Key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("custom field with this key already exists")
+	}
+
+	field := Field{
+		UserID:   user_id,
+		Key:      request.Key,
+		Label:    request.Label,
+		Type:     request.Type,
+		Options:  request.Options,
+		Required: request.Required,
+	}
+	if err := s.repo.CreateField(&field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (s *customFieldService) UpdateField(id, user_id uint, request UpdateFieldRequest) (*Field, error) {
+	field, err := s.FindFieldById(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Label != "" {
+		field.Label = request.Label
+	}
+	if request.Options != nil {
+		if err := checkOptions(field.Type, request.Options); err != nil {
+			return nil, err
+		}
+		field.Options = request.Options
+	}
+	if request.Required != nil {
+		field.Required = *request.Required
+	}
+
+	if err := s.repo.UpdateField(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *customFieldService) DeleteField(id, user_id uint) error {
+	if _, err := s.FindFieldById(id, user_id); err != nil {
+		return err
+	}
+	return s.repo.DeleteField(id, user_id)
+}
+
+func checkOptions(field_type string, options []string) error {
+	if field_type == TypeEnum && len(options) == 0 {
+		return fmt.Errorf("%w: enum fields need at least one option", ErrInvalidValue)
+	}
+	if field_type != TypeEnum && len(options) > 0 {
+		return fmt.Errorf("%w: options are only allowed for enum fields", ErrInvalidValue)
+	}
+	return nil
+}
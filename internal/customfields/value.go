@@ -0,0 +1,109 @@
+package customfields
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DateLayout is the format accepted and returned for date fields.
+	DateLayout = "2006-01-02"
+
+	maxTextLength = 1000
+)
+
+var ErrInvalidValue = errors.New("invalid custom field")
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ValidKey reports whether key can be used as a custom field key. Keys are
+// used in query parameters, so they are limited to lower-case letters,
+// digits and underscores.
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Parse checks raw against the field definition and returns the value to
+// store. A nil or empty raw value returns a Value with an empty Value, which
+// callers treat as "remove".
+func (f Field) Parse(raw any) (Value, error) {
+	value := Value{FieldID: f.ID}
+
+	var text string
+	switch v := raw.(type) {
+	case nil:
+		return value, nil
+	case string:
+		text = strings.TrimSpace(v)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		text = v.String()
+	case bool:
+		return value, fmt.Errorf("%w: %s must be a %s, not a boolean", ErrInvalidValue, f.Key, f.Type)
+	default:
+		return value, fmt.Errorf("%w: %s has an unsupported value", ErrInvalidValue, f.Key)
+	}
+	if text == "" {
+		return value, nil
+	}
+
+	switch f.Type {
+	case TypeText:
+		if utf8.RuneCountInString(text) > maxTextLength {
+			return value, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidValue, f.Key, maxTextLength)
+		}
+		value.Value = text
+	case TypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return value, fmt.Errorf("%w: %s must be a number", ErrInvalidValue, f.Key)
+		}
+		value.Value = strconv.FormatFloat(number, 'f', -1, 64)
+		value.NumberValue = &number
+	case TypeDate:
+		date, err := time.Parse(DateLayout, text)
+		if err != nil {
+			return value, fmt.Errorf("%w: %s must be a date in YYYY-MM-DD format", ErrInvalidValue, f.Key)
+		}
+		value.Value = date.Format(DateLayout)
+		value.DateValue = &date
+	case TypeEnum:
+		for _, option := range f.Options {
+			if option == text {
+				value.Value = text
+				return value, nil
+			}
+		}
+		return value, fmt.Errorf("%w: %s must be one of %s", ErrInvalidValue, f.Key, strings.Join(f.Options, ", "))
+	case TypeURL:
+		parsed, err := url.ParseRequestURI(text)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return value, fmt.Errorf("%w: %s must be an http or https URL", ErrInvalidValue, f.Key)
+		}
+		if len(text) > maxTextLength {
+			return value, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidValue, f.Key, maxTextLength)
+		}
+		value.Value = text
+	default:
+		return value, fmt.Errorf("%w: %s has unknown type %q", ErrInvalidValue, f.Key, f.Type)
+	}
+
+	return value, nil
+}
+
+// Typed returns the stored value as it is shown to clients: numbers as
+// numbers and everything else as strings.
+func (v Value) Typed() any {
+	if v.NumberValue != nil {
+		return *v.NumberValue
+	}
+	return v.Value
+}
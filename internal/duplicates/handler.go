@@ -0,0 +1,75 @@
+package duplicates
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler interface {
+	FindDuplicates(c *gin.Context)
+	MergeContacts(c *gin.Context)
+}
+
+type duplicateHandler struct {
+	svc DuplicateService
+}
+
+func NewDuplicateHandler(svc DuplicateService) DuplicateHandler {
+	return &duplicateHandler{svc: svc}
+}
+
+func (h *duplicateHandler) FindDuplicates(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	min_score := DefaultMinScore
+	if value, ok := c.GetQuery("min_score"); ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_score"})
+			return
+		}
+		min_score = parsed
+	}
+
+	response, err := h.svc.FindDuplicates(user_id.(uint), c.GetUint("workspace_id"), min_score)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Duplicate contacts retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *duplicateHandler) MergeContacts(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request MergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.MergeContacts(user_id.(uint), c.GetUint("workspace_id"), request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contacts merged successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,36 @@
+package duplicates
+
+import "github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+const (
+	WinnerSurvivor = "survivor"
+	WinnerLoser    = "loser"
+)
+
+// MergeFields lists the contact fields a merge request may pick a winner for.
+var MergeFields = []string{"first_name", "last_name", "email", "phone"}
+
+type DuplicatePair struct {
+	ContactA contacts.Contact `json:"contact_a"`
+	ContactB contacts.Contact `json:"contact_b"`
+	Score    float64          `json:"score"`
+	Reasons  []string         `json:"reasons"`
+}
+
+type GetDuplicatesResponse struct {
+	Data     []DuplicatePair `json:"data"`
+	MinScore float64         `json:"min_score"`
+	Total    int             `json:"total"`
+}
+
+type MergeRequest struct {
+	SurvivorID uint              `json:"survivor_id" binding:"required"`
+	LoserID    uint              `json:"loser_id" binding:"required,nefield=SurvivorID"`
+	Fields     map[string]string `json:"fields"`
+}
+
+type MergeResponse struct {
+	Contact        contacts.Contact `json:"contact"`
+	MergedID       uint             `json:"merged_id"`
+	MovedAddresses int64            `json:"moved_addresses"`
+}
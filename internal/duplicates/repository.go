@@ -0,0 +1,128 @@
+package duplicates
+
+import (
+	"errors"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+
+	"gorm.io/gorm"
+)
+
+// ErrLoserNotDeleted rolls a merge back when the loser was deleted by
+// someone else while it was being merged.
+var ErrLoserNotDeleted = errors.New("the contact to merge was deleted meanwhile")
+
+type DuplicateRepository interface {
+	GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error)
+	FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	MergeContacts(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error)
+}
+
+type duplicateRepository struct {
+	db *gorm.DB
+}
+
+func NewDuplicateRepository(db *gorm.DB) DuplicateRepository {
+	return &duplicateRepository{db: db}
+}
+
+func (r *duplicateRepository) GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error) {
+	var contact_list []contacts.Contact
+	if err := r.db.Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).Order("contact_id").Find(&contact_list).Error; err != nil {
+		return nil, err
+	}
+	return contact_list, nil
+}
+
+func (r *duplicateRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.InWorkspace(user_id, workspace_id, permission)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// MergeContacts saves the survivor, moves every address of the loser to it
+// and soft-deletes the loser in one transaction. It returns the number of
+// addresses moved. The survivor's history records the changed fields and
+// the addresses it gained; the loser's records its deletion.
+func (r *duplicateRepository) MergeContacts(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error) {
+	var moved int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := contacts.LoadSnapshot(tx, survivor.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(survivor).Error; err != nil {
+			return err
+		}
+		if err := contacts.RecordChanges(tx, survivor.ID, user_id, history.ActionUpdate, before); err != nil {
+			return err
+		}
+
+		// A type keeps the survivor's primary; the loser's one comes over as
+		// a plain address.
+		var taken []string
+		err = tx.Model(&addresses.Address{}).Where("contact_id = ? AND is_primary = ?", survivor.ID, true).Pluck("type", &taken).Error
+		if err != nil {
+			return err
+		}
+		if len(taken) > 0 {
+			err := tx.Model(&addresses.Address{}).
+				Where("contact_id = ? AND is_primary = ? AND type IN ?", loser_id, true, taken).
+				Update("is_primary", false).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var address_list []addresses.Address
+		if err := tx.Where("contact_id = ?", loser_id).Find(&address_list).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&addresses.Address{}).Where("contact_id = ?", loser_id).Update("contact_id", survivor.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+		for i := range address_list {
+			address_list[i].ContactID = survivor.ID
+			if err := addresses.Record(tx, user_id, &address_list[i], history.ActionCreate, nil); err != nil {
+				return err
+			}
+		}
+
+		// Keep the loser's timeline on the survivor.
+		if err := tx.Model(&activities.Activity{}).Where("contact_id = ?", loser_id).Update("contact_id", survivor.ID).Error; err != nil {
+			return err
+		}
+		latest := tx.Model(&activities.Activity{}).Select("MAX(occurred_at)").Where("contact_id = ?", survivor.ID)
+		if err := tx.Model(&contacts.Contact{}).Where("contact_id = ?", survivor.ID).UpdateColumn("last_interaction_at", latest).Error; err != nil {
+			return err
+		}
+
+		// Both contacts were checked for access already; in a workspace
+		// they may have been created by different members.
+		result = tx.Where("contact_id = ?", loser_id).Delete(&contacts.Contact{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLoserNotDeleted
+		}
+		return history.Record(tx, &history.Revision{
+			ContactID: loser_id,
+			Entity:    history.EntityContact,
+			EntityID:  loser_id,
+			Action:    history.ActionDelete,
+			AuthorID:  user_id,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
@@ -0,0 +1,137 @@
+package duplicates
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+const (
+	emailWeight = 0.45
+	phoneWeight = 0.35
+	nameWeight  = 0.20
+
+	// minPhoneDigits avoids matching short extensions or placeholder numbers.
+	minPhoneDigits = 6
+)
+
+// NormalizeEmail lower-cases the address and drops a "+tag" suffix from the
+// local part, so "John+crm@Example.com" matches "john@example.com".
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return email
+	}
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + "@" + domain
+}
+
+// NormalizePhone keeps only the digits of a phone number.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NormalizeName lower-cases the name, drops punctuation and collapses
+// whitespace.
+func NormalizeName(first_name, last_name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(first_name + " " + last_name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// phonesMatch compares two normalized numbers on their trailing digits so a
+// national "0812..." matches an international "62812...". It is only used
+// for contacts saved before numbers were stored in E.164 form.
+func phonesMatch(a, b string) bool {
+	if len(a) < minPhoneDigits || len(b) < minPhoneDigits {
+		return false
+	}
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= minPhoneDigits && strings.HasSuffix(b, a)
+}
+
+// NameSimilarity returns a value between 0 and 1 based on the Levenshtein
+// distance between the two normalized names.
+func NameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	longest := max(len(ra), len(rb))
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+// Score rates how likely two contacts describe the same person and returns
+// the reasons that contributed to the score.
+func Score(a, b contacts.Contact) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	if email := NormalizeEmail(a.Email); email != "" && email == NormalizeEmail(b.Email) {
+		score += emailWeight
+		reasons = append(reasons, "same email")
+	}
+
+	if a.PhoneE164 != "" && b.PhoneE164 != "" {
+		if a.PhoneE164 == b.PhoneE164 {
+			score += phoneWeight
+			reasons = append(reasons, "same phone")
+		}
+	} else if phonesMatch(NormalizePhone(a.Phone), NormalizePhone(b.Phone)) {
+		score += phoneWeight
+		reasons = append(reasons, "same phone")
+	}
+
+	similarity := NameSimilarity(NormalizeName(a.FirstName, a.LastName), NormalizeName(b.FirstName, b.LastName))
+	if similarity >= 0.7 {
+		score += nameWeight * similarity
+		if similarity == 1 {
+			reasons = append(reasons, "same name")
+		} else {
+			reasons = append(reasons, "similar name")
+		}
+	}
+
+	return score, reasons
+}
@@ -0,0 +1,172 @@
+package duplicates
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+// DefaultMinScore is the threshold used when the caller does not pass one.
+// It is reached by a shared email alone or by a shared phone plus a similar
+// name.
+const DefaultMinScore = 0.45
+
+type DuplicateService interface {
+	FindDuplicates(user_id, workspace_id uint, min_score float64) (*GetDuplicatesResponse, error)
+	MergeContacts(user_id, workspace_id uint, request MergeRequest) (*MergeResponse, error)
+}
+
+type duplicateService struct {
+	repo DuplicateRepository
+}
+
+func NewDuplicateService(repo DuplicateRepository) DuplicateService {
+	return &duplicateService{repo: repo}
+}
+
+func (s *duplicateService) FindDuplicates(user_id, workspace_id uint, min_score float64) (*GetDuplicatesResponse, error) {
+	contact_list, err := s.repo.GetContacts(user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []DuplicatePair{}
+	for _, candidate := range candidatePairs(contact_list) {
+		a, b := contact_list[candidate[0]], contact_list[candidate[1]]
+		score, reasons := Score(a, b)
+		if score < min_score {
+			continue
+		}
+		pairs = append(pairs, DuplicatePair{ContactA: a, ContactB: b, Score: score, Reasons: reasons})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+
+	return &GetDuplicatesResponse{
+		Data:     pairs,
+		MinScore: min_score,
+		Total:    len(pairs),
+	}, nil
+}
+
+func (s *duplicateService) MergeContacts(user_id, workspace_id uint, request MergeRequest) (*MergeResponse, error) {
+	survivor, err := s.findContact(request.SurvivorID, user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+	loser, err := s.findContact(request.LoserID, user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+
+	for field, winner := range request.Fields {
+		if !isMergeField(field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		if winner != WinnerSurvivor && winner != WinnerLoser {
+			return nil, fmt.Errorf("winner for %s must be %q or %q", field, WinnerSurvivor, WinnerLoser)
+		}
+	}
+
+	// The survivor keeps its values unless the loser was picked explicitly or
+	// the survivor has nothing for that field.
+	survivor.FirstName = pick(survivor.FirstName, loser.FirstName, request.Fields["first_name"])
+	survivor.LastName = pick(survivor.LastName, loser.LastName, request.Fields["last_name"])
+	survivor.Email = pick(survivor.Email, loser.Email, request.Fields["email"])
+	// The E.164 form travels with the phone it was parsed from.
+	if phone := pick(survivor.Phone, loser.Phone, request.Fields["phone"]); phone != survivor.Phone {
+		survivor.Phone = phone
+		survivor.PhoneE164 = loser.PhoneE164
+	}
+
+	moved, err := s.repo.MergeContacts(user_id, survivor, loser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergeResponse{
+		Contact:        *survivor,
+		MergedID:       loser.ID,
+		MovedAddresses: moved,
+	}, nil
+}
+
+// findContact finds a contact user_id may change; viewers of a workspace
+// cannot merge its contacts.
+func (s *duplicateService) findContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	contact_db, err := s.repo.FindContactById(id, user_id, workspace_id, contacts.PermissionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+	return contact_db, nil
+}
+
+func pick(survivor, loser, winner string) string {
+	if winner == WinnerLoser && loser != "" {
+		return loser
+	}
+	if survivor == "" {
+		return loser
+	}
+	return survivor
+}
+
+func isMergeField(field string) bool {
+	for _, merge_field := range MergeFields {
+		if field == merge_field {
+			return true
+		}
+	}
+	return false
+}
+
+// candidatePairs groups contacts by email, phone suffix and name prefix and
+// returns the index pairs that share at least one group, so only plausible
+// pairs are scored instead of every combination.
+func candidatePairs(contact_list []contacts.Contact) [][2]int {
+	blocks := make(map[string][]int)
+	for i, contact := range contact_list {
+		if email := NormalizeEmail(contact.Email); email != "" {
+			blocks["email:"+email] = append(blocks["email:"+email], i)
+		}
+		if phone := NormalizePhone(contact.Phone); len(phone) >= minPhoneDigits {
+			key := "phone:" + phone[len(phone)-minPhoneDigits:]
+			blocks[key] = append(blocks[key], i)
+		}
+		if name := []rune(NormalizeName(contact.FirstName, contact.LastName)); len(name) > 0 {
+			key := "name:" + string(name[:min(3, len(name))])
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs [][2]int
+	for _, members := range blocks {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				pair := [2]int{members[x], members[y]}
+				if !seen[pair] {
+					seen[pair] = true
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
@@ -0,0 +1,43 @@
+package groups
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// Group is a named set of a user's contacts.
+type Group struct {
+	ID        uint               `gorm:"column:group_id;primaryKey" json:"id"`
+	UserID    uint               `gorm:"not null;uniqueIndex:idx_contact_groups_user_name" json:"user_id"`
+	Name      string             `gorm:"type:varchar(100);not null;uniqueIndex:idx_contact_groups_user_name" json:"name"`
+	Contacts  []contacts.Contact `gorm:"many2many:contact_group_members;foreignKey:ID;joinForeignKey:GroupID;references:ID;joinReferences:ContactID" json:"contacts,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func (Group) TableName() string {
+	return "contact_groups"
+}
+
+// Member is a row of the contact_group_members join table.
+type Member struct {
+	GroupID   uint `gorm:"primaryKey"`
+	ContactID uint `gorm:"primaryKey;index"`
+}
+
+func (Member) TableName() string {
+	return "contact_group_members"
+}
+
+type CreateGroupRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type UpdateGroupRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type MembersRequest struct {
+	ContactIDs []uint `json:"contact_ids" binding:"required,min=1,max=500"`
+}
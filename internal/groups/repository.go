@@ -0,0 +1,91 @@
+package groups
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GroupRepository interface {
+	GetGroups(user_id uint) ([]Group, error)
+	FindGroupById(id, user_id uint) (*Group, error)
+	FindGroupByName(user_id uint, name string) (*Group, error)
+	CreateGroup(group *Group) error
+	UpdateGroup(group *Group) error
+	DeleteGroup(id uint) error
+	CountOwnedContacts(user_id, workspace_id uint, contact_ids []uint) (int64, error)
+	AddMembers(group_id uint, contact_ids []uint) error
+	RemoveMembers(group_id uint, contact_ids []uint) error
+}
+
+type groupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+func (r *groupRepository) GetGroups(user_id uint) ([]Group, error) {
+	var group_list []Group
+	if err := r.db.Where("user_id = ?", user_id).Order("name").Find(&group_list).Error; err != nil {
+		return nil, err
+	}
+	return group_list, nil
+}
+
+func (r *groupRepository) FindGroupById(id, user_id uint) (*Group, error) {
+	var group Group
+	if err := r.db.Preload("Contacts").Where("group_id = ? AND user_id = ?", id, user_id).First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) FindGroupByName(user_id uint, name string) (*Group, error) {
+	var group Group
+	if err := r.db.Where("user_id = ? AND name = ?", user_id, name).First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) CreateGroup(group *Group) error {
+	return r.db.Omit("Contacts").Create(group).Error
+}
+
+func (r *groupRepository) UpdateGroup(group *Group) error {
+	return r.db.Model(group).Update("name", group.Name).Error
+}
+
+func (r *groupRepository) DeleteGroup(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&Member{}).Error; err != nil {
+			return err
+		}
+		// Shares of the group go with it.
+		if err := tx.Exec("DELETE FROM contact_shares WHERE group_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Where("group_id = ?", id).Delete(&Group{}).Error
+	})
+}
+
+func (r *groupRepository) CountOwnedContacts(user_id, workspace_id uint, contact_ids []uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&contacts.Contact{}).Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).Where("contact_id IN ?", contact_ids).Count(&count).Error
+	return count, err
+}
+
+func (r *groupRepository) AddMembers(group_id uint, contact_ids []uint) error {
+	members := make([]Member, len(contact_ids))
+	for i, contact_id := range contact_ids {
+		members[i] = Member{GroupID: group_id, ContactID: contact_id}
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&members).Error
+}
+
+func (r *groupRepository) RemoveMembers(group_id uint, contact_ids []uint) error {
+	return r.db.Where("group_id = ? AND contact_id IN ?", group_id, contact_ids).Delete(&Member{}).Error
+}
@@ -0,0 +1,128 @@
+package groups
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type GroupService interface {
+	GetGroups(user_id uint) ([]Group, error)
+	FindGroupById(id, user_id uint) (*Group, error)
+	CreateGroup(user_id uint, request CreateGroupRequest) (*Group, error)
+	UpdateGroup(id, user_id uint, request UpdateGroupRequest) (*Group, error)
+	DeleteGroup(id, user_id uint) error
+	AddContacts(id, user_id, workspace_id uint, request MembersRequest) error
+	RemoveContacts(id, user_id uint, request MembersRequest) error
+}
+
+type groupService struct {
+	repo GroupRepository
+}
+
+func NewGroupService(repo GroupRepository) GroupService {
+	return &groupService{repo: repo}
+}
+
+func (s *groupService) GetGroups(user_id uint) ([]Group, error) {
+	group_list, err := s.repo.GetGroups(user_id)
+	if err != nil {
+		return nil, err
+	}
+	return group_list, nil
+}
+
+func (s *groupService) FindGroupById(id, user_id uint) (*Group, error) {
+	group, err := s.repo.FindGroupById(id, user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *groupService) CreateGroup(user_id uint, request CreateGroupRequest) (*Group, error) {
+	if err := s.checkName(user_id, 0, request.Name); err != nil {
+		return nil, err
+	}
+
+	group := Group{UserID: user_id, Name: request.Name}
+	if err := s.repo.CreateGroup(&group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (s *groupService) UpdateGroup(id, user_id uint, request UpdateGroupRequest) (*Group, error) {
+	group, err := s.FindGroupById(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkName(user_id, id, request.Name); err != nil {
+		return nil, err
+	}
+
+	group.Name = request.Name
+	if err := s.repo.UpdateGroup(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *groupService) DeleteGroup(id, user_id uint) error {
+	if _, err := s.FindGroupById(id, user_id); err != nil {
+		return err
+	}
+	return s.repo.DeleteGroup(id)
+}
+
+func (s *groupService) AddContacts(id, user_id, workspace_id uint, request MembersRequest) error {
+	if _, err := s.FindGroupById(id, user_id); err != nil {
+		return err
+	}
+
+	// Only contacts of the active workspace can be grouped; a group is what
+	// gets shared, so it must not pull in contacts shared with the owner.
+	contact_ids := unique(request.ContactIDs)
+	count, err := s.repo.CountOwnedContacts(user_id, workspace_id, contact_ids)
+	if err != nil {
+		return err
+	}
+	if count != int64(len(contact_ids)) {
+		return errors.New("contact not found")
+	}
+
+	return s.repo.AddMembers(id, contact_ids)
+}
+
+func (s *groupService) RemoveContacts(id, user_id uint, request MembersRequest) error {
+	if _, err := s.FindGroupById(id, user_id); err != nil {
+		return err
+	}
+	return s.repo.RemoveMembers(id, request.ContactIDs)
+}
+
+func (s *groupService) checkName(user_id, id uint, name string) error {
+	existing, err := s.repo.FindGroupByName(user_id, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return errors.New("group with this name already exists")
+	}
+	return nil
+}
+
+func unique(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
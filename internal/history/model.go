@@ -0,0 +1,63 @@
+package history
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+const (
+	EntityContact = "contact"
+	EntityAddress = "address"
+)
+
+// ActionRestore puts fields back to an earlier revision; ActionUndelete
+// takes a record out of the trash.
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionRestore  = "restore"
+	ActionUndelete = "undelete"
+)
+
+// Revision is one change to a contact or to one of its addresses. Every
+// revision of a contact's addresses also carries the contact id so the
+// whole history of a contact can be read in one query.
+type Revision struct {
+	ID        uint       `gorm:"column:revision_id;primaryKey" json:"id"`
+	ContactID uint       `gorm:"not null;index:idx_contact_revisions_contact" json:"contact_id"`
+	Entity    string     `gorm:"type:varchar(10);not null" json:"entity"`
+	EntityID  uint       `gorm:"not null" json:"entity_id"`
+	Action    string     `gorm:"type:varchar(10);not null" json:"action"`
+	AuthorID  uint       `gorm:"not null;index" json:"author_id"`
+	Author    users.User `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"author"`
+	Changes   []Change   `gorm:"foreignKey:RevisionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"changes"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Revision) TableName() string {
+	return "contact_revisions"
+}
+
+// Change is the before and after value of one field. A nil value means the
+// field was empty.
+type Change struct {
+	ID         uint    `gorm:"column:change_id;primaryKey" json:"-"`
+	RevisionID uint    `gorm:"not null;index" json:"-"`
+	Field      string  `gorm:"type:varchar(60);not null" json:"field"`
+	OldValue   *string `gorm:"type:text" json:"old_value"`
+	NewValue   *string `gorm:"type:text" json:"new_value"`
+}
+
+func (Change) TableName() string {
+	return "contact_revision_changes"
+}
+
+type GetRevisionsResponse struct {
+	Data       []Revision `json:"data"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
+	Total      int        `json:"total"`
+	TotalPages int        `json:"total_pages"`
+}
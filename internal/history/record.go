@@ -0,0 +1,55 @@
+package history
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Diff returns the fields whose value differs between two snapshots, sorted
+// by field name. Missing and empty values are treated alike.
+func Diff(before, after map[string]string) []Change {
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []Change
+	for field := range fields {
+		if before[field] == after[field] {
+			continue
+		}
+		changes = append(changes, Change{Field: field, OldValue: value(before[field]), NewValue: value(after[field])})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// Record stores a revision with its changes. Updates that changed nothing
+// are not recorded.
+func Record(tx *gorm.DB, revision *Revision) error {
+	if len(revision.Changes) == 0 && (revision.Action == ActionUpdate || revision.Action == ActionRestore) {
+		return nil
+	}
+	return tx.Omit("Author").Create(revision).Error
+}
+
+// Value dereferences a change value, returning "" for nil.
+func Value(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func value(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
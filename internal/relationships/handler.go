@@ -0,0 +1,132 @@
+package relationships
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/gin-gonic/gin"
+)
+
+type RelationshipHandler interface {
+	CreateRelationship(c *gin.Context)
+	DeleteRelationship(c *gin.Context)
+	GetRelated(c *gin.Context)
+}
+
+type relationshipHandler struct {
+	svc RelationshipService
+}
+
+func NewRelationshipHandler(svc RelationshipService) RelationshipHandler {
+	return &relationshipHandler{svc: svc}
+}
+
+func (h *relationshipHandler) CreateRelationship(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	var request CreateRelationshipRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	relationships, err := h.svc.CreateRelationship(uint(intId), user_id.(uint), c.GetUint("workspace_id"), request)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Relationship created successfully",
+		"data":    relationships,
+	})
+}
+
+func (h *relationshipHandler) DeleteRelationship(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	relationshipId, err := strconv.Atoi(c.Param("relationship_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid relationship id"})
+		return
+	}
+
+	if err := h.svc.DeleteRelationship(uint(intId), uint(relationshipId), user_id.(uint), c.GetUint("workspace_id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Relationship deleted successfully",
+	})
+}
+
+func (h *relationshipHandler) GetRelated(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	depth, err := strconv.Atoi(c.DefaultQuery("depth", strconv.Itoa(DefaultDepth)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid depth"})
+		return
+	}
+
+	var types []string
+	if raw := c.Query("type"); raw != "" {
+		types = strings.Split(raw, ",")
+	}
+
+	response, err := h.svc.GetRelated(uint(intId), user_id.(uint), c.GetUint("workspace_id"), depth, types)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Related contacts retrieved successfully",
+		"data":    response,
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, contacts.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidType), errors.Is(err, ErrSelfRelationship), errors.Is(err, ErrInvalidDepth):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
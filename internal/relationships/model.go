@@ -0,0 +1,55 @@
+package relationships
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+const (
+	// DefaultDepth and MaxDepth bound GET /contacts/:id/related.
+	DefaultDepth = 1
+	MaxDepth     = 3
+)
+
+// inverses lists the relationship types and the type of the reverse
+// direction: when B is A's manager, A is B's report.
+var inverses = map[string]string{
+	"spouse":    "spouse",
+	"partner":   "partner",
+	"sibling":   "sibling",
+	"friend":    "friend",
+	"colleague": "colleague",
+	"parent":    "child",
+	"child":     "parent",
+	"manager":   "report",
+	"report":    "manager",
+	"assistant": "executive",
+	"executive": "assistant",
+}
+
+// Inverse returns the type of the reverse relationship and whether t is a
+// known type.
+func Inverse(t string) (string, bool) {
+	inverse, ok := inverses[t]
+	return inverse, ok
+}
+
+type CreateRelationshipRequest struct {
+	RelatedID     uint   `json:"related_id" binding:"required"`
+	Type          string `json:"type" binding:"required"`
+	Bidirectional bool   `json:"bidirectional"`
+}
+
+// RelatedContact is a contact reached from the starting contact. Type is the
+// relationship of the last hop, seen from Via: the contact is Via's Type.
+type RelatedContact struct {
+	Contact contacts.Contact `json:"contact"`
+	Type    string           `json:"type"`
+	Via     uint             `json:"via"`
+	Depth   int              `json:"depth"`
+}
+
+type GetRelatedResponse struct {
+	ContactID uint             `json:"contact_id"`
+	Depth     int              `json:"depth"`
+	Data      []RelatedContact `json:"data"`
+}
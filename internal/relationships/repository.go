@@ -0,0 +1,87 @@
+package relationships
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+type RelationshipRepository interface {
+	FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	CreateRelationships(relationships []contacts.Relationship) error
+	FindRelationship(id, contact_id uint) (*contacts.Relationship, error)
+	DeleteRelationship(relationship contacts.Relationship) error
+	GetRelationships(contact_ids []uint) ([]contacts.Relationship, error)
+}
+
+type relationshipRepository struct {
+	db *gorm.DB
+}
+
+func NewRelationshipRepository(db *gorm.DB) RelationshipRepository {
+	return &relationshipRepository{db: db}
+}
+
+// FindContact finds a contact of the active workspace or one shared with
+// user_id at permission or above.
+func (r *relationshipRepository) FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.AccessibleBy(user_id, workspace_id, permission)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// CreateRelationships stores one direction, or both for a bidirectional
+// relationship. A direction that already exists is left as it is.
+func (r *relationshipRepository) CreateRelationships(relationships []contacts.Relationship) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range relationships {
+			err := tx.Omit("Related").
+				Where(contacts.Relationship{ContactID: relationships[i].ContactID, RelatedID: relationships[i].RelatedID, Type: relationships[i].Type}).
+				FirstOrCreate(&relationships[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *relationshipRepository) FindRelationship(id, contact_id uint) (*contacts.Relationship, error) {
+	var relationship contacts.Relationship
+	if err := r.db.Where("relationship_id = ? AND contact_id = ?", id, contact_id).First(&relationship).Error; err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// DeleteRelationship removes the relationship together with its reverse
+// direction, if there is one.
+func (r *relationshipRepository) DeleteRelationship(relationship contacts.Relationship) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("relationship_id = ?", relationship.ID).Delete(&contacts.Relationship{}).Error; err != nil {
+			return err
+		}
+		inverse, ok := Inverse(relationship.Type)
+		if !ok {
+			return nil
+		}
+		return tx.Where("contact_id = ? AND related_id = ? AND type = ?", relationship.RelatedID, relationship.ContactID, inverse).
+			Delete(&contacts.Relationship{}).Error
+	})
+}
+
+// GetRelationships returns the relationships starting at any of the given
+// contacts, with the related contact loaded.
+func (r *relationshipRepository) GetRelationships(contact_ids []uint) ([]contacts.Relationship, error) {
+	var relationships []contacts.Relationship
+	err := r.db.Scopes(contacts.WithLiveRelated).
+		Preload("Related").
+		Where("contact_relationships.contact_id IN ?", contact_ids).
+		Find(&relationships).Error
+	if err != nil {
+		return nil, err
+	}
+	return relationships, nil
+}
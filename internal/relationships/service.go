@@ -0,0 +1,174 @@
+package relationships
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidType = errors.New("invalid relationship type")
+
+var ErrSelfRelationship = errors.New("a contact cannot be related to itself")
+
+var ErrInvalidDepth = fmt.Errorf("depth must be between 1 and %d", MaxDepth)
+
+type RelationshipService interface {
+	CreateRelationship(contact_id, user_id, workspace_id uint, request CreateRelationshipRequest) ([]contacts.Relationship, error)
+	DeleteRelationship(contact_id, relationship_id, user_id, workspace_id uint) error
+	GetRelated(contact_id, user_id, workspace_id uint, depth int, types []string) (*GetRelatedResponse, error)
+}
+
+type relationshipService struct {
+	repo RelationshipRepository
+}
+
+func NewRelationshipService(repo RelationshipRepository) RelationshipService {
+	return &relationshipService{repo: repo}
+}
+
+// CreateRelationship records that the related contact is the contact's
+// request.Type and, when bidirectional, the reverse as well. It returns the
+// stored directions.
+func (s *relationshipService) CreateRelationship(contact_id, user_id, workspace_id uint, request CreateRelationshipRequest) ([]contacts.Relationship, error) {
+	request.Type = strings.ToLower(strings.TrimSpace(request.Type))
+	inverse, ok := Inverse(request.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidType, request.Type)
+	}
+	if request.RelatedID == contact_id {
+		return nil, ErrSelfRelationship
+	}
+
+	contact, err := s.findEditable(contact_id, user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+	related, err := s.repo.FindContact(request.RelatedID, user_id, workspace_id, contacts.PermissionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("related contact not found")
+		}
+		return nil, err
+	}
+	if !sameOwner(contact, related) {
+		return nil, errors.New("related contact not found")
+	}
+
+	relationships := []contacts.Relationship{{ContactID: contact.ID, RelatedID: related.ID, Type: request.Type}}
+	if request.Bidirectional {
+		relationships = append(relationships, contacts.Relationship{ContactID: related.ID, RelatedID: contact.ID, Type: inverse})
+	}
+	if err := s.repo.CreateRelationships(relationships); err != nil {
+		return nil, err
+	}
+
+	relationships[0].Related = related
+	if request.Bidirectional {
+		relationships[1].Related = contact
+	}
+	return relationships, nil
+}
+
+func (s *relationshipService) DeleteRelationship(contact_id, relationship_id, user_id, workspace_id uint) error {
+	if _, err := s.findEditable(contact_id, user_id, workspace_id); err != nil {
+		return err
+	}
+	relationship, err := s.repo.FindRelationship(relationship_id, contact_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("relationship not found")
+		}
+		return err
+	}
+	return s.repo.DeleteRelationship(*relationship)
+}
+
+// GetRelated walks the relationship graph breadth-first from the contact up
+// to depth hops and returns every contact reached, nearest first. Each
+// contact is listed once, by the first path that reaches it. When types is
+// not empty only relationships of those types are followed.
+func (s *relationshipService) GetRelated(contact_id, user_id, workspace_id uint, depth int, types []string) (*GetRelatedResponse, error) {
+	if depth < 1 || depth > MaxDepth {
+		return nil, ErrInvalidDepth
+	}
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if _, ok := Inverse(t); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidType, t)
+		}
+		allowed[t] = true
+	}
+
+	if _, err := s.repo.FindContact(contact_id, user_id, workspace_id, contacts.PermissionView); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+
+	response := &GetRelatedResponse{ContactID: contact_id, Depth: depth, Data: []RelatedContact{}}
+	visited := map[uint]bool{contact_id: true}
+	frontier := []uint{contact_id}
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		relationships, err := s.repo.GetRelationships(frontier)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(relationships, func(i, j int) bool {
+			return relationships[i].ID < relationships[j].ID
+		})
+
+		frontier = nil
+		for _, relationship := range relationships {
+			if len(allowed) > 0 && !allowed[relationship.Type] {
+				continue
+			}
+			if visited[relationship.RelatedID] || relationship.Related == nil {
+				continue
+			}
+			visited[relationship.RelatedID] = true
+			frontier = append(frontier, relationship.RelatedID)
+			response.Data = append(response.Data, RelatedContact{
+				Contact: *relationship.Related,
+				Type:    relationship.Type,
+				Via:     relationship.ContactID,
+				Depth:   level,
+			})
+		}
+	}
+	return response, nil
+}
+
+// sameOwner reports whether both contacts belong to the same organization,
+// or to the same user's personal workspace. A relationship never links a
+// contact shared with the user to one of their own.
+func sameOwner(a, b *contacts.Contact) bool {
+	if a.WorkspaceID != nil || b.WorkspaceID != nil {
+		return a.WorkspaceID != nil && b.WorkspaceID != nil && *a.WorkspaceID == *b.WorkspaceID
+	}
+	return a.UserID == b.UserID
+}
+
+// findEditable makes sure user_id can see the contact and may change it.
+func (s *relationshipService) findEditable(contact_id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	if _, err := s.repo.FindContact(contact_id, user_id, workspace_id, contacts.PermissionView); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+	contact, err := s.repo.FindContact(contact_id, user_id, workspace_id, contacts.PermissionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, contacts.ErrForbidden
+		}
+		return nil, err
+	}
+	return contact, nil
+}
@@ -0,0 +1,110 @@
+package reminders
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler interface {
+	GetUpcoming(c *gin.Context)
+	GetNotifications(c *gin.Context)
+	MarkNotificationRead(c *gin.Context)
+}
+
+type reminderHandler struct {
+	svc ReminderService
+}
+
+func NewReminderHandler(svc ReminderService) ReminderHandler {
+	return &reminderHandler{svc: svc}
+}
+
+func (h *reminderHandler) GetUpcoming(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(DefaultDays)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days"})
+		return
+	}
+
+	response, err := h.svc.GetUpcoming(user_id.(uint), c.GetUint("workspace_id"), days)
+	if err != nil {
+		if errors.Is(err, ErrInvalidDays) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Upcoming reminders retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *reminderHandler) GetNotifications(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intPage, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || intPage < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	intLimit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || intLimit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	unread := c.DefaultQuery("unread", "false") == "true"
+
+	response, err := h.svc.GetNotifications(user_id.(uint), intPage, intLimit, unread)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *reminderHandler) MarkNotificationRead(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	notification, err := h.svc.MarkNotificationRead(uint(intId), user_id.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read",
+		"data":    notification,
+	})
+}
@@ -0,0 +1,72 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/date"
+)
+
+const (
+	KindBirthday    = "birthday"
+	KindAnniversary = "anniversary"
+	KindCustom      = "custom"
+
+	// DefaultDays is the window of GET /reminders/upcoming without ?days.
+	DefaultDays = 30
+	// MaxDays caps the window so every date occurs at most once in it.
+	MaxDays = 365
+)
+
+// Source is a recurring date of a contact: a birthday, an anniversary or a
+// custom date field.
+type Source struct {
+	ContactID   uint
+	ContactName string
+	Kind        string
+	Label       string
+	Date        date.Date
+}
+
+// Reminder is the next occurrence of a Source.
+type Reminder struct {
+	ContactID   uint      `json:"contact_id"`
+	ContactName string    `json:"contact_name"`
+	Kind        string    `json:"kind"`
+	Label       string    `json:"label"`
+	Date        date.Date `json:"date"`
+	DaysUntil   int       `json:"days_until"`
+	Years       int       `json:"years"`
+}
+
+// Notification is a reminder the scheduler has raised for a user. The
+// unique index makes generating the same reminder twice a no-op.
+type Notification struct {
+	ID        uint       `gorm:"column:notification_id;primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;uniqueIndex:idx_reminder_notifications_occurrence;index:idx_reminder_notifications_user" json:"user_id"`
+	ContactID uint       `gorm:"not null;uniqueIndex:idx_reminder_notifications_occurrence" json:"contact_id"`
+	Kind      string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_reminder_notifications_occurrence" json:"kind"`
+	Label     string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_reminder_notifications_occurrence" json:"label"`
+	OccursOn  date.Date  `gorm:"type:date;not null;uniqueIndex:idx_reminder_notifications_occurrence" json:"occurs_on"`
+	Message   string     `gorm:"type:varchar(255);not null" json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `gorm:"index:idx_reminder_notifications_user" json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "reminder_notifications"
+}
+
+type UpcomingResponse struct {
+	Timezone string     `json:"timezone"`
+	From     date.Date  `json:"from"`
+	Days     int        `json:"days"`
+	Data     []Reminder `json:"data"`
+}
+
+type GetNotificationsResponse struct {
+	Data       []Notification `json:"data"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	Total      int            `json:"total"`
+	TotalPages int            `json:"total_pages"`
+}
@@ -0,0 +1,175 @@
+package reminders
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/date"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	FindUserById(user_id uint) (*users.User, error)
+	EachUserBatch(fn func(user_list []users.User) error) error
+	FindWorkspaceIds(user_id uint) ([]uint, error)
+	GetSources(user_id, workspace_id uint) ([]Source, error)
+	CreateNotifications(notifications []Notification) (int64, error)
+	GetNotifications(user_id uint, page, limit int, unread bool) (*GetNotificationsResponse, error)
+	MarkNotificationRead(id, user_id uint) (*Notification, error)
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+func (r *reminderRepository) FindUserById(user_id uint) (*users.User, error) {
+	var user users.User
+	if err := r.db.Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *reminderRepository) EachUserBatch(fn func(user_list []users.User) error) error {
+	var user_list []users.User
+	return r.db.Select("user_id", "timezone").FindInBatches(&user_list, 500, func(tx *gorm.DB, batch int) error {
+		return fn(user_list)
+	}).Error
+}
+
+// FindWorkspaceIds returns the organization workspaces user_id is a member
+// of.
+func (r *reminderRepository) FindWorkspaceIds(user_id uint) ([]uint, error) {
+	var workspace_ids []uint
+	err := r.db.Table("workspace_members").Where("user_id = ?", user_id).
+		Order("workspace_id").Pluck("workspace_id", &workspace_ids).Error
+	return workspace_ids, err
+}
+
+// GetSources returns the dates of the contacts user_id can see in the
+// workspace.
+func (r *reminderRepository) GetSources(user_id, workspace_id uint) ([]Source, error) {
+	var contact_list []contacts.Contact
+	err := r.db.Select("contact_id", "first_name", "last_name", "birthday", "anniversary").
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).
+		Where("birthday IS NOT NULL OR anniversary IS NOT NULL").
+		Find(&contact_list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []Source
+	for _, contact := range contact_list {
+		name := contactName(contact.FirstName, contact.LastName)
+		if contact.Birthday != nil {
+			sources = append(sources, Source{ContactID: contact.ID, ContactName: name, Kind: KindBirthday, Label: KindBirthday, Date: *contact.Birthday})
+		}
+		if contact.Anniversary != nil {
+			sources = append(sources, Source{ContactID: contact.ID, ContactName: name, Kind: KindAnniversary, Label: KindAnniversary, Date: *contact.Anniversary})
+		}
+	}
+
+	// Values of custom fields of type date recur the same way.
+	var custom []struct {
+		ContactID uint
+		FirstName string
+		LastName  string
+		Label     string
+		DateValue date.Date
+	}
+	err = r.db.Table("contact_custom_values cv").
+		Select("cv.contact_id, contacts.first_name, contacts.last_name, f.label, cv.date_value").
+		Joins("JOIN custom_fields f ON f.field_id = cv.field_id").
+		Joins("JOIN contacts ON contacts.contact_id = cv.contact_id AND contacts.deleted_at IS NULL").
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).
+		Where("f.type = ? AND cv.date_value IS NOT NULL", customfields.TypeDate).
+		Scan(&custom).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range custom {
+		sources = append(sources, Source{
+			ContactID:   value.ContactID,
+			ContactName: contactName(value.FirstName, value.LastName),
+			Kind:        KindCustom,
+			Label:       value.Label,
+			Date:        value.DateValue,
+		})
+	}
+
+	return sources, nil
+}
+
+// CreateNotifications inserts the notifications that do not exist yet and
+// returns how many were new.
+func (r *reminderRepository) CreateNotifications(notifications []Notification) (int64, error) {
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&notifications)
+	return result.RowsAffected, result.Error
+}
+
+func (r *reminderRepository) GetNotifications(user_id uint, page, limit int, unread bool) (*GetNotificationsResponse, error) {
+	var notifications []Notification
+	var total int64
+
+	query := r.db.Model(&Notification{}).Where("user_id = ?", user_id)
+	if unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := query.Order("occurs_on").Order("notification_id").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &GetNotificationsResponse{
+		Data:       notifications,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (r *reminderRepository) MarkNotificationRead(id, user_id uint) (*Notification, error) {
+	var notification Notification
+	if err := r.db.Where("notification_id = ? AND user_id = ?", id, user_id).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	if notification.ReadAt != nil {
+		return &notification, nil
+	}
+
+	now := time.Now()
+	if err := r.db.Model(&notification).Update("read_at", now).Error; err != nil {
+		return nil, err
+	}
+	notification.ReadAt = &now
+	return &notification, nil
+}
+
+func contactName(first_name, last_name string) string {
+	return strings.TrimSpace(first_name + " " + last_name)
+}
@@ -0,0 +1,51 @@
+package reminders
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Scheduler periodically turns upcoming dates into notifications. It runs
+// inside the API process; running several instances only costs duplicate
+// work because notifications are deduplicated by the database.
+type Scheduler struct {
+	svc       ReminderService
+	interval  time.Duration
+	lead_days int
+}
+
+func NewScheduler(svc ReminderService, interval time.Duration, lead_days int) *Scheduler {
+	return &Scheduler{svc: svc, interval: interval, lead_days: lead_days}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled. The
+// first run happens immediately.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.RunOnce(time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce generates the notifications due at now.
+func (s *Scheduler) RunOnce(now time.Time) {
+	created, err := s.svc.GenerateNotifications(now, s.lead_days)
+	if err != nil {
+		log.Printf("reminders: generating notifications failed: %v", err)
+		return
+	}
+	if created > 0 {
+		log.Printf("reminders: created %d notifications", created)
+	}
+}
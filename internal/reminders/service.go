@@ -0,0 +1,132 @@
+package reminders
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidDays = fmt.Errorf("days must be between 1 and %d", MaxDays)
+
+type ReminderService interface {
+	GetUpcoming(user_id, workspace_id uint, days int) (*UpcomingResponse, error)
+	GenerateNotifications(now time.Time, lead_days int) (int64, error)
+	GetNotifications(user_id uint, page, limit int, unread bool) (*GetNotificationsResponse, error)
+	MarkNotificationRead(id, user_id uint) (*Notification, error)
+}
+
+type reminderService struct {
+	repo ReminderRepository
+}
+
+func NewReminderService(repo ReminderRepository) ReminderService {
+	return &reminderService{repo: repo}
+}
+
+func (s *reminderService) GetUpcoming(user_id, workspace_id uint, days int) (*UpcomingResponse, error) {
+	if days < 1 || days > MaxDays {
+		return nil, ErrInvalidDays
+	}
+
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	sources, err := s.repo.GetSources(user_id, workspace_id)
+	if err != nil {
+		return nil, err
+	}
+
+	today := Today(time.Now(), user.Timezone)
+	return &UpcomingResponse{
+		Timezone: Location(user.Timezone).String(),
+		From:     today,
+		Days:     days,
+		Data:     Upcoming(sources, today, days),
+	}, nil
+}
+
+// GenerateNotifications raises a notification for every date occurring
+// within lead_days of each user's current date, in the user's timezone,
+// covering the personal workspace and every organization the user is a
+// member of. Notifications that already exist are left alone, so it is safe
+// to run as often as needed.
+func (s *reminderService) GenerateNotifications(now time.Time, lead_days int) (int64, error) {
+	var created int64
+	err := s.repo.EachUserBatch(func(user_list []users.User) error {
+		for _, user := range user_list {
+			sources, err := s.allSources(user.ID)
+			if err != nil {
+				return err
+			}
+
+			upcoming := Upcoming(sources, Today(now, user.Timezone), lead_days)
+			notifications := make([]Notification, 0, len(upcoming))
+			for _, reminder := range upcoming {
+				notifications = append(notifications, Notification{
+					UserID:    user.ID,
+					ContactID: reminder.ContactID,
+					Kind:      reminder.Kind,
+					Label:     reminder.Label,
+					OccursOn:  reminder.Date,
+					Message:   Message(reminder),
+				})
+			}
+
+			count, err := s.repo.CreateNotifications(notifications)
+			if err != nil {
+				return err
+			}
+			created += count
+		}
+		return nil
+	})
+	return created, err
+}
+
+// allSources returns the dates of every contact user_id can see, in any
+// workspace.
+func (s *reminderService) allSources(user_id uint) ([]Source, error) {
+	workspace_ids, err := s.repo.FindWorkspaceIds(user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []Source
+	for _, workspace_id := range append([]uint{workspaces.Personal}, workspace_ids...) {
+		workspace_sources, err := s.repo.GetSources(user_id, workspace_id)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, workspace_sources...)
+	}
+	return sources, nil
+}
+
+func (s *reminderService) GetNotifications(user_id uint, page, limit int, unread bool) (*GetNotificationsResponse, error) {
+	response, err := s.repo.GetNotifications(user_id, page, limit, unread)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *reminderService) MarkNotificationRead(id, user_id uint) (*Notification, error) {
+	notification, err := s.repo.MarkNotificationRead(id, user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification not found")
+		}
+		return nil, err
+	}
+	return notification, nil
+}
@@ -0,0 +1,91 @@
+package reminders
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/date"
+)
+
+// Location returns the location of an IANA timezone name, falling back to
+// UTC for empty or unknown names.
+func Location(timezone string) *time.Location {
+	if timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today returns the current calendar date in the given timezone.
+func Today(now time.Time, timezone string) date.Date {
+	return date.Of(now.In(Location(timezone)))
+}
+
+// Upcoming returns the sources that occur within days of today (today
+// included), soonest first.
+func Upcoming(sources []Source, today date.Date, days int) []Reminder {
+	reminders := []Reminder{}
+	for _, source := range sources {
+		next := nextOccurrence(source.Date, today)
+		until := int(next.Sub(today.Time).Hours() / 24)
+		if until >= days {
+			continue
+		}
+		reminders = append(reminders, Reminder{
+			ContactID:   source.ContactID,
+			ContactName: source.ContactName,
+			Kind:        source.Kind,
+			Label:       source.Label,
+			Date:        next,
+			DaysUntil:   until,
+			Years:       next.Year() - source.Date.Year(),
+		})
+	}
+
+	sort.SliceStable(reminders, func(i, j int) bool {
+		if reminders[i].DaysUntil != reminders[j].DaysUntil {
+			return reminders[i].DaysUntil < reminders[j].DaysUntil
+		}
+		return reminders[i].ContactName < reminders[j].ContactName
+	})
+	return reminders
+}
+
+// Message is the text of the notification raised for a reminder.
+func Message(reminder Reminder) string {
+	when := fmt.Sprintf("in %d days", reminder.DaysUntil)
+	switch reminder.DaysUntil {
+	case 0:
+		when = "today"
+	case 1:
+		when = "tomorrow"
+	}
+	return fmt.Sprintf("%s's %s is %s (%s)", reminder.ContactName, reminder.Label, when, reminder.Date)
+}
+
+// nextOccurrence returns the first anniversary of origin on or after today.
+// February 29 is celebrated on February 28 in common years.
+func nextOccurrence(origin, today date.Date) date.Date {
+	next := occurrenceIn(origin, today.Year())
+	if next.Before(today.Time) {
+		next = occurrenceIn(origin, today.Year()+1)
+	}
+	return next
+}
+
+func occurrenceIn(origin date.Date, year int) date.Date {
+	day := origin.Day()
+	if origin.Month() == time.February && day == 29 && !isLeap(year) {
+		day = 28
+	}
+	return date.New(year, origin.Month(), day)
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
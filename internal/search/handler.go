@@ -0,0 +1,56 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler interface {
+	Search(c *gin.Context)
+}
+
+type searchHandler struct {
+	svc SearchService
+}
+
+func NewSearchHandler(svc SearchService) SearchHandler {
+	return &searchHandler{svc: svc}
+}
+
+func (h *searchHandler) Search(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	response, err := h.svc.Search(user_id.(uint), c.GetUint("workspace_id"), c.Query("q"), page, limit)
+	if err != nil {
+		if errors.Is(err, ErrEmptyQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Search results retrieved successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+// Index finds contacts by the words of their fields, addresses and
+// activities.
+type Index interface {
+	// Search returns one page of the contacts matching query, most relevant
+	// first, and how many match in total.
+	Search(query Query) ([]Hit, int, error)
+}
+
+// The columns of the FULLTEXT indexes; a MATCH must list them exactly.
+const (
+	contactColumns  = "contacts.first_name, contacts.last_name, contacts.email, contacts.phone, contacts.job_title"
+	addressColumns  = "a.street, a.city, a.state, a.postal_code, a.country"
+	activityColumns = "act.body"
+)
+
+type mysqlIndex struct {
+	db *gorm.DB
+}
+
+// NewMySQLIndex searches the FULLTEXT indexes of the contacts, addresses and
+// activities tables.
+func NewMySQLIndex(db *gorm.DB) Index {
+	return &mysqlIndex{db: db}
+}
+
+// Search requires every term to match somewhere in the contact, its
+// addresses or its activities, and ranks by the summed relevance of all
+// three. The contact's own fields count double.
+func (i *mysqlIndex) Search(query Query) ([]Hit, int, error) {
+	if len(query.Terms) == 0 {
+		return []Hit{}, 0, nil
+	}
+
+	db := i.db.Model(&contacts.Contact{}).Scopes(contacts.AccessibleBy(query.UserID, query.WorkspaceID, contacts.PermissionView))
+	prefixes := make([]string, len(query.Terms))
+	var all_codes []string
+	for n, term := range query.Terms {
+		prefixes[n] = term + "*"
+		condition := "MATCH(" + contactColumns + ") AGAINST (? IN BOOLEAN MODE)" +
+			" OR EXISTS (SELECT 1 FROM addresses a WHERE a.contact_id = contacts.contact_id AND a.deleted_at IS NULL AND MATCH(" + addressColumns + ") AGAINST (? IN BOOLEAN MODE))" +
+			" OR EXISTS (SELECT 1 FROM activities act WHERE act.contact_id = contacts.contact_id AND act.deleted_at IS NULL AND MATCH(" + activityColumns + ") AGAINST (? IN BOOLEAN MODE))"
+		args := []any{prefixes[n], prefixes[n], prefixes[n]}
+		if codes := CountryCodes(term); len(codes) > 0 {
+			condition += " OR EXISTS (SELECT 1 FROM addresses a WHERE a.contact_id = contacts.contact_id AND a.deleted_at IS NULL AND a.country IN ?)"
+			args = append(args, codes)
+			all_codes = append(all_codes, codes...)
+		}
+		db = db.Where("("+condition+")", args...)
+	}
+
+	// The conditions are shared by the count and the page query.
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	any_term := strings.Join(prefixes, " ")
+	score := "2 * MATCH(" + contactColumns + ") AGAINST (? IN BOOLEAN MODE)" +
+		" + COALESCE((SELECT SUM(MATCH(" + addressColumns + ") AGAINST (? IN BOOLEAN MODE)) FROM addresses a WHERE a.contact_id = contacts.contact_id AND a.deleted_at IS NULL), 0)" +
+		" + COALESCE((SELECT SUM(MATCH(" + activityColumns + ") AGAINST (? IN BOOLEAN MODE)) FROM activities act WHERE act.contact_id = contacts.contact_id AND act.deleted_at IS NULL), 0)"
+
+	score_args := []any{any_term, any_term, any_term}
+	if len(all_codes) > 0 {
+		score += " + (SELECT COUNT(*) FROM addresses a WHERE a.contact_id = contacts.contact_id AND a.deleted_at IS NULL AND a.country IN ?)"
+		score_args = append(score_args, all_codes)
+	}
+
+	var hits []Hit
+	err := db.Select("contacts.contact_id AS contact_id, ("+score+") AS score", score_args...).
+		Order("score DESC").
+		Order("contacts.contact_id").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return hits, int(total), nil
+}
+
+// CountryCodes returns the codes of the countries with a word in their name
+// that starts with term. Addresses store the code, which is too short for
+// the FULLTEXT index and is not the name the memory index sees, so a
+// country name is matched against the codes instead.
+func CountryCodes(term string) []string {
+	var codes []string
+	for _, c := range country.All() {
+		for _, word := range Tokenize(c.Name) {
+			if strings.HasPrefix(word, term) {
+				codes = append(codes, c.Code)
+				break
+			}
+		}
+	}
+	return codes
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+)
+
+// MemoryIndex is an inverted index held in memory, for tests and small
+// setups. Documents are added with Put. Visibility follows ownership only:
+// a contact is found by its owner in the personal workspace and by everyone
+// in its organization workspace; shares are not modelled.
+type MemoryIndex struct {
+	mu       sync.RWMutex
+	docs     map[uint]Document
+	postings map[string]map[uint]float64
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{docs: make(map[uint]Document), postings: make(map[string]map[uint]float64)}
+}
+
+// Put adds the document, replacing an earlier version of it.
+func (m *MemoryIndex) Put(doc Document) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(doc.ContactID)
+	m.docs[doc.ContactID] = doc
+	for _, field := range doc.Fields {
+		weight := fieldWeight(field)
+		for _, word := range Tokenize(field.Text) {
+			if m.postings[word] == nil {
+				m.postings[word] = make(map[uint]float64)
+			}
+			m.postings[word][doc.ContactID] += weight
+		}
+	}
+}
+
+func (m *MemoryIndex) Remove(contact_id uint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(contact_id)
+}
+
+func (m *MemoryIndex) remove(contact_id uint) {
+	if _, ok := m.docs[contact_id]; !ok {
+		return
+	}
+	delete(m.docs, contact_id)
+	for word, contacts := range m.postings {
+		delete(contacts, contact_id)
+		if len(contacts) == 0 {
+			delete(m.postings, word)
+		}
+	}
+}
+
+// Search scores every word a term is a prefix of by its weight in the
+// document and its rarity across documents. Exact words score more than
+// longer words that only start with the term.
+func (m *MemoryIndex) Search(query Query) ([]Hit, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	scores := make(map[uint]float64)
+	for n, term := range query.Terms {
+		matched := make(map[uint]float64)
+		for word, contacts := range m.postings {
+			if !strings.HasPrefix(word, term) {
+				continue
+			}
+			idf := 1 + math.Log(float64(len(m.docs))/float64(len(contacts)))
+			exact := 0.5
+			if word == term {
+				exact = 1
+			}
+			for contact_id, weight := range contacts {
+				matched[contact_id] += weight * idf * exact
+			}
+		}
+
+		// Every term must match.
+		if n == 0 {
+			scores = matched
+			continue
+		}
+		for contact_id := range scores {
+			if score, ok := matched[contact_id]; ok {
+				scores[contact_id] += score
+			} else {
+				delete(scores, contact_id)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for contact_id, score := range scores {
+		if m.visible(m.docs[contact_id], query) {
+			hits = append(hits, Hit{ContactID: contact_id, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ContactID < hits[j].ContactID
+	})
+
+	total := len(hits)
+	from := min(total, (query.Page-1)*query.Limit)
+	to := min(total, from+query.Limit)
+	return hits[from:to], total, nil
+}
+
+func (m *MemoryIndex) visible(doc Document, query Query) bool {
+	if query.WorkspaceID == workspaces.Personal {
+		return doc.WorkspaceID == nil && doc.UserID == query.UserID
+	}
+	return doc.WorkspaceID != nil && *doc.WorkspaceID == query.WorkspaceID
+}
+
+// fieldWeight makes names count most and activities least.
+func fieldWeight(field Field) float64 {
+	switch {
+	case field.Source == SourceContact && (field.Name == "first_name" || field.Name == "last_name"):
+		return 3
+	case field.Source == SourceContact:
+		return 2
+	case field.Source == SourceAddress:
+		return 1
+	default:
+		return 0.5
+	}
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// Sources of the searchable text of a contact.
+const (
+	SourceContact  = "contact"
+	SourceAddress  = "address"
+	SourceActivity = "activity"
+)
+
+const (
+	// MaxTerms bounds the number of words of a query.
+	MaxTerms = 10
+	// MaxLimit bounds the page size of GET /search.
+	MaxLimit = 50
+)
+
+// Query asks for the contacts visible to UserID in WorkspaceID that match
+// every term. A term matches any word it is a prefix of.
+type Query struct {
+	UserID      uint
+	WorkspaceID uint
+	Terms       []string
+	Page        int
+	Limit       int
+}
+
+// Hit is a matching contact and its relevance; higher is better.
+type Hit struct {
+	ContactID uint
+	Score     float64
+}
+
+// Field is one piece of searchable text, e.g. the city of an address.
+type Field struct {
+	Source   string
+	SourceID uint
+	Name     string
+	Text     string
+}
+
+// Document is the searchable text of a contact: its own fields, its
+// addresses and its activities. UserID and WorkspaceID are the contact's
+// owner and workspace.
+type Document struct {
+	ContactID   uint
+	UserID      uint
+	WorkspaceID *uint
+	Fields      []Field
+}
+
+// Highlight is a field that matched, with the matching words wrapped in
+// <mark> tags.
+type Highlight struct {
+	Source   string `json:"source"`
+	SourceID uint   `json:"source_id"`
+	Field    string `json:"field"`
+	Snippet  string `json:"snippet"`
+}
+
+type Result struct {
+	Contact    contacts.Contact `json:"contact"`
+	Score      float64          `json:"score"`
+	Highlights []Highlight      `json:"highlights"`
+}
+
+type SearchResponse struct {
+	Query      string   `json:"query"`
+	Data       []Result `json:"data"`
+	Page       int      `json:"page"`
+	Limit      int      `json:"limit"`
+	Total      int      `json:"total"`
+	TotalPages int      `json:"total_pages"`
+}
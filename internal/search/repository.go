@@ -0,0 +1,90 @@
+package search
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+type SearchRepository interface {
+	FindContacts(contact_ids []uint) ([]contacts.Contact, error)
+	LoadDocuments(contact_ids []uint) ([]Document, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+func (r *searchRepository) FindContacts(contact_ids []uint) ([]contacts.Contact, error) {
+	var contacts_db []contacts.Contact
+	if err := r.db.Preload("Company").Preload("Tags").Where("contact_id IN ?", contact_ids).Find(&contacts_db).Error; err != nil {
+		return nil, err
+	}
+	return contacts_db, nil
+}
+
+// LoadDocuments reads the searchable text of the contacts, used to
+// highlight what matched.
+func (r *searchRepository) LoadDocuments(contact_ids []uint) ([]Document, error) {
+	var contacts_db []contacts.Contact
+	if err := r.db.Where("contact_id IN ?", contact_ids).Find(&contacts_db).Error; err != nil {
+		return nil, err
+	}
+	var addresses_db []addresses.Address
+	if err := r.db.Where("contact_id IN ?", contact_ids).Order("address_id").Find(&addresses_db).Error; err != nil {
+		return nil, err
+	}
+	var activities_db []activities.Activity
+	if err := r.db.Where("contact_id IN ?", contact_ids).Order("occurred_at DESC").Find(&activities_db).Error; err != nil {
+		return nil, err
+	}
+
+	addresses_by_contact := make(map[uint][]addresses.Address)
+	for _, address := range addresses_db {
+		addresses_by_contact[address.ContactID] = append(addresses_by_contact[address.ContactID], address)
+	}
+	activities_by_contact := make(map[uint][]activities.Activity)
+	for _, activity := range activities_db {
+		activities_by_contact[activity.ContactID] = append(activities_by_contact[activity.ContactID], activity)
+	}
+
+	docs := make([]Document, len(contacts_db))
+	for i, contact := range contacts_db {
+		docs[i] = NewDocument(contact, addresses_by_contact[contact.ID], activities_by_contact[contact.ID])
+	}
+	return docs, nil
+}
+
+// NewDocument collects the searchable text of a contact.
+func NewDocument(contact contacts.Contact, address_list []addresses.Address, activity_list []activities.Activity) Document {
+	doc := Document{ContactID: contact.ID, UserID: contact.UserID, WorkspaceID: contact.WorkspaceID}
+	add := func(source string, source_id uint, name, text string) {
+		if text != "" {
+			doc.Fields = append(doc.Fields, Field{Source: source, SourceID: source_id, Name: name, Text: text})
+		}
+	}
+
+	add(SourceContact, contact.ID, "first_name", contact.FirstName)
+	add(SourceContact, contact.ID, "last_name", contact.LastName)
+	add(SourceContact, contact.ID, "email", contact.Email)
+	add(SourceContact, contact.ID, "phone", contact.Phone)
+	add(SourceContact, contact.ID, "job_title", contact.JobTitle)
+	for _, address := range address_list {
+		add(SourceAddress, address.ID, "street", address.Street)
+		add(SourceAddress, address.ID, "city", address.City)
+		add(SourceAddress, address.ID, "state", address.State)
+		add(SourceAddress, address.ID, "postal_code", address.PostalCode)
+		add(SourceAddress, address.ID, "country", country.Name(address.Country))
+	}
+	for _, activity := range activity_list {
+		add(SourceActivity, activity.ID, "body", activity.Body)
+	}
+	return doc
+}
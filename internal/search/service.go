@@ -0,0 +1,86 @@
+package search
+
+import (
+	"errors"
+)
+
+var ErrEmptyQuery = errors.New("search query has no words")
+
+type SearchService interface {
+	Search(user_id, workspace_id uint, q string, page, limit int) (*SearchResponse, error)
+}
+
+type searchService struct {
+	index Index
+	repo  SearchRepository
+}
+
+func NewSearchService(index Index, repo SearchRepository) SearchService {
+	return &searchService{index: index, repo: repo}
+}
+
+// Search finds the contacts matching every word of q, most relevant first,
+// with the fields that matched highlighted.
+func (s *searchService) Search(user_id, workspace_id uint, q string, page, limit int) (*SearchResponse, error) {
+	terms := ParseQuery(q)
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	hits, total, err := s.index.Search(Query{UserID: user_id, WorkspaceID: workspace_id, Terms: terms, Page: page, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchResponse{Query: q, Data: []Result{}, Page: page, Limit: limit, Total: total}
+	response.TotalPages = total / limit
+	if total%limit != 0 {
+		response.TotalPages++
+	}
+	if len(hits) == 0 {
+		return response, nil
+	}
+
+	contact_ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		contact_ids[i] = hit.ContactID
+	}
+	contacts_db, err := s.repo.FindContacts(contact_ids)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.repo.LoadDocuments(contact_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	by_id := make(map[uint]int, len(contacts_db))
+	for i, contact := range contacts_db {
+		by_id[contact.ID] = i
+	}
+	docs_by_id := make(map[uint]Document, len(docs))
+	for _, doc := range docs {
+		docs_by_id[doc.ContactID] = doc
+	}
+	for _, hit := range hits {
+		i, ok := by_id[hit.ContactID]
+		if !ok {
+			continue
+		}
+		response.Data = append(response.Data, Result{
+			Contact:    contacts_db[i],
+			Score:      hit.Score,
+			Highlights: Highlights(docs_by_id[hit.ContactID], terms),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// snippetLength is roughly how many characters of a long field a highlight
+// shows around the first match.
+const snippetLength = 160
+
+// Tokenize splits text into lowercase words of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// ParseQuery turns a search box input into at most MaxTerms distinct terms.
+func ParseQuery(q string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range Tokenize(q) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// matchesAny reports whether word starts with one of the terms.
+func matchesAny(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mark wraps the words of text that start with one of the terms in
+// <mark> tags and escapes the rest for HTML. Long text is cut down to a
+// snippet around the first match. ok is false when nothing matched.
+func Mark(text string, terms []string) (snippet string, ok bool) {
+	type span struct{ start, end int }
+	var spans []span
+	start := -1
+	for i, r := range text + " " {
+		if !isSeparator(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			if matchesAny(strings.ToLower(text[start:i]), terms) {
+				spans = append(spans, span{start, i})
+			}
+			start = -1
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if len(text) > snippetLength {
+		from = max(0, spans[0].start-snippetLength/4)
+		to = min(len(text), from+snippetLength)
+		for from > 0 && !utf8.RuneStart(text[from]) {
+			from++
+		}
+		for to < len(text) && !utf8.RuneStart(text[to]) {
+			to--
+		}
+		// Cut at word boundaries.
+		if from > 0 {
+			if i := strings.IndexByte(text[from:spans[0].start], ' '); i >= 0 {
+				from += i + 1
+			}
+		}
+		if to < len(text) {
+			if i := strings.LastIndexByte(text[from:to], ' '); i > spans[0].end-from {
+				to = from + i
+			}
+		}
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	last := from
+	for _, s := range spans {
+		if s.start < from || s.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:s.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[s.start:s.end]))
+		b.WriteString("</mark>")
+		last = s.end
+	}
+	b.WriteString(html.EscapeString(text[last:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// Highlights returns the fields of doc that match one of the terms.
+func Highlights(doc Document, terms []string) []Highlight {
+	highlights := []Highlight{}
+	for _, field := range doc.Fields {
+		if snippet, ok := Mark(field.Text, terms); ok {
+			highlights = append(highlights, Highlight{Source: field.Source, SourceID: field.SourceID, Field: field.Name, Snippet: snippet})
+		}
+	}
+	return highlights
+}
@@ -0,0 +1,190 @@
+package sharing
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareHandler interface {
+	ShareContact(c *gin.Context)
+	GetContactShares(c *gin.Context)
+	ShareGroup(c *gin.Context)
+	GetGroupShares(c *gin.Context)
+	GetShares(c *gin.Context)
+	RevokeShare(c *gin.Context)
+}
+
+type shareHandler struct {
+	svc ShareService
+}
+
+func NewShareHandler(svc ShareService) ShareHandler {
+	return &shareHandler{svc: svc}
+}
+
+func (h *shareHandler) ShareContact(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	var request ShareRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	share, err := h.svc.ShareContact(user_id.(uint), c.GetUint("workspace_id"), uint(intId), request)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrWorkspaceContact) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact shared successfully",
+		"data":    share,
+	})
+}
+
+func (h *shareHandler) GetContactShares(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	response, err := h.svc.GetContactShares(user_id.(uint), c.GetUint("workspace_id"), uint(intId))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shares retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *shareHandler) ShareGroup(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	var request ShareRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	share, err := h.svc.ShareGroup(user_id.(uint), uint(intId), request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Group shared successfully",
+		"data":    share,
+	})
+}
+
+func (h *shareHandler) GetGroupShares(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	shares, err := h.svc.GetGroupShares(user_id.(uint), uint(intId))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shares retrieved successfully",
+		"data":    shares,
+	})
+}
+
+func (h *shareHandler) GetShares(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	direction := c.DefaultQuery("direction", "given")
+	if direction != "given" && direction != "received" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid direction"})
+		return
+	}
+
+	shares, err := h.svc.GetShares(user_id.(uint), direction == "received")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shares retrieved successfully",
+		"data":    shares,
+	})
+}
+
+func (h *shareHandler) RevokeShare(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	if err := h.svc.RevokeShare(user_id.(uint), uint(intId)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Share revoked successfully",
+	})
+}
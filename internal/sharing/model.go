@@ -0,0 +1,38 @@
+package sharing
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+// Share grants another user access to one contact or to every contact in a
+// group. Exactly one of ContactID and GroupID is set.
+type Share struct {
+	ID         uint       `gorm:"column:share_id;primaryKey" json:"id"`
+	OwnerID    uint       `gorm:"not null;index" json:"owner_id"`
+	GranteeID  uint       `gorm:"not null;uniqueIndex:idx_contact_shares_grantee_contact;uniqueIndex:idx_contact_shares_grantee_group" json:"grantee_id"`
+	Grantee    users.User `gorm:"foreignKey:GranteeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"grantee"`
+	ContactID  *uint      `gorm:"uniqueIndex:idx_contact_shares_grantee_contact;index" json:"contact_id,omitempty"`
+	GroupID    *uint      `gorm:"uniqueIndex:idx_contact_shares_grantee_group;index" json:"group_id,omitempty"`
+	Permission string     `gorm:"type:varchar(10);not null" json:"permission"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (Share) TableName() string {
+	return "contact_shares"
+}
+
+type ShareRequest struct {
+	Email      string `json:"email" binding:"required,email"`
+	Permission string `json:"permission" binding:"required,oneof=view edit"`
+}
+
+// ContactSharesResponse lists everyone a contact is shared with, directly
+// and through the groups it belongs to.
+type ContactSharesResponse struct {
+	ContactID uint    `json:"contact_id"`
+	OwnerID   uint    `json:"owner_id"`
+	Shares    []Share `json:"shares"`
+}
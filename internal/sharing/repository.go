@@ -0,0 +1,129 @@
+package sharing
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShareRepository interface {
+	FindOwnedContact(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindAccessibleContact(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindOwnedGroup(id, user_id uint) (*groups.Group, error)
+	FindUserByEmail(email string) (*users.User, error)
+	SaveShare(share *Share) error
+	GetContactShares(contact_id uint) ([]Share, error)
+	GetGroupShares(group_id uint) ([]Share, error)
+	GetShares(user_id uint, received bool) ([]Share, error)
+	FindShareById(id uint) (*Share, error)
+	DeleteShare(id uint) error
+}
+
+type shareRepository struct {
+	db *gorm.DB
+}
+
+func NewShareRepository(db *gorm.DB) ShareRepository {
+	return &shareRepository{db: db}
+}
+
+// FindOwnedContact finds a contact of the workspace, not one shared with
+// user_id, that user_id may change.
+func (r *shareRepository) FindOwnedContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionEdit)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *shareRepository) FindAccessibleContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.AccessibleBy(user_id, workspace_id, contacts.PermissionView)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *shareRepository) FindOwnedGroup(id, user_id uint) (*groups.Group, error) {
+	var group groups.Group
+	if err := r.db.Where("group_id = ? AND user_id = ?", id, user_id).First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *shareRepository) FindUserByEmail(email string) (*users.User, error) {
+	var user users.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SaveShare creates the grant, or changes the permission when the grantee
+// already has one on the same contact or group.
+func (r *shareRepository) SaveShare(share *Share) error {
+	err := r.db.Omit("Grantee").Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"permission", "updated_at"}),
+	}).Create(share).Error
+	if err != nil {
+		return err
+	}
+
+	query := r.db.Preload("Grantee").Where("grantee_id = ?", share.GranteeID)
+	if share.ContactID != nil {
+		query = query.Where("contact_id = ?", *share.ContactID)
+	} else {
+		query = query.Where("group_id = ?", *share.GroupID)
+	}
+	return query.First(share).Error
+}
+
+func (r *shareRepository) GetContactShares(contact_id uint) ([]Share, error) {
+	var shares []Share
+	err := r.db.Preload("Grantee").
+		Where("contact_id = ?", contact_id).
+		Or("group_id IN (?)", r.db.Model(&groups.Member{}).Select("group_id").Where("contact_id = ?", contact_id)).
+		Order("share_id").
+		Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *shareRepository) GetGroupShares(group_id uint) ([]Share, error) {
+	var shares []Share
+	if err := r.db.Preload("Grantee").Where("group_id = ?", group_id).Order("share_id").Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *shareRepository) GetShares(user_id uint, received bool) ([]Share, error) {
+	var shares []Share
+	column := "owner_id = ?"
+	if received {
+		column = "grantee_id = ?"
+	}
+	if err := r.db.Preload("Grantee").Where(column, user_id).Order("share_id").Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *shareRepository) FindShareById(id uint) (*Share, error) {
+	var share Share
+	if err := r.db.Where("share_id = ?", id).First(&share).Error; err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *shareRepository) DeleteShare(id uint) error {
+	return r.db.Where("share_id = ?", id).Delete(&Share{}).Error
+}
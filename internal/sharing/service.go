@@ -0,0 +1,147 @@
+package sharing
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrWorkspaceContact is returned when sharing an organization contact.
+// Organization contacts are shared through workspace membership instead.
+var ErrWorkspaceContact = errors.New("workspace contacts are shared with the workspace members")
+
+type ShareService interface {
+	ShareContact(user_id, workspace_id, contact_id uint, request ShareRequest) (*Share, error)
+	ShareGroup(user_id, group_id uint, request ShareRequest) (*Share, error)
+	GetContactShares(user_id, workspace_id, contact_id uint) (*ContactSharesResponse, error)
+	GetGroupShares(user_id, group_id uint) ([]Share, error)
+	GetShares(user_id uint, received bool) ([]Share, error)
+	RevokeShare(user_id, id uint) error
+}
+
+type shareService struct {
+	repo ShareRepository
+}
+
+func NewShareService(repo ShareRepository) ShareService {
+	return &shareService{repo: repo}
+}
+
+func (s *shareService) ShareContact(user_id, workspace_id, contact_id uint, request ShareRequest) (*Share, error) {
+	contact, err := s.repo.FindOwnedContact(contact_id, user_id, workspace_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+	if contact.WorkspaceID != nil {
+		return nil, ErrWorkspaceContact
+	}
+
+	share, err := s.newShare(user_id, request)
+	if err != nil {
+		return nil, err
+	}
+	share.ContactID = &contact_id
+
+	if err := s.repo.SaveShare(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+func (s *shareService) ShareGroup(user_id, group_id uint, request ShareRequest) (*Share, error) {
+	if _, err := s.repo.FindOwnedGroup(group_id, user_id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
+	}
+
+	share, err := s.newShare(user_id, request)
+	if err != nil {
+		return nil, err
+	}
+	share.GroupID = &group_id
+
+	if err := s.repo.SaveShare(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+func (s *shareService) GetContactShares(user_id, workspace_id, contact_id uint) (*ContactSharesResponse, error) {
+	contact, err := s.repo.FindAccessibleContact(contact_id, user_id, workspace_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+
+	shares, err := s.repo.GetContactShares(contact_id)
+	if err != nil {
+		return nil, err
+	}
+	return &ContactSharesResponse{ContactID: contact.ID, OwnerID: contact.UserID, Shares: shares}, nil
+}
+
+func (s *shareService) GetGroupShares(user_id, group_id uint) ([]Share, error) {
+	if _, err := s.repo.FindOwnedGroup(group_id, user_id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
+	}
+
+	shares, err := s.repo.GetGroupShares(group_id)
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (s *shareService) GetShares(user_id uint, received bool) ([]Share, error) {
+	shares, err := s.repo.GetShares(user_id, received)
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// RevokeShare removes a grant. The owner can revoke any of their grants and
+// a grantee can give up access that was shared with them.
+func (s *shareService) RevokeShare(user_id, id uint) error {
+	share, err := s.repo.FindShareById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("share not found")
+		}
+		return err
+	}
+	if share.OwnerID != user_id && share.GranteeID != user_id {
+		return errors.New("share not found")
+	}
+	return s.repo.DeleteShare(id)
+}
+
+func (s *shareService) newShare(user_id uint, request ShareRequest) (*Share, error) {
+	grantee, err := s.repo.FindUserByEmail(strings.TrimSpace(request.Email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	if grantee.ID == user_id {
+		return nil, errors.New("cannot share with yourself")
+	}
+
+	return &Share{
+		OwnerID:    user_id,
+		GranteeID:  grantee.ID,
+		Permission: request.Permission,
+	}, nil
+}
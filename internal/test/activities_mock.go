@@ -0,0 +1,64 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// MockActivityRepository implements activities.ActivityRepository interface
+type MockActivityRepository struct {
+	FindContactByIdFunc  func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	GetActivitiesFunc    func(contact_id uint, page, limit int, activity_type string) (*activities.GetActivitiesResponse, error)
+	FindActivityByIdFunc func(id, contact_id uint) (*activities.Activity, error)
+	CreateActivityFunc   func(activity *activities.Activity) error
+	UpdateActivityFunc   func(activity *activities.Activity) error
+	DeleteActivityFunc   func(id, contact_id uint) error
+}
+
+// FindContactById implements activities.ActivityRepository
+func (m *MockActivityRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	if m.FindContactByIdFunc != nil {
+		return m.FindContactByIdFunc(id, user_id, workspace_id, permission)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// GetActivities implements activities.ActivityRepository
+func (m *MockActivityRepository) GetActivities(contact_id uint, page, limit int, activity_type string) (*activities.GetActivitiesResponse, error) {
+	if m.GetActivitiesFunc != nil {
+		return m.GetActivitiesFunc(contact_id, page, limit, activity_type)
+	}
+	return nil, nil
+}
+
+// FindActivityById implements activities.ActivityRepository
+func (m *MockActivityRepository) FindActivityById(id, contact_id uint) (*activities.Activity, error) {
+	if m.FindActivityByIdFunc != nil {
+		return m.FindActivityByIdFunc(id, contact_id)
+	}
+	return nil, nil
+}
+
+// CreateActivity implements activities.ActivityRepository
+func (m *MockActivityRepository) CreateActivity(activity *activities.Activity) error {
+	if m.CreateActivityFunc != nil {
+		return m.CreateActivityFunc(activity)
+	}
+	return nil
+}
+
+// UpdateActivity implements activities.ActivityRepository
+func (m *MockActivityRepository) UpdateActivity(activity *activities.Activity) error {
+	if m.UpdateActivityFunc != nil {
+		return m.UpdateActivityFunc(activity)
+	}
+	return nil
+}
+
+// DeleteActivity implements activities.ActivityRepository
+func (m *MockActivityRepository) DeleteActivity(id, contact_id uint) error {
+	if m.DeleteActivityFunc != nil {
+		return m.DeleteActivityFunc(id, contact_id)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+// ========== GetActivities Tests ==========
+
+// TestGetActivities_Success tests retrieval of a contact's timeline
+func TestGetActivities_Success(t *testing.T) {
+	mockRepo := &MockActivityRepository{
+		GetActivitiesFunc: func(contact_id uint, page, limit int, activity_type string) (*activities.GetActivitiesResponse, error) {
+			if contact_id != 5 || activity_type != activities.TypeCall {
+				t.Errorf("Expected calls of contact 5, got contact %d type %q", contact_id, activity_type)
+			}
+			return &activities.GetActivitiesResponse{
+				Data:  []activities.Activity{{ID: 2, ContactID: 5, Type: activities.TypeCall}},
+				Page:  page,
+				Limit: limit,
+				Total: 1,
+			}, nil
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	result, err := service.GetActivities(1, 0, 5, 1, 10, activities.TypeCall)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Total != 1 {
+		t.Errorf("Expected 1 activity, got %d", result.Total)
+	}
+}
+
+// TestGetActivities_ContactNotFound tests retrieval for a contact of another user
+func TestGetActivities_ContactNotFound(t *testing.T) {
+	mockRepo := &MockActivityRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	_, err := service.GetActivities(2, 0, 5, 1, 10, "")
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
+
+// ========== CreateActivity Tests ==========
+
+// TestCreateActivity_Success tests that the author and timestamp are filled in
+func TestCreateActivity_Success(t *testing.T) {
+	var saved *activities.Activity
+	mockRepo := &MockActivityRepository{
+		CreateActivityFunc: func(activity *activities.Activity) error {
+			activity.ID = 1
+			saved = activity
+			return nil
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	before := time.Now()
+	result, err := service.CreateActivity(3, 0, 5, activities.CreateActivityRequest{Type: activities.TypeNote, Body: "Prefers email"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.AuthorID != 3 || saved.ContactID != 5 {
+		t.Errorf("Expected author 3 on contact 5, got author %d on contact %d", saved.AuthorID, saved.ContactID)
+	}
+
+	if result.OccurredAt.Before(before) {
+		t.Errorf("Expected occurred_at to default to now, got %v", result.OccurredAt)
+	}
+}
+
+// TestCreateActivity_WithTimestamp tests logging an activity that happened earlier
+func TestCreateActivity_WithTimestamp(t *testing.T) {
+	occurred_at := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	service := activities.NewActivityService(&MockActivityRepository{})
+
+	result, err := service.CreateActivity(1, 0, 5, activities.CreateActivityRequest{
+		Type:       activities.TypeMeeting,
+		Body:       "Quarterly review",
+		OccurredAt: &occurred_at,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !result.OccurredAt.Equal(occurred_at) {
+		t.Errorf("Expected occurred_at %v, got %v", occurred_at, result.OccurredAt)
+	}
+}
+
+// ========== UpdateActivity Tests ==========
+
+// TestUpdateActivity_NotFound tests updating an activity of another contact
+func TestUpdateActivity_NotFound(t *testing.T) {
+	mockRepo := &MockActivityRepository{
+		FindActivityByIdFunc: func(id, contact_id uint) (*activities.Activity, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	_, err := service.UpdateActivity(1, 0, 5, 9, activities.UpdateActivityRequest{Body: "Updated"})
+
+	if err == nil || err.Error() != "activity not found" {
+		t.Errorf("Expected 'activity not found' error, got %v", err)
+	}
+}
+
+// ========== DeleteActivity Tests ==========
+
+// TestDeleteActivity_Success tests deleting an activity
+func TestDeleteActivity_Success(t *testing.T) {
+	deleted := false
+	mockRepo := &MockActivityRepository{
+		FindActivityByIdFunc: func(id, contact_id uint) (*activities.Activity, error) {
+			return &activities.Activity{ID: id, ContactID: contact_id}, nil
+		},
+		DeleteActivityFunc: func(id, contact_id uint) error {
+			deleted = id == 9 && contact_id == 5
+			return nil
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	if err := service.DeleteActivity(1, 0, 5, 9); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !deleted {
+		t.Error("Expected activity 9 of contact 5 to be deleted")
+	}
+}
+
+// ========== Activities Workspace Tests ==========
+
+// TestGetActivities_Workspace tests reading the timeline of a contact in the active workspace
+func TestGetActivities_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	var gotPermission string
+	mockRepo := &MockActivityRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			gotWorkspace, gotPermission = workspace_id, permission
+			return &contacts.Contact{ID: id}, nil
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	if _, err := service.GetActivities(1, 3, 5, 1, 10, ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 || gotPermission != contacts.PermissionView {
+		t.Errorf("Expected a view check in workspace 3, got %q in workspace %d", gotPermission, gotWorkspace)
+	}
+}
+
+// TestCreateActivity_WorkspaceViewer tests that viewers of a workspace cannot log activities
+func TestCreateActivity_WorkspaceViewer(t *testing.T) {
+	mockRepo := &MockActivityRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if workspace_id == 3 && permission == contacts.PermissionEdit {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &contacts.Contact{ID: id}, nil
+		},
+		CreateActivityFunc: func(activity *activities.Activity) error {
+			t.Fatalf("Expected no activity to be created")
+			return nil
+		},
+	}
+
+	service := activities.NewActivityService(mockRepo)
+
+	_, err := service.CreateActivity(1, 3, 5, activities.CreateActivityRequest{Type: activities.TypeNote, Body: "Hello"})
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// dedupeRepo returns a mock repository of contact 2 holding existing.
+func dedupeRepo(existing []addresses.Address) *MockAddressRepository {
+	return &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			for _, address := range existing {
+				if address.ID == address_id {
+					return &address, nil
+				}
+			}
+			return nil, errors.New("address not found")
+		},
+		GetContactAddressesFunc: func(contact_id uint) ([]addresses.Address, error) {
+			return existing, nil
+		},
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+			return &addresses.AddressResponse{ID: 9, Street: address.Street, City: address.City}, nil
+		},
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+			return &addresses.AddressResponse{ID: address_id, Street: address.Street}, nil
+		},
+	}
+}
+
+// ========== Address Dedupe Tests ==========
+
+// TestMatchKey tests that spelling variants of the same address match
+func TestMatchKey(t *testing.T) {
+	base := addresses.Address{Street: "Jl. Sudirman 1", City: "Jakarta", State: "DKI Jakarta", PostalCode: "10220", Country: "ID"}
+	same := []string{"Jalan Sudirman No. 1", "JL SUDIRMAN NO.1", "  jln.  Sudirman,  nomor 1 "}
+	for _, street := range same {
+		other := base
+		other.Street = street
+		if addresses.MatchKey(other) != addresses.MatchKey(base) {
+			t.Errorf("Expected %q to match %q: %q != %q", street, base.Street, addresses.MatchKey(other), addresses.MatchKey(base))
+		}
+	}
+
+	english := addresses.Address{Street: "1600 Pennsylvania Ave. NW", City: "Washington", State: "DC", Country: "US"}
+	spelled := english
+	spelled.Street = "1600 pennsylvania avenue northwest"
+	if addresses.MatchKey(english) != addresses.MatchKey(spelled) {
+		t.Errorf("Expected English abbreviations to be expanded")
+	}
+
+	different := base
+	different.Street = "Jl. Sudirman 10"
+	if addresses.MatchKey(different) == addresses.MatchKey(base) {
+		t.Errorf("Expected another house number not to match")
+	}
+}
+
+// TestParseDuplicatePolicy tests reading the ADDRESS_DUPLICATES setting
+func TestParseDuplicatePolicy(t *testing.T) {
+	tests := map[string]addresses.DuplicatePolicy{
+		"":        addresses.DuplicatesWarn,
+		"warn":    addresses.DuplicatesWarn,
+		" REJECT": addresses.DuplicatesReject,
+	}
+	for value, want := range tests {
+		got, err := addresses.ParseDuplicatePolicy(value)
+		if err != nil || got != want {
+			t.Errorf("%q: expected %q, got %q %v", value, want, got, err)
+		}
+	}
+
+	if _, err := addresses.ParseDuplicatePolicy("ignore"); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
+
+// TestCreateAddress_WarnsOnDuplicate tests saving a duplicate and reporting what it duplicates
+func TestCreateAddress_WarnsOnDuplicate(t *testing.T) {
+	mockRepo := dedupeRepo([]addresses.Address{
+		{ID: 4, ContactID: 2, Street: "Jalan Sudirman No. 1", City: "Jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeHome},
+		{ID: 5, ContactID: 2, Street: "Jalan Sudirman No. 1", City: "Jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeBilling},
+	})
+
+	service := addresses.NewAddressService(mockRepo, nil, addresses.DuplicatesWarn)
+
+	response, err := service.CreateAddress(1, 0, 2, addresses.CreateAddressRequest{Street: " Jl.  Sudirman 1 ", City: "jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeHome})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(response.DuplicateOf, []uint{4}) {
+		t.Errorf("Expected a duplicate of the home address only, got %v", response.DuplicateOf)
+	}
+	if response.Street != "Jl. Sudirman 1" {
+		t.Errorf("Expected the street to be trimmed, got %q", response.Street)
+	}
+}
+
+// TestCreateAddress_RejectsDuplicate tests refusing a duplicate when configured to
+func TestCreateAddress_RejectsDuplicate(t *testing.T) {
+	mockRepo := dedupeRepo([]addresses.Address{
+		{ID: 4, ContactID: 2, Street: "Jalan Sudirman No. 1", City: "Jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeOther},
+	})
+	mockRepo.CreateAddressFunc = func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+		t.Fatalf("Expected no address to be created")
+		return nil, nil
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, addresses.DuplicatesReject)
+
+	_, err := service.CreateAddress(1, 0, 2, addresses.CreateAddressRequest{Street: "Jl. Sudirman 1", City: "Jakarta", State: "DKI Jakarta", Country: "Indonesia"})
+
+	if !errors.Is(err, addresses.ErrDuplicateAddress) {
+		t.Errorf("Expected ErrDuplicateAddress, got %v", err)
+	}
+}
+
+// TestUpdateAddress_DuplicateIgnoresItself tests that an address does not duplicate itself
+func TestUpdateAddress_DuplicateIgnoresItself(t *testing.T) {
+	mockRepo := dedupeRepo([]addresses.Address{
+		{ID: 4, ContactID: 2, Street: "Jalan Sudirman No. 1", City: "Jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeOther},
+		{ID: 6, ContactID: 2, Street: "Jalan Thamrin No. 3", City: "Jakarta", State: "DKI Jakarta", Country: "ID", Type: addresses.TypeOther},
+	})
+
+	service := addresses.NewAddressService(mockRepo, nil, addresses.DuplicatesReject)
+
+	if _, err := service.UpdateAddress(1, 0, 2, 4, addresses.UpdateAddressRequest{Street: "Jl. Sudirman No.1"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	_, err := service.UpdateAddress(1, 0, 2, 6, addresses.UpdateAddressRequest{Street: "Jl. Sudirman 1"})
+	if !errors.Is(err, addresses.ErrDuplicateAddress) {
+		t.Errorf("Expected ErrDuplicateAddress, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// ========== Address Format Tests ==========
+
+// TestFormatLines_Countries tests the field order and case conventions of several countries
+func TestFormatLines_Countries(t *testing.T) {
+	tests := []struct {
+		address addresses.Address
+		want    string
+	}{
+		{
+			addresses.Address{Street: "1600 Pennsylvania Ave NW", City: "Washington", State: "DC", PostalCode: "20500", Country: "US"},
+			"1600 Pennsylvania Ave NW|WASHINGTON, DC 20500|UNITED STATES",
+		},
+		{
+			addresses.Address{Street: "Unter den Linden 77", City: "Berlin", PostalCode: "10117", Country: "DE"},
+			"Unter den Linden 77|10117 Berlin|GERMANY",
+		},
+		{
+			addresses.Address{Street: "10 Downing Street", City: "London", PostalCode: "SW1A 2AA", Country: "GB"},
+			"10 Downing Street|LONDON|SW1A 2AA|UNITED KINGDOM",
+		},
+		{
+			addresses.Address{Street: "Jl. Asia Afrika No. 8", City: "Bandung", State: "Jawa Barat", PostalCode: "40111", Country: "ID"},
+			"Jl. Asia Afrika No. 8|Bandung|Jawa Barat 40111|INDONESIA",
+		},
+		{
+			addresses.Address{Street: "5 Rue de Rivoli", City: "Paris", PostalCode: "75001", Country: "FR"},
+			"5 Rue de Rivoli|75001 PARIS|FRANCE",
+		},
+	}
+
+	for _, tt := range tests {
+		got := strings.Join(addresses.FormatLines(tt.address, addresses.Recipient{}, ""), "|")
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.address.Country, tt.want, got)
+		}
+	}
+}
+
+// TestFormatLines_MissingFields tests that empty fields take their separators along
+func TestFormatLines_MissingFields(t *testing.T) {
+	address := addresses.Address{City: "Austin", PostalCode: "78701", Country: "US"}
+
+	got := strings.Join(addresses.FormatLines(address, addresses.Recipient{}, "US"), "|")
+
+	if got != "AUSTIN 78701" {
+		t.Errorf("Expected %q, got %q", "AUSTIN 78701", got)
+	}
+}
+
+// TestFormatLines_Recipient tests the recipient lines and leaving out the sender's country
+func TestFormatLines_Recipient(t *testing.T) {
+	address := addresses.Address{Street: "Jl. Sudirman 1", City: "Jakarta", PostalCode: "10220", Country: "ID"}
+	recipient := addresses.Recipient{Name: "Budi Santoso", Organization: "PT Maju"}
+
+	got := strings.Join(addresses.FormatLines(address, recipient, "ID"), "|")
+
+	if got != "Budi Santoso|PT Maju|Jl. Sudirman 1|Jakarta|10220" {
+		t.Errorf("Unexpected label %q", got)
+	}
+}
+
+// TestFormatLines_LegacyCountry tests an address whose country is not a code
+func TestFormatLines_LegacyCountry(t *testing.T) {
+	address := addresses.Address{Street: "Main St 1", City: "Springfield", Country: "Freedonia"}
+
+	got := strings.Join(addresses.FormatLines(address, addresses.Recipient{}, ""), "|")
+
+	if got != "Main St 1|Springfield|FREEDONIA" {
+		t.Errorf("Unexpected label %q", got)
+	}
+}
+
+// ========== Label Tests ==========
+
+// TestGetLabel_Success tests addressing a label to the contact and company
+func TestGetLabel_Success(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		FindAddressByIdFunc: func(address_id, user_id, workspace_id uint, permission string) (*addresses.Address, error) {
+			return &addresses.Address{
+				ID:         address_id,
+				ContactID:  1,
+				Contact:    contacts.Contact{ID: 1, FirstName: "Jane", LastName: "Doe", Company: &companies.Company{Name: "Acme"}},
+				Street:     "1 Infinite Loop",
+				City:       "Cupertino",
+				State:      "CA",
+				PostalCode: "95014",
+				Country:    "US",
+			}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	label, err := service.GetLabel(1, 0, 3, "usa")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got := strings.Join(label.Lines, "|")
+	if label.AddressID != 3 || got != "Jane Doe|Acme|1 Infinite Loop|CUPERTINO, CA 95014" {
+		t.Errorf("Unexpected label %d %q", label.AddressID, got)
+	}
+}
+
+// TestGetLabel_InvalidFrom tests rejecting an unknown sender country
+func TestGetLabel_InvalidFrom(t *testing.T) {
+	service := addresses.NewAddressService(&MockAddressRepository{}, nil, "")
+
+	_, err := service.GetLabel(1, 0, 3, "Atlantis")
+
+	if !errors.Is(err, country.ErrUnknownCountry) {
+		t.Errorf("Expected ErrUnknownCountry, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+// ========== GeoJSON Export Tests ==========
+
+// TestExportGeoJSON_Batches tests writing every batch into one FeatureCollection
+func TestExportGeoJSON_Batches(t *testing.T) {
+	contact := contacts.Contact{ID: 2, FirstName: "Jane", LastName: "Doe"}
+	var gotSearch string
+	mockRepo := &MockAddressRepository{
+		EachLocatedAddressBatchFunc: func(user_id, workspace_id uint, filter addresses.AddressFilter, fn func(address_list []addresses.Address) error) error {
+			gotSearch = filter.Search
+			if err := fn([]addresses.Address{{ID: 1, ContactID: 2, Contact: contact, City: "Bandung", Country: "ID", Type: addresses.TypeHome, Latitude: float(-6.9175), Longitude: float(107.6191)}}); err != nil {
+				return err
+			}
+			return fn([]addresses.Address{{ID: 5, ContactID: 2, Contact: contact, City: "Jakarta", Country: "ID", Type: addresses.TypeWork, Latitude: float(-6.2088), Longitude: float(106.8456)}})
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	var buf bytes.Buffer
+	err := service.ExportGeoJSON(1, 0, addresses.AddressQuery{Search: "ban"}, &buf)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotSearch != "ban" {
+		t.Errorf("Expected the search to reach the repository, got %q", gotSearch)
+	}
+
+	var collection struct {
+		Type     string              `json:"type"`
+		Features []addresses.Feature `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, buf.String())
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("Unexpected collection %s", buf.String())
+	}
+
+	feature := collection.Features[0]
+	if feature.Geometry.Type != "Point" || feature.Geometry.Coordinates != [2]float64{107.6191, -6.9175} {
+		t.Errorf("Expected a point with the longitude first, got %+v", feature.Geometry)
+	}
+	if feature.Properties.ContactName != "Jane Doe" || feature.Properties.CountryName != "Indonesia" || feature.Properties.Type != addresses.TypeHome {
+		t.Errorf("Unexpected properties %+v", feature.Properties)
+	}
+	if collection.Features[1].ID != 5 {
+		t.Errorf("Expected the second batch, got %+v", collection.Features[1])
+	}
+}
+
+// TestExportGeoJSON_Empty tests that no located address gives an empty collection
+func TestExportGeoJSON_Empty(t *testing.T) {
+	service := addresses.NewAddressService(&MockAddressRepository{}, nil, "")
+
+	var buf bytes.Buffer
+	err := service.ExportGeoJSON(1, 0, addresses.AddressQuery{}, &buf)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != "{\"type\":\"FeatureCollection\",\"features\":[]}\n" {
+		t.Errorf("Unexpected output %q", buf.String())
+	}
+}
+
+// TestExportGeoJSON_ContactNotFound tests failing before anything is written
+func TestExportGeoJSON_ContactNotFound(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		EachLocatedAddressBatchFunc: func(user_id, workspace_id uint, filter addresses.AddressFilter, fn func(address_list []addresses.Address) error) error {
+			t.Fatalf("Expected no export")
+			return nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	var buf bytes.Buffer
+	err := service.ExportGeoJSON(1, 0, addresses.AddressQuery{ContactID: 9}, &buf)
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected contact not found, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", buf.String())
+	}
+}
+
+// TestExportGeoJSON_RepositoryError tests passing on a failure while streaming
+func TestExportGeoJSON_RepositoryError(t *testing.T) {
+	failure := errors.New("connection lost")
+	mockRepo := &MockAddressRepository{
+		EachLocatedAddressBatchFunc: func(user_id, workspace_id uint, filter addresses.AddressFilter, fn func(address_list []addresses.Address) error) error {
+			return failure
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	if err := service.ExportGeoJSON(1, 0, addresses.AddressQuery{}, &bytes.Buffer{}); !errors.Is(err, failure) {
+		t.Errorf("Expected the repository error, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+// ========== Address List Tests ==========
+
+// TestListAddresses_NormalizesFilter tests that filters are compared the way addresses are stored
+func TestListAddresses_NormalizesFilter(t *testing.T) {
+	var got addresses.AddressFilter
+	mockRepo := &MockAddressRepository{
+		ListAddressesFunc: func(user_id, workspace_id uint, page, limit int, filter addresses.AddressFilter) (*addresses.GetAddressesResponse, error) {
+			got = filter
+			return &addresses.GetAddressesResponse{Page: page, Limit: limit}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.ListAddresses(1, 0, 1, 10, addresses.AddressQuery{
+		City:       " Cupertino ",
+		State:      "California",
+		Country:    "usa",
+		PostalCode: "950",
+		Type:       "Work",
+		Sort:       addresses.SortContact,
+		Order:      "DESC",
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := addresses.AddressFilter{
+		City:        "Cupertino",
+		State:       "CA",
+		Country:     "US",
+		PostalCode:  "950",
+		Type:        addresses.TypeWork,
+		SortColumns: []string{"contacts.first_name", "contacts.last_name"},
+		Order:       "desc",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+// TestListAddresses_Pagination tests the default and maximum page size
+func TestListAddresses_Pagination(t *testing.T) {
+	service := addresses.NewAddressService(&MockAddressRepository{}, nil, "")
+
+	response, err := service.ListAddresses(1, 0, 0, 0, addresses.AddressQuery{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Page != 1 || response.Limit != 10 {
+		t.Errorf("Expected page 1 of 10, got page %d of %d", response.Page, response.Limit)
+	}
+
+	response, err = service.ListAddresses(1, 0, 2, 1000, addresses.AddressQuery{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Limit != addresses.MaxLimit {
+		t.Errorf("Expected limit %d, got %d", addresses.MaxLimit, response.Limit)
+	}
+}
+
+// TestListAddresses_InvalidQuery tests rejecting unknown types, sorts and countries
+func TestListAddresses_InvalidQuery(t *testing.T) {
+	service := addresses.NewAddressService(&MockAddressRepository{
+		ListAddressesFunc: func(user_id, workspace_id uint, page, limit int, filter addresses.AddressFilter) (*addresses.GetAddressesResponse, error) {
+			t.Fatalf("Expected no query")
+			return nil, nil
+		},
+	}, nil, "")
+
+	tests := []struct {
+		query addresses.AddressQuery
+		want  error
+	}{
+		{addresses.AddressQuery{Type: "vacation"}, addresses.ErrInvalidType},
+		{addresses.AddressQuery{Sort: "street"}, addresses.ErrInvalidSort},
+		{addresses.AddressQuery{Order: "up"}, addresses.ErrInvalidSort},
+		{addresses.AddressQuery{Country: "Atlantis"}, country.ErrUnknownCountry},
+		{addresses.AddressQuery{Country: "US", State: "Narnia"}, country.ErrUnknownSubdivision},
+	}
+	for _, tt := range tests {
+		if _, err := service.ListAddresses(1, 0, 1, 10, tt.query); !errors.Is(err, tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.query, tt.want, err)
+		}
+	}
+}
+
+// TestListAddresses_ContactNotFound tests that a contact_id filter is checked for access
+func TestListAddresses_ContactNotFound(t *testing.T) {
+	var gotPermission string
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			gotPermission = permission
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.ListAddresses(1, 0, 1, 10, addresses.AddressQuery{ContactID: 9})
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected contact not found, got %v", err)
+	}
+	if gotPermission != contacts.PermissionView {
+		t.Errorf("Expected a view check, got %q", gotPermission)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 )
 
 // ========== Addresses Repository Integration Tests ==========
@@ -33,7 +34,7 @@ func TestAddressRepository_CreateAddress_Integration(t *testing.T) {
 		Country:    "USA",
 	}
 
-	response, err := repo.CreateAddress(request)
+	response, err := repo.CreateAddress(user1.ID, request)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -63,7 +64,7 @@ func TestAddressRepository_CreateAddress_ForeignKeyConstraint_Integration(t *tes
 		Country:   "USA",
 	}
 
-	_, err := repo.CreateAddress(request)
+	_, err := repo.CreateAddress(999, request)
 
 	// Should fail due to foreign key constraint
 	if err == nil {
@@ -211,7 +212,7 @@ func TestAddressRepository_FindAddressById_Integration(t *testing.T) {
 	db.Create(&createdAddress)
 
 	// Find address
-	address, err := repo.FindAddressById(createdAddress.ID)
+	address, err := repo.FindAddressById(createdAddress.ID, user1.ID, workspaces.Personal, contacts.PermissionView)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -250,7 +251,7 @@ func TestAddressRepository_FindAddressById_WrongUser_Integration(t *testing.T) {
 	db.Create(&createdAddress)
 
 	// Try to find with user2's ID (should fail - authorization check)
-	_, err := repo.FindAddressById(createdAddress.ID)
+	_, err := repo.FindAddressById(createdAddress.ID, user2.ID, workspaces.Personal, contacts.PermissionView)
 
 	if err == nil {
 		t.Error("BUG FOUND: Should not find address belonging to different user (authorization breach!)")
@@ -285,7 +286,7 @@ func TestAddressRepository_UpdateAddress_Integration(t *testing.T) {
 		City:   "Boston",
 	}
 
-	_, err := repo.UpdateAddress(createdAddress.ID, updateAddress)
+	_, err := repo.UpdateAddress(user1.ID, createdAddress.ID, updateAddress)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -328,7 +329,7 @@ func TestAddressRepository_UpdateAddress_PartialUpdate_Integration(t *testing.T)
 		City: "Boston",
 	}
 
-	_, err := repo.UpdateAddress(createdAddress.ID, updateAddress)
+	_, err := repo.UpdateAddress(user1.ID, createdAddress.ID, updateAddress)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -369,7 +370,7 @@ func TestAddressRepository_DeleteAddress_Integration(t *testing.T) {
 	db.Create(&createdAddress)
 
 	// Delete address
-	err := repo.DeleteAddress(createdAddress.ID)
+	err := repo.DeleteAddress(user1.ID, createdAddress.ID)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -407,7 +408,7 @@ func TestAddressRepository_DeleteAddress_WrongUser_Integration(t *testing.T) {
 	db.Create(&createdAddress)
 
 	// Try to delete with user2's ID
-	err := repo.DeleteAddress(createdAddress.ID)
+	err := repo.DeleteAddress(user2.ID, createdAddress.ID)
 
 	// Should succeed (no error) but address should NOT be deleted
 	if err != nil {
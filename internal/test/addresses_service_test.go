@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -13,7 +14,7 @@ import (
 
 func TestCreateAddress_Success(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID:        id,
 				UserID:    user_id,
@@ -21,7 +22,7 @@ func TestCreateAddress_Success(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		CreateAddressFunc: func(address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
 			return &addresses.AddressResponse{
 				ID:         1,
 				ContactID:  address.ContactID,
@@ -34,7 +35,7 @@ func TestCreateAddress_Success(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.CreateAddressRequest{
 		ContactID:  1,
@@ -45,7 +46,7 @@ func TestCreateAddress_Success(t *testing.T) {
 		Country:    "USA",
 	}
 
-	response, err := service.CreateAddress(1, request)
+	response, err := service.CreateAddress(1, 0, request.ContactID, request)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -58,19 +59,19 @@ func TestCreateAddress_Success(t *testing.T) {
 
 func TestCreateAddress_ContactNotFound(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.CreateAddressRequest{
 		ContactID: 999,
 		Country:   "USA",
 	}
 
-	_, err := service.CreateAddress(1, request)
+	_, err := service.CreateAddress(1, 0, request.ContactID, request)
 
 	if err == nil {
 		t.Error("Expected error for non-existent contact, got nil")
@@ -79,26 +80,55 @@ func TestCreateAddress_ContactNotFound(t *testing.T) {
 	if err.Error() != "contact not found" {
 		t.Errorf("Expected 'contact not found' error, got '%s'", err.Error())
 	}
+
+	if !errors.Is(err, addresses.ErrContactNotFound) {
+		t.Errorf("Expected ErrContactNotFound, got %v", err)
+	}
+}
+
+func TestCreateAddress_UsesPathContact(t *testing.T) {
+	var created uint
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+			created = address.ContactID
+			return &addresses.AddressResponse{ID: 1, ContactID: address.ContactID}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.CreateAddress(1, 0, 7, addresses.CreateAddressRequest{ContactID: 3, State: "NY", Country: "USA"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created != 7 {
+		t.Errorf("Expected contact 7, got %d", created)
+	}
 }
 
 func TestCreateAddress_DatabaseError(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return &contacts.Contact{ID: id, UserID: user_id}, nil
 		},
-		CreateAddressFunc: func(address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.CreateAddressRequest{
 		ContactID: 1,
 		Country:   "USA",
 	}
 
-	_, err := service.CreateAddress(1, request)
+	_, err := service.CreateAddress(1, 0, request.ContactID, request)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -120,9 +150,9 @@ func TestGetAddresses_Success(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	result, err := service.GetAddresses(1, 1, 1, 10, "")
+	result, err := service.GetAddresses(1, 0, 1, 1, 10, "")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -159,9 +189,9 @@ func TestGetAddresses_WithSearch(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	result, err := service.GetAddresses(1, 1, 1, 10, "New York")
+	result, err := service.GetAddresses(1, 0, 1, 1, 10, "New York")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -185,9 +215,9 @@ func TestGetAddresses_EmptyResult(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	result, err := service.GetAddresses(1, 1, 1, 10, "")
+	result, err := service.GetAddresses(1, 0, 1, 1, 10, "")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -205,9 +235,9 @@ func TestGetAddresses_DatabaseError(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	_, err := service.GetAddresses(1, 1, 1, 10, "")
+	_, err := service.GetAddresses(1, 0, 1, 1, 10, "")
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -218,7 +248,7 @@ func TestGetAddresses_DatabaseError(t *testing.T) {
 
 func TestFindAddressById_Success(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID:  1,
 				Street:     "123 Main St",
@@ -230,9 +260,9 @@ func TestFindAddressById_Success(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	address, err := service.FindAddressById(1, 1)
+	address, err := service.FindAddressById(1, 0, 1, 1)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -249,14 +279,14 @@ func TestFindAddressById_Success(t *testing.T) {
 
 func TestFindAddressById_NotFound(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	_, err := service.FindAddressById(1, 999)
+	_, err := service.FindAddressById(1, 0, 1, 999)
 
 	if err == nil {
 		t.Error("Expected error for non-existent address, got nil")
@@ -267,16 +297,35 @@ func TestFindAddressById_NotFound(t *testing.T) {
 	}
 }
 
+func TestFindAddressById_AddressNotFound(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.FindAddressById(1, 0, 1, 999)
+
+	if !errors.Is(err, addresses.ErrAddressNotFound) {
+		t.Errorf("Expected ErrAddressNotFound, got %v", err)
+	}
+}
+
 func TestFindAddressById_WrongUser(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	_, err := service.FindAddressById(999, 1)
+	_, err := service.FindAddressById(999, 0, 1, 1)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -285,14 +334,14 @@ func TestFindAddressById_WrongUser(t *testing.T) {
 
 func TestFindAddressById_DatabaseError(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	_, err := service.FindAddressById(1, 1)
+	_, err := service.FindAddressById(1, 0, 1, 1)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -307,7 +356,7 @@ func TestFindAddressById_DatabaseError(t *testing.T) {
 
 func TestUpdateAddress_Success(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID:  1,
 				Street:     "123 Main St",
@@ -317,7 +366,7 @@ func TestUpdateAddress_Success(t *testing.T) {
 				Country:    "USA",
 			}, nil
 		},
-		UpdateAddressFunc: func(address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
 			return &addresses.AddressResponse{
 				ID:         address_id,
 				ContactID:  address.ContactID,
@@ -330,14 +379,14 @@ func TestUpdateAddress_Success(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.UpdateAddressRequest{
 		Street: "456 Updated St",
 		City:   "Boston",
 	}
 
-	response, err := service.UpdateAddress(1, 1, request)
+	response, err := service.UpdateAddress(1, 0, 1, 1, request)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -350,15 +399,16 @@ func TestUpdateAddress_Success(t *testing.T) {
 
 func TestUpdateAddress_PartialUpdate(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID: 1,
 				Street:    "123 Main St",
 				City:      "New York",
+				State:     "NY",
 				Country:   "USA",
 			}, nil
 		},
-		UpdateAddressFunc: func(address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
 			return &addresses.AddressResponse{
 				ID:      address_id,
 				Street:  address.Street,
@@ -368,13 +418,13 @@ func TestUpdateAddress_PartialUpdate(t *testing.T) {
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.UpdateAddressRequest{
 		City: "Boston",
 	}
 
-	response, err := service.UpdateAddress(1, 1, request)
+	response, err := service.UpdateAddress(1, 0, 1, 1, request)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -387,18 +437,18 @@ func TestUpdateAddress_PartialUpdate(t *testing.T) {
 
 func TestUpdateAddress_NotFound(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.UpdateAddressRequest{
 		City: "Boston",
 	}
 
-	_, err := service.UpdateAddress(1, 999, request)
+	_, err := service.UpdateAddress(1, 0, 1, 999, request)
 
 	if err == nil {
 		t.Error("Expected error for non-existent address, got nil")
@@ -407,18 +457,18 @@ func TestUpdateAddress_NotFound(t *testing.T) {
 
 func TestUpdateAddress_WrongUser(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.UpdateAddressRequest{
 		City: "Boston",
 	}
 
-	_, err := service.UpdateAddress(999, 1, request)
+	_, err := service.UpdateAddress(999, 0, 1, 1, request)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -427,48 +477,98 @@ func TestUpdateAddress_WrongUser(t *testing.T) {
 
 func TestUpdateAddress_DatabaseError(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID: 1,
 				City:      "New York",
 			}, nil
 		},
-		UpdateAddressFunc: func(address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
 	request := addresses.UpdateAddressRequest{
 		City: "Boston",
 	}
 
-	_, err := service.UpdateAddress(1, 1, request)
+	_, err := service.UpdateAddress(1, 0, 1, 1, request)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
 	}
 }
 
+func TestFindAddressById_OtherContact(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			if contact_id != 1 {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &addresses.Address{ID: address_id, ContactID: 1}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.FindAddressById(1, 0, 2, 1)
+
+	if err == nil || err.Error() != "address not found" {
+		t.Errorf("Expected 'address not found', got %v", err)
+	}
+}
+
+func TestUpdateAddress_ChecksContactOnce(t *testing.T) {
+	var permissions []string
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			permissions = append(permissions, permission)
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			return &addresses.Address{ID: address_id, ContactID: contact_id, State: "NY", Country: "USA"}, nil
+		},
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+			return &addresses.AddressResponse{ID: address_id, ContactID: address.ContactID, City: address.City}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.UpdateAddress(1, 0, 1, 1, addresses.UpdateAddressRequest{City: "Boston"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(permissions) != 1 || permissions[0] != contacts.PermissionEdit {
+		t.Errorf("Expected one edit check, got %v", permissions)
+	}
+}
+
 // ========== DeleteAddress Tests ==========
 
 func TestDeleteAddress_Success(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID: 1,
 				City:      "New York",
 			}, nil
 		},
-		DeleteAddressFunc: func(address_id uint) error {
+		DeleteAddressFunc: func(user_id, address_id uint) error {
 			return nil
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	err := service.DeleteAddress(1, 1)
+	err := service.DeleteAddress(1, 0, 1, 1)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -477,14 +577,14 @@ func TestDeleteAddress_Success(t *testing.T) {
 
 func TestDeleteAddress_NotFound(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	err := service.DeleteAddress(1, 999)
+	err := service.DeleteAddress(1, 0, 1, 999)
 
 	if err == nil {
 		t.Error("Expected error for non-existent address, got nil")
@@ -493,14 +593,14 @@ func TestDeleteAddress_NotFound(t *testing.T) {
 
 func TestDeleteAddress_WrongUser(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	err := service.DeleteAddress(999, 1)
+	err := service.DeleteAddress(999, 0, 1, 1)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -509,21 +609,133 @@ func TestDeleteAddress_WrongUser(t *testing.T) {
 
 func TestDeleteAddress_DatabaseError(t *testing.T) {
 	mockRepo := &MockAddressRepository{
-		FindAddressByIdFunc: func(address_id uint) (*addresses.Address, error) {
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
 			return &addresses.Address{
 				ContactID: 1,
 			}, nil
 		},
-		DeleteAddressFunc: func(address_id uint) error {
+		DeleteAddressFunc: func(user_id, address_id uint) error {
 			return errors.New("database connection error")
 		},
 	}
 
-	service := addresses.NewAddressService(mockRepo)
+	service := addresses.NewAddressService(mockRepo, nil, "")
 
-	err := service.DeleteAddress(1, 1)
+	err := service.DeleteAddress(1, 0, 1, 1)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
 	}
 }
+
+// ========== IncludeAddresses Tests ==========
+
+func TestIncludeAddresses_EmbedsInContact(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		GetContactAddressesFunc: func(contact_id uint) ([]addresses.Address, error) {
+			return []addresses.Address{{ID: 1, ContactID: contact_id, City: "Bandung", Country: "ID"}}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+	contact := &contacts.Contact{ID: 5, FirstName: "Budi"}
+
+	included, err := service.IncludeAddresses(contact)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := json.Marshal(contacts.ContactDetail{Contact: contact, Included: map[string]any{"addresses": included}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var decoded struct {
+		ID        uint                `json:"id"`
+		FirstName string              `json:"first_name"`
+		Addresses []addresses.Address `json:"addresses"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	if decoded.ID != 5 || decoded.FirstName != "Budi" || len(decoded.Addresses) != 1 || decoded.Addresses[0].City != "Bandung" {
+		t.Errorf("Unexpected contact %s", data)
+	}
+}
+
+// ========== Address Type Tests ==========
+
+func TestCreateAddress_DefaultsToOther(t *testing.T) {
+	var created addresses.CreateAddressRequest
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+			created = address
+			return &addresses.AddressResponse{ID: 1, ContactID: address.ContactID, Type: address.Type, IsPrimary: address.IsPrimary}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	response, err := service.CreateAddress(1, 0, 1, addresses.CreateAddressRequest{Country: "ID", IsPrimary: true})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created.Type != addresses.TypeOther || !response.IsPrimary {
+		t.Errorf("Expected a primary 'other' address, got %+v", response)
+	}
+}
+
+func TestUpdateAddress_ChangesTypeAndPrimary(t *testing.T) {
+	var saved *addresses.Address
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			return &addresses.Address{ID: address_id, ContactID: contact_id, Country: "ID", Type: addresses.TypeHome, IsPrimary: true}, nil
+		},
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+			saved = address
+			return &addresses.AddressResponse{ID: address_id, Type: address.Type, IsPrimary: address.IsPrimary}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.UpdateAddress(1, 0, 1, 1, addresses.UpdateAddressRequest{Type: addresses.TypeBilling})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.Type != addresses.TypeBilling || !saved.IsPrimary {
+		t.Errorf("Expected a primary billing address, got %+v", saved)
+	}
+
+	not_primary := false
+	_, err = service.UpdateAddress(1, 0, 1, 1, addresses.UpdateAddressRequest{IsPrimary: &not_primary})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.IsPrimary || saved.Type != addresses.TypeHome {
+		t.Errorf("Expected a plain home address, got %+v", saved)
+	}
+}
+
+func TestValidType(t *testing.T) {
+	for _, address_type := range addresses.Types {
+		if !addresses.ValidType(address_type) {
+			t.Errorf("Expected %q to be valid", address_type)
+		}
+	}
+
+	if addresses.ValidType("office") || addresses.ValidType("") {
+		t.Error("Expected unknown types to be invalid")
+	}
+}
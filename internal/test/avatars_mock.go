@@ -0,0 +1,83 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/avatars"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+// MockAvatarRepository implements avatars.AvatarRepository interface. Without
+// funcs it keeps avatars in the Avatars map keyed by contact or user id.
+type MockAvatarRepository struct {
+	FindContactFunc        func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	FindAvatarFunc         func(owner avatars.Owner) (*avatars.Avatar, error)
+	FindContactAvatarsFunc func(contact_ids []uint) ([]avatars.Avatar, error)
+	SaveAvatarFunc         func(avatar *avatars.Avatar) error
+	DeleteAvatarFunc       func(id uint) error
+	Avatars                map[uint]avatars.Avatar
+}
+
+// FindContact implements avatars.AvatarRepository
+func (m *MockAvatarRepository) FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	if m.FindContactFunc != nil {
+		return m.FindContactFunc(id, user_id, workspace_id, permission)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// FindAvatar implements avatars.AvatarRepository
+func (m *MockAvatarRepository) FindAvatar(owner avatars.Owner) (*avatars.Avatar, error) {
+	if m.FindAvatarFunc != nil {
+		return m.FindAvatarFunc(owner)
+	}
+	avatar, ok := m.Avatars[ownerId(owner)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &avatar, nil
+}
+
+// FindContactAvatars implements avatars.AvatarRepository
+func (m *MockAvatarRepository) FindContactAvatars(contact_ids []uint) ([]avatars.Avatar, error) {
+	if m.FindContactAvatarsFunc != nil {
+		return m.FindContactAvatarsFunc(contact_ids)
+	}
+	var avatar_list []avatars.Avatar
+	for _, contact_id := range contact_ids {
+		if avatar, ok := m.Avatars[contact_id]; ok {
+			avatar_list = append(avatar_list, avatar)
+		}
+	}
+	return avatar_list, nil
+}
+
+// SaveAvatar implements avatars.AvatarRepository
+func (m *MockAvatarRepository) SaveAvatar(avatar *avatars.Avatar) error {
+	if m.SaveAvatarFunc != nil {
+		return m.SaveAvatarFunc(avatar)
+	}
+	if m.Avatars == nil {
+		m.Avatars = make(map[uint]avatars.Avatar)
+	}
+	id := ownerId(avatars.Owner{ContactID: avatar.ContactID, UserID: avatar.UserID})
+	avatar.ID = id
+	m.Avatars[id] = *avatar
+	return nil
+}
+
+// DeleteAvatar implements avatars.AvatarRepository
+func (m *MockAvatarRepository) DeleteAvatar(id uint) error {
+	if m.DeleteAvatarFunc != nil {
+		return m.DeleteAvatarFunc(id)
+	}
+	delete(m.Avatars, id)
+	return nil
+}
+
+// ownerId maps contacts and users to distinct keys of the Avatars map.
+func ownerId(owner avatars.Owner) uint {
+	if owner.ContactID != nil {
+		return *owner.ContactID
+	}
+	return 100000 + *owner.UserID
+}
@@ -0,0 +1,310 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/avatars"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/blob"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	return img
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(width, height)); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// ========== Image Tests ==========
+
+// TestDecode_SniffsContentType tests that the type comes from the data
+func TestDecode_SniffsContentType(t *testing.T) {
+	_, content_type, err := avatars.Decode(testJPEG(t, 40, 20))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if content_type != "image/jpeg" {
+		t.Errorf("Expected image/jpeg, got %s", content_type)
+	}
+}
+
+// TestDecode_RejectsOtherTypes tests rejecting data that is not an image
+func TestDecode_RejectsOtherTypes(t *testing.T) {
+	_, _, err := avatars.Decode([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+
+	if !errors.Is(err, avatars.ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+// TestDecode_RejectsLargeFiles tests the upload size limit
+func TestDecode_RejectsLargeFiles(t *testing.T) {
+	_, _, err := avatars.Decode(make([]byte, avatars.MaxUploadSize+1))
+
+	if !errors.Is(err, avatars.ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
+
+// TestDecode_RejectsTruncatedImage tests an image whose header is valid but data is cut off
+func TestDecode_RejectsTruncatedImage(t *testing.T) {
+	data := testPNG(t, 50, 50)
+
+	_, _, err := avatars.Decode(data[:len(data)/2])
+
+	if !errors.Is(err, avatars.ErrInvalidImage) {
+		t.Errorf("Expected ErrInvalidImage, got %v", err)
+	}
+}
+
+// TestThumbnail_CropsToSquare tests cropping and downscaling
+func TestThumbnail_CropsToSquare(t *testing.T) {
+	thumb := avatars.Thumbnail(testImage(300, 200), 100)
+
+	if thumb.Bounds().Dx() != 100 || thumb.Bounds().Dy() != 100 {
+		t.Errorf("Expected 100x100, got %v", thumb.Bounds())
+	}
+
+	small := avatars.Thumbnail(testImage(30, 60), 100)
+	if small.Bounds().Dx() != 30 || small.Bounds().Dy() != 30 {
+		t.Errorf("Expected small images not to be enlarged, got %v", small.Bounds())
+	}
+}
+
+// ========== Avatar Service Tests ==========
+
+// TestUploadContactAvatar_StoresSizes tests storing the original and the thumbnails
+func TestUploadContactAvatar_StoresSizes(t *testing.T) {
+	store := blob.NewMemoryStore()
+	service := avatars.NewAvatarService(&MockAvatarRepository{}, store)
+
+	avatar, err := service.UploadContactAvatar(1, 1, 0, testPNG(t, 800, 600))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if avatar.Width != 800 || avatar.Height != 600 || avatar.ContentType != "image/png" {
+		t.Errorf("Unexpected avatar %+v", avatar)
+	}
+
+	if store.Len() != 3 {
+		t.Errorf("Expected 3 blobs, got %d", store.Len())
+	}
+
+	thumb, err := service.GetContactAvatar(1, 1, 0, avatars.SizeThumb)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(thumb.Data))
+	if err != nil {
+		t.Fatalf("Failed to decode thumbnail: %v", err)
+	}
+
+	if img.Bounds().Dx() != 128 || img.Bounds().Dy() != 128 {
+		t.Errorf("Expected 128x128 thumbnail, got %v", img.Bounds())
+	}
+}
+
+// TestUploadContactAvatar_ReplacesPrevious tests that old blobs are removed on re-upload
+func TestUploadContactAvatar_ReplacesPrevious(t *testing.T) {
+	store := blob.NewMemoryStore()
+	service := avatars.NewAvatarService(&MockAvatarRepository{}, store)
+
+	first, err := service.UploadContactAvatar(1, 1, 0, testPNG(t, 64, 64))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = service.UploadContactAvatar(1, 1, 0, testJPEG(t, 64, 64))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if store.Len() != 3 {
+		t.Errorf("Expected only the new 3 blobs, got %d", store.Len())
+	}
+
+	if _, err := store.Get(first.BlobKey(avatars.SizeOriginal)); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("Expected old original to be deleted, got %v", err)
+	}
+}
+
+// TestUploadContactAvatar_ViewOnly tests that a view-only share cannot change the avatar
+func TestUploadContactAvatar_ViewOnly(t *testing.T) {
+	mockRepo := &MockAvatarRepository{
+		FindContactFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if permission == contacts.PermissionEdit {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &contacts.Contact{ID: id}, nil
+		},
+	}
+	store := blob.NewMemoryStore()
+	service := avatars.NewAvatarService(mockRepo, store)
+
+	_, err := service.UploadContactAvatar(1, 1, 0, testPNG(t, 64, 64))
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+
+	if store.Len() != 0 {
+		t.Errorf("Expected nothing stored, got %d blobs", store.Len())
+	}
+}
+
+// TestUploadContactAvatar_SaveFails tests that stored blobs are cleaned up when saving fails
+func TestUploadContactAvatar_SaveFails(t *testing.T) {
+	mockRepo := &MockAvatarRepository{
+		SaveAvatarFunc: func(avatar *avatars.Avatar) error {
+			return errors.New("database error")
+		},
+	}
+	store := blob.NewMemoryStore()
+	service := avatars.NewAvatarService(mockRepo, store)
+
+	_, err := service.UploadContactAvatar(1, 1, 0, testPNG(t, 64, 64))
+
+	if err == nil {
+		t.Fatal("Expected database error, got nil")
+	}
+
+	if store.Len() != 0 {
+		t.Errorf("Expected blobs to be cleaned up, got %d", store.Len())
+	}
+}
+
+// TestGetUserAvatar_InvalidSize tests rejecting an unknown size
+func TestGetUserAvatar_InvalidSize(t *testing.T) {
+	service := avatars.NewAvatarService(&MockAvatarRepository{}, blob.NewMemoryStore())
+
+	_, err := service.GetUserAvatar(1, "huge")
+
+	if !errors.Is(err, avatars.ErrInvalidSize) {
+		t.Errorf("Expected ErrInvalidSize, got %v", err)
+	}
+}
+
+// TestDeleteUserAvatar_Success tests deleting the avatar and its blobs
+func TestDeleteUserAvatar_Success(t *testing.T) {
+	store := blob.NewMemoryStore()
+	service := avatars.NewAvatarService(&MockAvatarRepository{}, store)
+
+	if _, err := service.UploadUserAvatar(1, testPNG(t, 64, 64)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := service.DeleteUserAvatar(1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if store.Len() != 0 {
+		t.Errorf("Expected blobs to be deleted, got %d", store.Len())
+	}
+
+	_, err := service.GetUserAvatar(1, "")
+	if err == nil || err.Error() != "avatar not found" {
+		t.Errorf("Expected 'avatar not found', got %v", err)
+	}
+}
+
+// TestDeleteContactAvatars_RemovesBlobs tests removing the rows and files of purged contacts
+func TestDeleteContactAvatars_RemovesBlobs(t *testing.T) {
+	store := blob.NewMemoryStore()
+	mockRepo := &MockAvatarRepository{}
+	service := avatars.NewAvatarService(mockRepo, store)
+
+	avatar, err := service.UploadContactAvatar(3, 1, 0, testPNG(t, 40, 40))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.UploadContactAvatar(4, 1, 0, testPNG(t, 40, 40)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := service.DeleteContactAvatars([]uint{3, 9}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, size := range []string{avatars.SizeOriginal, avatars.SizeMedium, avatars.SizeThumb} {
+		if _, err := store.Get(avatar.BlobKey(size)); !errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("Expected the %s blob to be deleted, got %v", size, err)
+		}
+	}
+	if _, ok := mockRepo.Avatars[3]; ok {
+		t.Errorf("Expected the avatar row of contact 3 to be deleted")
+	}
+	if _, ok := mockRepo.Avatars[4]; !ok {
+		t.Errorf("Expected the avatar of contact 4 to be kept")
+	}
+}
+
+// ========== BlobStore Tests ==========
+
+// TestFileStore_RoundTrip tests writing, reading and deleting a file blob
+func TestFileStore_RoundTrip(t *testing.T) {
+	store := blob.NewFileStore(t.TempDir())
+	data := testPNG(t, 10, 10)
+
+	if err := store.Put("avatars/users/1/a/original", blob.Blob{Data: data, ContentType: "image/png"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := store.Get("avatars/users/1/a/original")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !bytes.Equal(got.Data, data) || got.ContentType != "image/png" {
+		t.Errorf("Expected the stored PNG back, got %d bytes of %s", len(got.Data), got.ContentType)
+	}
+
+	if err := store.Delete("avatars/users/1/a/original"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := store.Get("avatars/users/1/a/original"); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestFileStore_RejectsTraversal tests that keys cannot leave the root
+func TestFileStore_RejectsTraversal(t *testing.T) {
+	store := blob.NewFileStore(t.TempDir())
+
+	for _, key := range []string{"../secret", "/etc/passwd", "a//b", "a/./b", ""} {
+		if err := store.Put(key, blob.Blob{Data: []byte("x")}); !errors.Is(err, blob.ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey for %q, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/bulk"
+)
+
+// MockBulkRepository implements bulk.BulkRepository interface. Without
+// TransactionFunc it runs every item against Services.
+type MockBulkRepository struct {
+	TransactionFunc func(fn func(run bulk.ItemRunner) error) error
+	Services        bulk.Services
+}
+
+// Transaction implements bulk.BulkRepository
+func (m *MockBulkRepository) Transaction(fn func(run bulk.ItemRunner) error) error {
+	if m.TransactionFunc != nil {
+		return m.TransactionFunc(fn)
+	}
+	return fn(func(item func(services bulk.Services) error) error {
+		return item(m.Services)
+	})
+}
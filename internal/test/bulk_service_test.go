@@ -0,0 +1,235 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/bulk"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"gorm.io/gorm"
+)
+
+// bulkService builds a bulk service whose items run against contactRepo
+func bulkService(contactRepo *MockContactRepository) bulk.BulkService {
+	contactSvc := contacts.NewContactService(contactRepo)
+	repo := &MockBulkRepository{Services: bulk.Services{
+		Contacts: contactSvc,
+		Groups:   groups.NewGroupService(&MockGroupRepository{}),
+	}}
+	return bulk.NewBulkService(repo, contactSvc)
+}
+
+// ========== Bulk Service Tests ==========
+
+// TestBulk_TagReportsEachItem tests that failing contacts are skipped and reported
+func TestBulk_TagReportsEachItem(t *testing.T) {
+	tagged := map[uint][]string{}
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			if id == 2 {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return ownContact(id, user_id, workspace_id)
+		},
+		AddTagsFunc: func(id uint, tags []string) error {
+			tagged[id] = tags
+			return nil
+		},
+	}
+	service := bulkService(mockRepo)
+
+	response, err := service.Run(1, 0, bulk.BulkRequest{Action: bulk.ActionTag, IDs: []uint{1, 2, 3, 1}, Tags: []string{" VIP ", "vip"}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 3 || response.Succeeded != 2 || response.Failed != 1 {
+		t.Errorf("Expected 2 of 3 to succeed, got %+v", response)
+	}
+
+	if response.Results[1].ID != 2 || response.Results[1].Status != bulk.StatusFailed || response.Results[1].Error != "contact not found" {
+		t.Errorf("Unexpected result for contact 2: %+v", response.Results[1])
+	}
+
+	if len(tagged[3]) != 1 || tagged[3][0] != "vip" {
+		t.Errorf("Expected contact 3 tagged vip, got %v", tagged[3])
+	}
+}
+
+// TestBulk_AllOrNothing tests that one failure rolls the whole batch back
+func TestBulk_AllOrNothing(t *testing.T) {
+	var rolled_back bool
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			// Contact 2 belongs to someone else and is only shared.
+			return &contacts.Contact{ID: id, UserID: id}, nil
+		},
+	}
+	contactSvc := contacts.NewContactService(mockRepo)
+	repo := &MockBulkRepository{
+		TransactionFunc: func(fn func(run bulk.ItemRunner) error) error {
+			err := fn(func(item func(services bulk.Services) error) error {
+				return item(bulk.Services{Contacts: contactSvc})
+			})
+			rolled_back = err != nil
+			return err
+		},
+	}
+	service := bulk.NewBulkService(repo, contactSvc)
+
+	response, err := service.Run(1, 0, bulk.BulkRequest{Action: bulk.ActionDelete, IDs: []uint{1, 2}, AllOrNothing: true})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !rolled_back || !response.RolledBack || response.Succeeded != 0 {
+		t.Errorf("Expected the batch to be rolled back, got %+v", response)
+	}
+
+	if response.Results[0].Status != bulk.StatusRolledBack || response.Results[1].Status != bulk.StatusFailed {
+		t.Errorf("Unexpected results %+v", response.Results)
+	}
+}
+
+// TestBulk_FilterTooLarge tests the batch size cap for filters
+func TestBulk_FilterTooLarge(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{Total: bulk.MaxBatchSize + 1}, nil
+		},
+	}
+	service := bulkService(mockRepo)
+
+	_, err := service.Run(1, 0, bulk.BulkRequest{Action: bulk.ActionDelete, Filter: &bulk.Filter{CompanyID: 4}})
+
+	if !errors.Is(err, bulk.ErrBatchTooLarge) {
+		t.Errorf("Expected ErrBatchTooLarge, got %v", err)
+	}
+
+	if received.CompanyID != 4 {
+		t.Errorf("Expected the filter to reach the contact list, got %+v", received)
+	}
+}
+
+// TestBulk_FilterSelectsContacts tests applying an update to the contacts a filter matches
+func TestBulk_FilterSelectsContacts(t *testing.T) {
+	var updated []uint
+	mockRepo := &MockContactRepository{
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			return &contacts.GetContactsResponse{Data: []contacts.Contact{{ID: 5}, {ID: 6}}, Total: 2}, nil
+		},
+		FindContactByIdFunc: ownContact,
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			if contact.JobTitle != "Engineer" {
+				t.Errorf("Expected job title to be set, got %q", contact.JobTitle)
+			}
+			updated = append(updated, id)
+			return nil
+		},
+	}
+	service := bulkService(mockRepo)
+
+	response, err := service.Run(1, 0, bulk.BulkRequest{Action: bulk.ActionUpdate, Filter: &bulk.Filter{Tag: "team"}, Fields: &contacts.UpdateContactRequest{JobTitle: "Engineer"}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Succeeded != 2 || len(updated) != 2 {
+		t.Errorf("Expected 2 updates, got %+v", response)
+	}
+}
+
+// TestBulk_UpdateIgnoresManagedFields tests that bulk update fields cannot set ids, owners or flags
+func TestBulk_UpdateIgnoresManagedFields(t *testing.T) {
+	var saved *contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: ownContact,
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			saved = contact
+			return nil
+		},
+	}
+	service := bulkService(mockRepo)
+
+	var request bulk.BulkRequest
+	body := `{"action": "update", "ids": [5], "fields": {"id": 99, "user_id": 7, "favorite": true, "job_title": "Engineer"}}`
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	response, err := service.Run(1, 0, request)
+
+	if err != nil || response.Succeeded != 1 {
+		t.Fatalf("Expected the update to succeed, got %+v %v", response, err)
+	}
+	if saved.ID != 5 || saved.UserID != 1 || saved.Favorite || saved.JobTitle != "Engineer" {
+		t.Errorf("Expected only the job title to change, got %+v", saved)
+	}
+}
+
+// TestBulk_InvalidRequest tests the checks made before anything changes
+func TestBulk_InvalidRequest(t *testing.T) {
+	service := bulkService(&MockContactRepository{})
+
+	requests := []bulk.BulkRequest{
+		{Action: bulk.ActionDelete},
+		{Action: bulk.ActionDelete, IDs: []uint{1}, Filter: &bulk.Filter{}},
+		{Action: bulk.ActionRestore, Filter: &bulk.Filter{}},
+		{Action: bulk.ActionTag, IDs: []uint{1}, Tags: []string{" "}},
+		{Action: bulk.ActionAddToGroup, IDs: []uint{1}},
+		{Action: bulk.ActionUpdate, IDs: []uint{1}},
+	}
+	for _, request := range requests {
+		if _, err := service.Run(1, 0, request); !errors.Is(err, bulk.ErrInvalidRequest) {
+			t.Errorf("Expected ErrInvalidRequest for %+v, got %v", request, err)
+		}
+	}
+}
+
+// TestBulk_GroupNotFound tests that a missing group fails the request instead of every item
+func TestBulk_GroupNotFound(t *testing.T) {
+	contactSvc := contacts.NewContactService(&MockContactRepository{})
+	groupRepo := &MockGroupRepository{
+		FindGroupByIdFunc: func(id, user_id uint) (*groups.Group, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	repo := &MockBulkRepository{Services: bulk.Services{Contacts: contactSvc, Groups: groups.NewGroupService(groupRepo)}}
+	service := bulk.NewBulkService(repo, contactSvc)
+
+	_, err := service.Run(1, 0, bulk.BulkRequest{Action: bulk.ActionAddToGroup, IDs: []uint{1}, GroupID: 9})
+
+	if err == nil || err.Error() != "group not found" {
+		t.Errorf("Expected 'group not found', got %v", err)
+	}
+}
+
+// TestBulk_AddToGroupWorkspace tests that add_to_group checks the contacts in the active workspace
+func TestBulk_AddToGroupWorkspace(t *testing.T) {
+	contactSvc := contacts.NewContactService(&MockContactRepository{})
+	var gotWorkspaces []uint
+	groupRepo := &MockGroupRepository{
+		CountOwnedContactsFunc: func(user_id, workspace_id uint, contact_ids []uint) (int64, error) {
+			gotWorkspaces = append(gotWorkspaces, workspace_id)
+			return int64(len(contact_ids)), nil
+		},
+	}
+	repo := &MockBulkRepository{Services: bulk.Services{Contacts: contactSvc, Groups: groups.NewGroupService(groupRepo)}}
+	service := bulk.NewBulkService(repo, contactSvc)
+
+	response, err := service.Run(1, 3, bulk.BulkRequest{Action: bulk.ActionAddToGroup, IDs: []uint{1, 2}, GroupID: 9})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Succeeded != 2 || len(gotWorkspaces) != 2 || gotWorkspaces[0] != 3 || gotWorkspaces[1] != 3 {
+		t.Errorf("Expected both contacts checked in workspace 3, got %v and %+v", gotWorkspaces, response)
+	}
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"gorm.io/gorm"
+)
+
+// MockCompanyRepository implements companies.CompanyRepository interface
+type MockCompanyRepository struct {
+	GetCompaniesFunc      func(user_id uint, search string) ([]companies.Company, error)
+	FindCompanyByIdFunc   func(id, user_id uint) (*companies.Company, error)
+	FindCompanyByNameFunc func(user_id uint, name string) (*companies.Company, error)
+	CreateCompanyFunc     func(company *companies.Company) error
+	UpdateCompanyFunc     func(company *companies.Company) error
+	DeleteCompanyFunc     func(id uint) error
+}
+
+// GetCompanies implements companies.CompanyRepository
+func (m *MockCompanyRepository) GetCompanies(user_id uint, search string) ([]companies.Company, error) {
+	if m.GetCompaniesFunc != nil {
+		return m.GetCompaniesFunc(user_id, search)
+	}
+	return []companies.Company{}, nil
+}
+
+// FindCompanyById implements companies.CompanyRepository
+func (m *MockCompanyRepository) FindCompanyById(id, user_id uint) (*companies.Company, error) {
+	if m.FindCompanyByIdFunc != nil {
+		return m.FindCompanyByIdFunc(id, user_id)
+	}
+	return &companies.Company{ID: id, UserID: user_id}, nil
+}
+
+// FindCompanyByName implements companies.CompanyRepository
+func (m *MockCompanyRepository) FindCompanyByName(user_id uint, name string) (*companies.Company, error) {
+	if m.FindCompanyByNameFunc != nil {
+		return m.FindCompanyByNameFunc(user_id, name)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// CreateCompany implements companies.CompanyRepository
+func (m *MockCompanyRepository) CreateCompany(company *companies.Company) error {
+	if m.CreateCompanyFunc != nil {
+		return m.CreateCompanyFunc(company)
+	}
+	company.ID = 1
+	return nil
+}
+
+// UpdateCompany implements companies.CompanyRepository
+func (m *MockCompanyRepository) UpdateCompany(company *companies.Company) error {
+	if m.UpdateCompanyFunc != nil {
+		return m.UpdateCompanyFunc(company)
+	}
+	return nil
+}
+
+// DeleteCompany implements companies.CompanyRepository
+func (m *MockCompanyRepository) DeleteCompany(id uint) error {
+	if m.DeleteCompanyFunc != nil {
+		return m.DeleteCompanyFunc(id)
+	}
+	return nil
+}
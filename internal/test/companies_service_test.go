@@ -0,0 +1,167 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// ========== Company Service Tests ==========
+
+// TestCreateCompany_Success tests creating a company with a normalized domain
+func TestCreateCompany_Success(t *testing.T) {
+	service := companies.NewCompanyService(&MockCompanyRepository{})
+
+	company, err := service.CreateCompany(1, companies.CreateCompanyRequest{Name: " Acme ", Domain: "https://www.Acme.com/about", Industry: "Manufacturing"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if company.Name != "Acme" || company.Domain != "acme.com" || company.UserID != 1 {
+		t.Errorf("Unexpected company %+v", company)
+	}
+}
+
+// TestCreateCompany_DuplicateName tests rejecting a second company with the same name
+func TestCreateCompany_DuplicateName(t *testing.T) {
+	mockRepo := &MockCompanyRepository{
+		FindCompanyByNameFunc: func(user_id uint, name string) (*companies.Company, error) {
+			return &companies.Company{ID: 7, UserID: user_id, Name: name}, nil
+		},
+	}
+	service := companies.NewCompanyService(mockRepo)
+
+	_, err := service.CreateCompany(1, companies.CreateCompanyRequest{Name: "Acme"})
+
+	if err == nil || err.Error() != "company with this name already exists" {
+		t.Errorf("Expected duplicate name error, got %v", err)
+	}
+}
+
+// TestUpdateCompany_KeepsOtherFields tests that only the sent fields change
+func TestUpdateCompany_KeepsOtherFields(t *testing.T) {
+	mockRepo := &MockCompanyRepository{
+		FindCompanyByIdFunc: func(id, user_id uint) (*companies.Company, error) {
+			return &companies.Company{ID: id, UserID: user_id, Name: "Acme", Domain: "acme.com"}, nil
+		},
+	}
+	service := companies.NewCompanyService(mockRepo)
+
+	company, err := service.UpdateCompany(1, 1, companies.UpdateCompanyRequest{Industry: "Retail"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if company.Name != "Acme" || company.Domain != "acme.com" || company.Industry != "Retail" {
+		t.Errorf("Unexpected company %+v", company)
+	}
+}
+
+// TestDeleteCompany_NotFound tests deleting another user's company
+func TestDeleteCompany_NotFound(t *testing.T) {
+	mockRepo := &MockCompanyRepository{
+		FindCompanyByIdFunc: func(id, user_id uint) (*companies.Company, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		DeleteCompanyFunc: func(id uint) error {
+			t.Error("Expected nothing to be deleted")
+			return nil
+		},
+	}
+	service := companies.NewCompanyService(mockRepo)
+
+	err := service.DeleteCompany(1, 1)
+
+	if err == nil || err.Error() != "company not found" {
+		t.Errorf("Expected 'company not found', got %v", err)
+	}
+}
+
+// ========== Contact Company Tests ==========
+
+// TestCreateContact_ForeignCompany tests linking a contact to another user's company
+func TestCreateContact_ForeignCompany(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindCompanyFunc: func(id, user_id uint) (*companies.Company, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.CreateContact(contacts.Contact{UserID: 1, FirstName: "John", Email: "john@example.com", CompanyID: uintPtr(9)})
+
+	if err == nil || err.Error() != "company not found" {
+		t.Errorf("Expected 'company not found', got %v", err)
+	}
+}
+
+// TestUpdateContact_UnlinkCompany tests that company_id 0 removes the company
+func TestUpdateContact_UnlinkCompany(t *testing.T) {
+	var saved *contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id, CompanyID: uintPtr(3), JobTitle: "Engineer"}, nil
+		},
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			saved = contact
+			return nil
+		},
+	}
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(1, 1, 0, contacts.Contact{CompanyID: uintPtr(0)})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.CompanyID != nil || saved.JobTitle != "Engineer" {
+		t.Errorf("Expected company removed and job title kept, got %v %q", saved.CompanyID, saved.JobTitle)
+	}
+}
+
+// TestGetCompanyContacts_Filter tests that the company is checked and passed to the list
+func TestGetCompanyContacts_Filter(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetCompanyContacts(3, 1, 10, 1, "", contacts.ContactQuery{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received.CompanyID != 3 {
+		t.Errorf("Expected company filter 3, got %d", received.CompanyID)
+	}
+}
+
+// TestGetCompanyContacts_NotFound tests listing the people of another user's company
+func TestGetCompanyContacts_NotFound(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindCompanyFunc: func(id, user_id uint) (*companies.Company, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetCompanyContacts(3, 1, 10, 1, "", contacts.ContactQuery{})
+
+	if err == nil || err.Error() != "company not found" {
+		t.Errorf("Expected 'company not found', got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+)
+
+// MockContactCSVRepository implements contactcsv.ContactCSVRepository interface
+type MockContactCSVRepository struct {
+	EachContactBatchFunc  func(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error
+	EmailExistsFunc       func(user_id, workspace_id uint, email string) (bool, error)
+	CreateContactFunc     func(contact *contacts.Contact, address *addresses.Address) error
+	CreateAddressFunc     func(user_id uint, address *addresses.Address) error
+	GetCustomFieldsFunc   func(user_id uint) ([]customfields.Field, error)
+	FindUserRegionFunc    func(user_id uint) (string, error)
+	FindWorkspaceRoleFunc func(workspace_id, user_id uint) (string, error)
+}
+
+// EachContactBatch implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) EachContactBatch(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error {
+	if m.EachContactBatchFunc != nil {
+		return m.EachContactBatchFunc(user_id, workspace_id, search, fn)
+	}
+	return nil
+}
+
+// EmailExists implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) EmailExists(user_id, workspace_id uint, email string) (bool, error) {
+	if m.EmailExistsFunc != nil {
+		return m.EmailExistsFunc(user_id, workspace_id, email)
+	}
+	return false, nil
+}
+
+// CreateContact implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) CreateContact(contact *contacts.Contact, address *addresses.Address) error {
+	if m.CreateContactFunc != nil {
+		return m.CreateContactFunc(contact, address)
+	}
+	return nil
+}
+
+// CreateAddress implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) CreateAddress(user_id uint, address *addresses.Address) error {
+	if m.CreateAddressFunc != nil {
+		return m.CreateAddressFunc(user_id, address)
+	}
+	return nil
+}
+
+// FindUserRegion implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) FindUserRegion(user_id uint) (string, error) {
+	if m.FindUserRegionFunc != nil {
+		return m.FindUserRegionFunc(user_id)
+	}
+	return "", nil
+}
+
+// GetCustomFields implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	if m.GetCustomFieldsFunc != nil {
+		return m.GetCustomFieldsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindWorkspaceRole implements contactcsv.ContactCSVRepository
+func (m *MockContactCSVRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	if m.FindWorkspaceRoleFunc != nil {
+		return m.FindWorkspaceRoleFunc(workspace_id, user_id)
+	}
+	return "", nil
+}
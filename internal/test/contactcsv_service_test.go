@@ -0,0 +1,379 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contactcsv"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+)
+
+// ========== ExportContacts Tests ==========
+
+// TestContactCSVExport_Success tests that every address gets its own row
+func TestContactCSVExport_Success(t *testing.T) {
+	mockRepo := &MockContactCSVRepository{
+		EachContactBatchFunc: func(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error {
+			return fn(
+				[]contacts.Contact{
+					{ID: 1, UserID: user_id, FirstName: "John", Email: "john@example.com"},
+					{ID: 2, UserID: user_id, FirstName: "Jane", Email: "jane@example.com"},
+				},
+				[]addresses.Address{
+					{ID: 10, ContactID: 1, City: "Bandung", Country: "Indonesia"},
+					{ID: 11, ContactID: 1, City: "Jakarta", Country: "Indonesia"},
+				},
+			)
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	var buf bytes.Buffer
+	err := service.ExportContacts(1, 0, "", &buf)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+
+	if lines[1] != "1,John,,john@example.com,,10,,Bandung,,,Indonesia" {
+		t.Errorf("Unexpected first row '%s'", lines[1])
+	}
+
+	if lines[3] != "2,Jane,,jane@example.com,,,,,,," {
+		t.Errorf("Unexpected row for contact without address '%s'", lines[3])
+	}
+}
+
+// ========== ImportContacts Tests ==========
+
+// TestContactCSVImport_GooglePreset tests importing a Google export with created, skipped and failed rows
+func TestContactCSVImport_GooglePreset(t *testing.T) {
+	var created []contacts.Contact
+	var createdAddress *addresses.Address
+	mockRepo := &MockContactCSVRepository{
+		EmailExistsFunc: func(user_id, workspace_id uint, email string) (bool, error) {
+			return email == "existing@example.com", nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			contact.ID = uint(len(created) + 1)
+			created = append(created, *contact)
+			if address != nil {
+				createdAddress = address
+			}
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	data := "\ufeffGiven Name,Family Name,E-mail 1 - Value,Phone 1 - Value,Address 1 - City,Address 1 - Country\n" +
+		"John,Doe,john@example.com,0812-3456-789,Bandung,Indonesia\n" +
+		",,,,,\n" +
+		"Jane,,existing@example.com,,,\n" +
+		"J,,short@example.com,,,\n" +
+		"Bob,,JOHN@example.com,,,\n" +
+		"Ann,,ann@example.com,,Bandung,\n"
+
+	report, err := service.ImportContacts(3, 0, strings.NewReader(data), contactcsv.ImportRequest{Preset: "google"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Total != 6 || report.Created != 1 || report.Skipped != 3 || report.Failed != 2 {
+		t.Errorf("Expected 6 total, 1 created, 3 skipped, 2 failed, got %d/%d/%d/%d", report.Total, report.Created, report.Skipped, report.Failed)
+	}
+
+	if len(created) != 1 || created[0].UserID != 3 || created[0].LastName != "Doe" || created[0].PhoneE164 != "+628123456789" {
+		t.Errorf("Expected John Doe to be created for user 3, got %+v", created)
+	}
+
+	if createdAddress == nil || createdAddress.City != "Bandung" {
+		t.Errorf("Expected address in Bandung to be created, got %+v", createdAddress)
+	}
+
+	if report.Rows[0].Row != 2 || report.Rows[0].ContactID != 1 {
+		t.Errorf("Expected row 2 to create contact 1, got %+v", report.Rows[0])
+	}
+}
+
+// TestContactCSVImport_DryRun tests that a dry run validates rows without saving them
+func TestContactCSVImport_DryRun(t *testing.T) {
+	mockRepo := &MockContactCSVRepository{
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			t.Error("Expected no contact to be created during a dry run")
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	data := "Name,Mail\nJohn,john@example.com\nJane,not-an-email\n"
+	request := contactcsv.ImportRequest{
+		Mapping: map[string]string{"name": "first_name", "Mail": "email"},
+		DryRun:  true,
+	}
+
+	report, err := service.ImportContacts(1, 0, strings.NewReader(data), request)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !report.DryRun || report.Created != 1 || report.Failed != 1 {
+		t.Errorf("Expected dry run with 1 created and 1 failed, got %+v", report)
+	}
+}
+
+// TestContactCSVImport_InvalidMapping tests mapping validation
+func TestContactCSVImport_InvalidMapping(t *testing.T) {
+	service := contactcsv.NewContactCSVService(&MockContactCSVRepository{}, nil)
+
+	_, err := service.ImportContacts(1, 0, strings.NewReader("Name,Mail\n"), contactcsv.ImportRequest{
+		Mapping: map[string]string{"Name": "nickname"},
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field, got nil")
+	}
+
+	_, err = service.ImportContacts(1, 0, strings.NewReader("Name\n"), contactcsv.ImportRequest{
+		Mapping: map[string]string{"Name": "first_name"},
+	})
+	if err == nil {
+		t.Error("Expected error for unmapped email column, got nil")
+	}
+
+	_, err = service.ImportContacts(1, 0, strings.NewReader("Name\n"), contactcsv.ImportRequest{Preset: "yahoo"})
+	if err == nil {
+		t.Error("Expected error for unknown preset, got nil")
+	}
+}
+
+// TestContactCSVImport_ExportRoundTrip tests that re-importing an export keeps every address of a contact
+func TestContactCSVImport_ExportRoundTrip(t *testing.T) {
+	exportRepo := &MockContactCSVRepository{
+		EachContactBatchFunc: func(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error {
+			return fn(
+				[]contacts.Contact{{ID: 7, UserID: user_id, FirstName: "John", Email: "john@example.com"}},
+				[]addresses.Address{
+					{ID: 10, ContactID: 7, City: "Bandung", Country: "ID"},
+					{ID: 11, ContactID: 7, City: "Jakarta", Country: "ID"},
+				},
+			)
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := contactcsv.NewContactCSVService(exportRepo, nil).ExportContacts(1, 0, "", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var created []contacts.Contact
+	var saved []addresses.Address
+	importRepo := &MockContactCSVRepository{
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			contact.ID = uint(len(created) + 20)
+			created = append(created, *contact)
+			if address != nil {
+				address.ContactID = contact.ID
+				saved = append(saved, *address)
+			}
+			return nil
+		},
+		CreateAddressFunc: func(user_id uint, address *addresses.Address) error {
+			saved = append(saved, *address)
+			return nil
+		},
+	}
+
+	report, err := contactcsv.NewContactCSVService(importRepo, nil).ImportContacts(2, 0, &buf, contactcsv.ImportRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Created != 1 || report.AddressesAdded != 1 || report.Skipped != 0 || report.Failed != 0 {
+		t.Errorf("Expected 1 contact and 1 extra address, got %+v", report)
+	}
+	if len(created) != 1 || created[0].ID == 7 {
+		t.Fatalf("Expected one new contact, got %+v", created)
+	}
+	if len(saved) != 2 || saved[0].City != "Bandung" || saved[1].City != "Jakarta" || saved[1].ContactID != created[0].ID {
+		t.Errorf("Expected both addresses on the new contact, got %+v", saved)
+	}
+	if report.Rows[1].Status != contactcsv.RowAddressAdded || report.Rows[1].ContactID != created[0].ID {
+		t.Errorf("Expected row 3 to add an address to the new contact, got %+v", report.Rows[1])
+	}
+}
+
+// TestContactCSVImport_DuplicateEmailOtherContact tests that another contact_id with a seen email is skipped
+func TestContactCSVImport_DuplicateEmailOtherContact(t *testing.T) {
+	mockRepo := &MockContactCSVRepository{
+		CreateAddressFunc: func(user_id uint, address *addresses.Address) error {
+			t.Error("Expected no address to be added")
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	data := "contact_id,first_name,email,city,country\n" +
+		"1,John,john@example.com,Bandung,ID\n" +
+		"2,Johnny,JOHN@example.com,Jakarta,ID\n"
+
+	report, err := service.ImportContacts(1, 0, strings.NewReader(data), contactcsv.ImportRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Created != 1 || report.Skipped != 1 || report.Rows[1].Reason != "duplicate email in file" {
+		t.Errorf("Expected the second contact to be skipped, got %+v", report)
+	}
+}
+
+// TestContactCSVImport_Geocodes tests that imported addresses get coordinates, except in a dry run
+func TestContactCSVImport_Geocodes(t *testing.T) {
+	calls := 0
+	geocoder := &MockGeocoder{GeocodeFunc: func(q geo.Query) (*geo.Point, error) {
+		calls++
+		if q.City != "Bandung" || q.Country != "ID" {
+			t.Errorf("Expected the normalized address, got %+v", q)
+		}
+		return &geo.Point{Lat: -6.9, Lng: 107.6}, nil
+	}}
+	var saved []addresses.Address
+	mockRepo := &MockContactCSVRepository{
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			saved = append(saved, *address)
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, geocoder)
+
+	data := "first_name,email,city,country\nJohn,john@example.com,Bandung,Indonesia\n"
+	if _, err := service.ImportContacts(1, 0, strings.NewReader(data), contactcsv.ImportRequest{DryRun: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected no geocoding in a dry run, got %d calls", calls)
+	}
+
+	if _, err := service.ImportContacts(1, 0, strings.NewReader(data), contactcsv.ImportRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(saved) != 1 || saved[0].Latitude == nil || *saved[0].Latitude != -6.9 || *saved[0].Longitude != 107.6 {
+		t.Errorf("Expected the address to be located, got %+v", saved)
+	}
+}
+
+// TestContactCSVImport_RequiredCustomField tests that rows fail while the user has a required custom field
+func TestContactCSVImport_RequiredCustomField(t *testing.T) {
+	mockRepo := &MockContactCSVRepository{
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return []customfields.Field{
+				{ID: 1, UserID: user_id, Key: "nickname", Type: customfields.TypeText},
+				{ID: 2, UserID: user_id, Key: "department", Type: customfields.TypeText, Required: true},
+			}, nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			t.Error("Expected no contact to be created")
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	report, err := service.ImportContacts(1, 0, strings.NewReader("first_name,email\nJohn,john@example.com\n"), contactcsv.ImportRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Failed != 1 || !strings.Contains(report.Rows[0].Reason, "department is required") {
+		t.Errorf("Expected the row to fail on the required field, got %+v", report)
+	}
+}
+
+// ========== CSV Workspace Tests ==========
+
+// TestContactCSVExport_Workspace tests exporting the contacts of the active workspace
+func TestContactCSVExport_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockContactCSVRepository{
+		EachContactBatchFunc: func(user_id, workspace_id uint, search string, fn func(contact_list []contacts.Contact, address_list []addresses.Address) error) error {
+			gotWorkspace = workspace_id
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	var buf bytes.Buffer
+	if err := service.ExportContacts(1, 3, "", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 {
+		t.Errorf("Expected workspace 3, got %d", gotWorkspace)
+	}
+}
+
+// TestContactCSVImport_Workspace tests that imported contacts join the active workspace
+func TestContactCSVImport_Workspace(t *testing.T) {
+	var existsIn uint
+	var created *contacts.Contact
+	mockRepo := &MockContactCSVRepository{
+		FindWorkspaceRoleFunc: func(workspace_id, user_id uint) (string, error) {
+			return workspaces.RoleAdmin, nil
+		},
+		EmailExistsFunc: func(user_id, workspace_id uint, email string) (bool, error) {
+			existsIn = workspace_id
+			return false, nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address *addresses.Address) error {
+			created = contact
+			return nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	report, err := service.ImportContacts(1, 3, strings.NewReader("first_name,email\nJane,jane@example.com\n"), contactcsv.ImportRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Created != 1 || existsIn != 3 {
+		t.Errorf("Expected the email to be checked in workspace 3, got %d and %+v", existsIn, report)
+	}
+	if created == nil || created.WorkspaceID == nil || *created.WorkspaceID != 3 {
+		t.Errorf("Expected the contact in workspace 3, got %+v", created)
+	}
+}
+
+// TestContactCSVImport_WorkspaceViewer tests that viewers cannot import into a workspace
+func TestContactCSVImport_WorkspaceViewer(t *testing.T) {
+	mockRepo := &MockContactCSVRepository{
+		FindWorkspaceRoleFunc: func(workspace_id, user_id uint) (string, error) {
+			return workspaces.RoleViewer, nil
+		},
+	}
+
+	service := contactcsv.NewContactCSVService(mockRepo, nil)
+
+	_, err := service.ImportContacts(1, 3, strings.NewReader("first_name,email\nJane,jane@example.com\n"), contactcsv.ImportRequest{})
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 )
 
 // ========== Contacts Repository Integration Tests ==========
@@ -27,7 +28,7 @@ func TestContactRepository_GetContacts_Integration(t *testing.T) {
 	db.Create(&contacts.Contact{UserID: user2.ID, FirstName: "Bob", LastName: "Wilson", Email: "bob@example.com", Phone: "789"})
 
 	// Test get contacts for user1 only
-	result, err := repo.GetContacts(1, 10, int(user1.ID), "")
+	result, err := repo.GetContacts(1, 10, int(user1.ID), "", contacts.ContactFilter{})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -65,7 +66,7 @@ func TestContactRepository_GetContacts_WithSearch_Integration(t *testing.T) {
 	db.Create(&contacts.Contact{UserID: user1.ID, FirstName: "Jane", Email: "jane@example.com"})
 
 	// Test search
-	result, err := repo.GetContacts(1, 10, int(user1.ID), "john")
+	result, err := repo.GetContacts(1, 10, int(user1.ID), "john", contacts.ContactFilter{})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -136,7 +137,7 @@ func TestContactRepository_FindContactById_Integration(t *testing.T) {
 	db.Create(&createdContact)
 
 	// Find contact
-	contact, err := repo.FindContactById(createdContact.ID, user1.ID)
+	contact, err := repo.FindContactById(createdContact.ID, user1.ID, workspaces.Personal)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -168,7 +169,7 @@ func TestContactRepository_FindContactById_WrongUser_Integration(t *testing.T) {
 	db.Create(&createdContact)
 
 	// Try to find with user2's ID (should fail - authorization check)
-	_, err := repo.FindContactById(createdContact.ID, user2.ID)
+	_, err := repo.FindContactById(createdContact.ID, user2.ID, workspaces.Personal)
 
 	if err == nil {
 		t.Error("BUG FOUND: Should not find contact belonging to different user (authorization breach!)")
@@ -201,7 +202,7 @@ func TestContactRepository_UpdateContact_Integration(t *testing.T) {
 		Email:     "john.updated@example.com",
 	}
 
-	err := repo.UpdateContact(createdContact.ID, user1.ID, updateContact)
+	err := repo.UpdateContact(createdContact.ID, user1.ID, workspaces.Personal, updateContact)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -240,7 +241,7 @@ func TestContactRepository_UpdateContact_PartialUpdate_Integration(t *testing.T)
 		FirstName: "John Updated",
 	}
 
-	err := repo.UpdateContact(createdContact.ID, user1.ID, updateContact)
+	err := repo.UpdateContact(createdContact.ID, user1.ID, workspaces.Personal, updateContact)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -281,7 +282,7 @@ func TestContactRepository_DeleteContact_Integration(t *testing.T) {
 	db.Create(&createdContact)
 
 	// Delete contact
-	err := repo.DeleteContact(createdContact.ID, user1.ID)
+	err := repo.DeleteContact(createdContact.ID, user1.ID, workspaces.Personal)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -316,7 +317,7 @@ func TestContactRepository_DeleteContact_WrongUser_Integration(t *testing.T) {
 	db.Create(&createdContact)
 
 	// Try to delete with user2's ID
-	err := repo.DeleteContact(createdContact.ID, user2.ID)
+	err := repo.DeleteContact(createdContact.ID, user2.ID, workspaces.Personal)
 
 	// Should succeed (no error) but contact should NOT be deleted
 	if err != nil {
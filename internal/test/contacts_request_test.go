@@ -0,0 +1,171 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// ========== Contact Request Tests ==========
+
+// TestCreateContactRequest_IgnoresManagedFields tests that a create body cannot set ids, flags or associations
+func TestCreateContactRequest_IgnoresManagedFields(t *testing.T) {
+	body := `{
+		"id": 99,
+		"user_id": 7,
+		"first_name": "Jane",
+		"email": "jane@example.com",
+		"favorite": true,
+		"last_interaction_at": "2026-01-01T00:00:00Z",
+		"relationships": [{"related_id": 42, "type": "friend"}],
+		"tags": ["VIP"]
+	}`
+
+	var request contacts.CreateContactRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	contact := request.Contact(1)
+
+	if contact.ID != 0 || contact.UserID != 1 || contact.Favorite || contact.LastInteractionAt != nil {
+		t.Errorf("Expected managed fields to be ignored, got %+v", contact)
+	}
+	if len(contact.Relationships) != 0 {
+		t.Errorf("Expected no relationships, got %+v", contact.Relationships)
+	}
+	if len(contact.Tags) != 1 || contact.Tags[0].Name != "VIP" {
+		t.Errorf("Expected the tags to be kept, got %+v", contact.Tags)
+	}
+}
+
+// TestUpdateContactRequest_IgnoresManagedFields tests that an update body cannot set ids, flags or associations
+func TestUpdateContactRequest_IgnoresManagedFields(t *testing.T) {
+	body := `{"id": 99, "first_name": "Janet", "favorite": true, "relationships": [{"related_id": 42, "type": "friend"}]}`
+
+	var request contacts.UpdateContactRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	contact := request.Contact()
+
+	if contact.ID != 0 || contact.Favorite || len(contact.Relationships) != 0 {
+		t.Errorf("Expected managed fields to be ignored, got %+v", contact)
+	}
+	if contact.FirstName != "Janet" {
+		t.Errorf("Expected the first name to be kept, got %q", contact.FirstName)
+	}
+}
+
+// TestCreateContactRequest_IgnoresCompanyObject tests that a company can only be given by id or name
+func TestCreateContactRequest_IgnoresCompanyObject(t *testing.T) {
+	body := `{"first_name": "Jane", "email": "jane@example.com", "company": {"id": 5, "user_id": 2, "name": "Acme"}}`
+
+	var request contacts.CreateContactRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	contact := request.Contact(1)
+
+	if contact.Company != nil || contact.CompanyID != nil || contact.CompanyName != "" {
+		t.Errorf("Expected the company object to be ignored, got %+v", contact)
+	}
+}
+
+// ========== Contact Company Tests ==========
+
+// TestCreateContact_CompanyName tests linking the owner's company by name
+func TestCreateContact_CompanyName(t *testing.T) {
+	var gotOwner uint
+	var gotName string
+	var saved contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindOrCreateCompanyFunc: func(user_id uint, name string) (*companies.Company, error) {
+			gotOwner, gotName = user_id, name
+			return &companies.Company{ID: 8, UserID: user_id, Name: name}, nil
+		},
+		CreateContactFunc: func(contact contacts.Contact) (*contacts.ContactResponse, error) {
+			saved = contact
+			return &contacts.ContactResponse{ID: 1, CompanyID: contact.CompanyID}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	request := contacts.CreateContactRequest{FirstName: "Jane", Email: "jane@example.com", CompanyName: " Acme "}
+	_, err := service.CreateContact(request.Contact(3))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotOwner != 3 || gotName != "Acme" {
+		t.Errorf("Expected the company Acme of user 3, got %q of user %d", gotName, gotOwner)
+	}
+	if saved.CompanyID == nil || *saved.CompanyID != 8 || saved.Company != nil {
+		t.Errorf("Expected company 8 linked by id only, got %v %+v", saved.CompanyID, saved.Company)
+	}
+}
+
+// TestCreateContact_CompanyIdWins tests that company_id is used when both are given
+func TestCreateContact_CompanyIdWins(t *testing.T) {
+	var gotCompany uint
+	mockRepo := &MockContactRepository{
+		FindCompanyFunc: func(id, user_id uint) (*companies.Company, error) {
+			gotCompany = id
+			return &companies.Company{ID: id, UserID: user_id}, nil
+		},
+		FindOrCreateCompanyFunc: func(user_id uint, name string) (*companies.Company, error) {
+			t.Fatalf("Expected no lookup by name")
+			return nil, nil
+		},
+		CreateContactFunc: func(contact contacts.Contact) (*contacts.ContactResponse, error) {
+			return &contacts.ContactResponse{ID: 1}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	company_id := uint(4)
+	request := contacts.CreateContactRequest{FirstName: "Jane", Email: "jane@example.com", CompanyID: &company_id, CompanyName: "Acme"}
+	if _, err := service.CreateContact(request.Contact(3)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotCompany != 4 {
+		t.Errorf("Expected company 4 to be checked, got %d", gotCompany)
+	}
+}
+
+// TestUpdateContact_CompanyName tests changing the company by name
+func TestUpdateContact_CompanyName(t *testing.T) {
+	var saved *contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 5, FirstName: "Jane"}, nil
+		},
+		CanEditContactFunc: func(id, user_id, workspace_id uint) (bool, error) {
+			return true, nil
+		},
+		FindOrCreateCompanyFunc: func(user_id uint, name string) (*companies.Company, error) {
+			if user_id != 5 {
+				t.Errorf("Expected a company of the owner, got user %d", user_id)
+			}
+			return &companies.Company{ID: 9, UserID: user_id, Name: name}, nil
+		},
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			saved = contact
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(2, 5, 0, contacts.UpdateContactRequest{CompanyName: "Acme"}.Contact())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.CompanyID == nil || *saved.CompanyID != 9 {
+		t.Errorf("Expected company 9, got %v", saved.CompanyID)
+	}
+}
@@ -13,7 +13,7 @@ import (
 // TestGetContacts_Success tests successful retrieval with pagination
 func TestGetContacts_Success(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		GetContactsFunc: func(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error) {
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
 			return &contacts.GetContactsResponse{
 				Data: []contacts.Contact{
 					{ID: 1, UserID: uint(user_id), FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "1234567890"},
@@ -29,7 +29,7 @@ func TestGetContacts_Success(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	result, err := service.GetContacts(1, 10, 1, "")
+	result, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -51,7 +51,7 @@ func TestGetContacts_Success(t *testing.T) {
 // TestGetContacts_WithSearch tests contact retrieval with search query
 func TestGetContacts_WithSearch(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		GetContactsFunc: func(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error) {
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
 			if search == "john" {
 				return &contacts.GetContactsResponse{
 					Data: []contacts.Contact{
@@ -75,7 +75,7 @@ func TestGetContacts_WithSearch(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	result, err := service.GetContacts(1, 10, 1, "john")
+	result, err := service.GetContacts(1, 10, 1, "john", contacts.ContactQuery{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -93,7 +93,7 @@ func TestGetContacts_WithSearch(t *testing.T) {
 // TestGetContacts_EmptyResult tests contact retrieval with no results
 func TestGetContacts_EmptyResult(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		GetContactsFunc: func(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error) {
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
 			return &contacts.GetContactsResponse{
 				Data:       []contacts.Contact{},
 				Page:       page,
@@ -106,7 +106,7 @@ func TestGetContacts_EmptyResult(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	result, err := service.GetContacts(1, 10, 1, "nonexistent")
+	result, err := service.GetContacts(1, 10, 1, "nonexistent", contacts.ContactQuery{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -120,14 +120,14 @@ func TestGetContacts_EmptyResult(t *testing.T) {
 // TestGetContacts_DatabaseError tests contact retrieval with database error
 func TestGetContacts_DatabaseError(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		GetContactsFunc: func(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error) {
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	_, err := service.GetContacts(1, 10, 1, "")
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{})
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -236,7 +236,7 @@ func TestCreateContact_DatabaseError(t *testing.T) {
 // TestFindContactById_Success tests successful contact retrieval by ID
 func TestFindContactById_Success(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -250,7 +250,7 @@ func TestFindContactById_Success(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	contact, err := service.FindContactById(1, 1)
+	contact, err := service.FindContactById(1, 1, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -268,14 +268,14 @@ func TestFindContactById_Success(t *testing.T) {
 // TestFindContactById_NotFound tests finding non-existent contact
 func TestFindContactById_NotFound(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	_, err := service.FindContactById(999, 1)
+	_, err := service.FindContactById(999, 1, 0)
 
 	if err == nil {
 		t.Error("Expected error for non-existent contact, got nil")
@@ -289,7 +289,7 @@ func TestFindContactById_NotFound(t *testing.T) {
 // TestFindContactById_WrongUser tests finding contact with wrong user_id (authorization)
 func TestFindContactById_WrongUser(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			// Simulate contact not found because user_id doesn't match
 			return nil, gorm.ErrRecordNotFound
 		},
@@ -297,7 +297,7 @@ func TestFindContactById_WrongUser(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	_, err := service.FindContactById(1, 999)
+	_, err := service.FindContactById(1, 999, 0)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -311,14 +311,14 @@ func TestFindContactById_WrongUser(t *testing.T) {
 // TestFindContactById_DatabaseError tests finding contact with database error
 func TestFindContactById_DatabaseError(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	_, err := service.FindContactById(1, 1)
+	_, err := service.FindContactById(1, 1, 0)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -335,7 +335,7 @@ func TestFindContactById_DatabaseError(t *testing.T) {
 // TestUpdateContact_Success tests successful contact update
 func TestUpdateContact_Success(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -345,7 +345,7 @@ func TestUpdateContact_Success(t *testing.T) {
 				Phone:     "1234567890",
 			}, nil
 		},
-		UpdateContactFunc: func(id, user_id uint, contact *contacts.Contact) error {
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
 			return nil
 		},
 	}
@@ -357,7 +357,7 @@ func TestUpdateContact_Success(t *testing.T) {
 		Email:     "john.updated@example.com",
 	}
 
-	err := service.UpdateContact(1, 1, updateContact)
+	err := service.UpdateContact(1, 1, 0, updateContact)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -367,7 +367,7 @@ func TestUpdateContact_Success(t *testing.T) {
 // TestUpdateContact_FirstNameOnly tests updating only first name
 func TestUpdateContact_FirstNameOnly(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -375,7 +375,7 @@ func TestUpdateContact_FirstNameOnly(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		UpdateContactFunc: func(id, user_id uint, contact *contacts.Contact) error {
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
 			return nil
 		},
 	}
@@ -386,7 +386,7 @@ func TestUpdateContact_FirstNameOnly(t *testing.T) {
 		FirstName: "John Updated",
 	}
 
-	err := service.UpdateContact(1, 1, updateContact)
+	err := service.UpdateContact(1, 1, 0, updateContact)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -396,7 +396,7 @@ func TestUpdateContact_FirstNameOnly(t *testing.T) {
 // TestUpdateContact_EmailOnly tests updating only email
 func TestUpdateContact_EmailOnly(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -404,7 +404,7 @@ func TestUpdateContact_EmailOnly(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		UpdateContactFunc: func(id, user_id uint, contact *contacts.Contact) error {
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
 			return nil
 		},
 	}
@@ -415,7 +415,7 @@ func TestUpdateContact_EmailOnly(t *testing.T) {
 		Email: "john.new@example.com",
 	}
 
-	err := service.UpdateContact(1, 1, updateContact)
+	err := service.UpdateContact(1, 1, 0, updateContact)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -425,7 +425,7 @@ func TestUpdateContact_EmailOnly(t *testing.T) {
 // TestUpdateContact_NotFound tests updating non-existent contact
 func TestUpdateContact_NotFound(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
@@ -436,7 +436,7 @@ func TestUpdateContact_NotFound(t *testing.T) {
 		FirstName: "John Updated",
 	}
 
-	err := service.UpdateContact(999, 1, updateContact)
+	err := service.UpdateContact(999, 1, 0, updateContact)
 
 	if err == nil {
 		t.Error("Expected error for non-existent contact, got nil")
@@ -450,7 +450,7 @@ func TestUpdateContact_NotFound(t *testing.T) {
 // TestUpdateContact_WrongUser tests updating contact with wrong user_id (authorization)
 func TestUpdateContact_WrongUser(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			// Simulate contact not found because user_id doesn't match
 			return nil, gorm.ErrRecordNotFound
 		},
@@ -462,7 +462,7 @@ func TestUpdateContact_WrongUser(t *testing.T) {
 		FirstName: "John Updated",
 	}
 
-	err := service.UpdateContact(1, 999, updateContact)
+	err := service.UpdateContact(1, 999, 0, updateContact)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -476,7 +476,7 @@ func TestUpdateContact_WrongUser(t *testing.T) {
 // TestUpdateContact_DatabaseError tests update with database error
 func TestUpdateContact_DatabaseError(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -484,7 +484,7 @@ func TestUpdateContact_DatabaseError(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		UpdateContactFunc: func(id, user_id uint, contact *contacts.Contact) error {
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
 			return errors.New("database connection error")
 		},
 	}
@@ -495,7 +495,7 @@ func TestUpdateContact_DatabaseError(t *testing.T) {
 		FirstName: "John Updated",
 	}
 
-	err := service.UpdateContact(1, 1, updateContact)
+	err := service.UpdateContact(1, 1, 0, updateContact)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
@@ -507,7 +507,7 @@ func TestUpdateContact_DatabaseError(t *testing.T) {
 // TestDeleteContact_Success tests successful contact deletion
 func TestDeleteContact_Success(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -515,14 +515,14 @@ func TestDeleteContact_Success(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		DeleteContactFunc: func(id, user_id uint) error {
+		DeleteContactFunc: func(id, user_id, workspace_id uint) error {
 			return nil
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	err := service.DeleteContact(1, 1)
+	err := service.DeleteContact(1, 1, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -532,14 +532,14 @@ func TestDeleteContact_Success(t *testing.T) {
 // TestDeleteContact_NotFound tests deleting non-existent contact
 func TestDeleteContact_NotFound(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return nil, gorm.ErrRecordNotFound
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	err := service.DeleteContact(999, 1)
+	err := service.DeleteContact(999, 1, 0)
 
 	if err == nil {
 		t.Error("Expected error for non-existent contact, got nil")
@@ -553,7 +553,7 @@ func TestDeleteContact_NotFound(t *testing.T) {
 // TestDeleteContact_WrongUser tests deleting contact with wrong user_id (authorization)
 func TestDeleteContact_WrongUser(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			// Simulate contact not found because user_id doesn't match
 			return nil, gorm.ErrRecordNotFound
 		},
@@ -561,7 +561,7 @@ func TestDeleteContact_WrongUser(t *testing.T) {
 
 	service := contacts.NewContactService(mockRepo)
 
-	err := service.DeleteContact(1, 999)
+	err := service.DeleteContact(1, 999, 0)
 
 	if err == nil {
 		t.Error("Expected error for unauthorized access, got nil")
@@ -575,7 +575,7 @@ func TestDeleteContact_WrongUser(t *testing.T) {
 // TestDeleteContact_DatabaseError tests deletion with database error
 func TestDeleteContact_DatabaseError(t *testing.T) {
 	mockRepo := &MockContactRepository{
-		FindContactByIdFunc: func(id, user_id uint) (*contacts.Contact, error) {
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 			return &contacts.Contact{
 				ID: id,
 				UserID:    user_id,
@@ -583,16 +583,315 @@ func TestDeleteContact_DatabaseError(t *testing.T) {
 				Email:     "john@example.com",
 			}, nil
 		},
-		DeleteContactFunc: func(id, user_id uint) error {
+		DeleteContactFunc: func(id, user_id, workspace_id uint) error {
 			return errors.New("database connection error")
 		},
 	}
 
 	service := contacts.NewContactService(mockRepo)
 
-	err := service.DeleteContact(1, 1)
+	err := service.DeleteContact(1, 1, 0)
 
 	if err == nil {
 		t.Error("Expected database error, got nil")
 	}
 }
+
+// TestGetContacts_SortByLastInteraction tests sorting by the activity timeline
+func TestGetContacts_SortByLastInteraction(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{Sort: contacts.SortLastInteraction, Order: "desc"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received.SortColumns) != 1 || received.SortColumns[0] != "last_interaction_at" || received.SortField != nil {
+		t.Errorf("Expected sort on last_interaction_at, got %+v", received)
+	}
+}
+
+// TestGetContacts_SortFavorites tests listing favorites first, sorted by name
+func TestGetContacts_SortFavorites(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{Sort: contacts.SortFavorites})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !received.FavoritesFirst || len(received.SortColumns) != 2 || received.SortColumns[0] != "first_name" || received.Order != "asc" {
+		t.Errorf("Expected favorites first by name, got %+v", received)
+	}
+}
+
+// TestGetContacts_SortPreference tests that the stored preference applies without sort=
+func TestGetContacts_SortPreference(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		FindSortPreferenceFunc: func(user_id uint) (*contacts.SortPreference, error) {
+			return &contacts.SortPreference{Sort: contacts.SortCreatedAt, Order: "desc"}, nil
+		},
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received.SortColumns) != 1 || received.SortColumns[0] != "created_at" || received.Order != "desc" {
+		t.Errorf("Expected created_at desc from preference, got %+v", received)
+	}
+
+	_, err = service.GetContacts(1, 10, 1, "", contacts.ContactQuery{Sort: contacts.SortName})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received.SortColumns[0] != "first_name" || received.Order != "asc" {
+		t.Errorf("Expected sort= to override the preference, got %+v", received)
+	}
+}
+
+// TestGetContacts_StaleSortPreference tests falling back when the preferred custom field is gone
+func TestGetContacts_StaleSortPreference(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		FindSortPreferenceFunc: func(user_id uint) (*contacts.SortPreference, error) {
+			return &contacts.SortPreference{Sort: "cf.deleted", Order: "asc"}, nil
+		},
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received.SortField != nil || len(received.SortColumns) != 0 {
+		t.Errorf("Expected default order, got %+v", received)
+	}
+}
+
+// TestGetContacts_InvalidOrder tests rejecting an order other than asc or desc
+func TestGetContacts_InvalidOrder(t *testing.T) {
+	service := contacts.NewContactService(&MockContactRepository{})
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{Sort: contacts.SortName, Order: "up"})
+
+	if !errors.Is(err, contacts.ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
+
+// ========== Favorite Tests ==========
+
+// TestSetFavorite_Success tests marking an own contact as favorite
+func TestSetFavorite_Success(t *testing.T) {
+	var saved bool
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		SetFavoriteFunc: func(id uint, favorite bool) error {
+			saved = favorite
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	contact, err := service.SetFavorite(1, 1, 0, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !saved || !contact.Favorite {
+		t.Error("Expected contact to be a favorite")
+	}
+}
+
+// TestSetFavorite_ViewOnly tests that a view-only share cannot pin the contact
+func TestSetFavorite_ViewOnly(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 2}, nil
+		},
+		SetFavoriteFunc: func(id uint, favorite bool) error {
+			t.Error("Expected favorite not to be saved")
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.SetFavorite(1, 1, 0, true)
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// ========== SortPreference Tests ==========
+
+// TestSaveSortPreference_Normalizes tests storing a valid preference with a lowercase order
+func TestSaveSortPreference_Normalizes(t *testing.T) {
+	var saved contacts.SortPreference
+	mockRepo := &MockContactRepository{
+		SaveSortPreferenceFunc: func(user_id uint, preference contacts.SortPreference) error {
+			saved = preference
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.SaveSortPreference(1, contacts.SortPreference{Sort: contacts.SortUpdatedAt, Order: "DESC"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.Sort != "updated_at" || saved.Order != "desc" {
+		t.Errorf("Expected updated_at desc, got %+v", saved)
+	}
+}
+
+// TestSaveSortPreference_Invalid tests rejecting a sort outside the whitelist
+func TestSaveSortPreference_Invalid(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		SaveSortPreferenceFunc: func(user_id uint, preference contacts.SortPreference) error {
+			t.Error("Expected preference not to be saved")
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.SaveSortPreference(1, contacts.SortPreference{Sort: "password"})
+
+	if !errors.Is(err, contacts.ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
+
+// TestSortPreference_FavoritesFirst tests that favorites-first is accepted and lists favorites first
+func TestSortPreference_FavoritesFirst(t *testing.T) {
+	var saved contacts.SortPreference
+	var got contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		SaveSortPreferenceFunc: func(user_id uint, preference contacts.SortPreference) error {
+			saved = preference
+			return nil
+		},
+		FindSortPreferenceFunc: func(user_id uint) (*contacts.SortPreference, error) {
+			return &saved, nil
+		},
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			got = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	if _, err := service.SaveSortPreference(1, contacts.SortPreference{Sort: contacts.SortFavoritesFirst}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Sort != "favorites-first" || saved.Order != "asc" {
+		t.Errorf("Expected favorites-first asc, got %+v", saved)
+	}
+
+	if _, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !got.FavoritesFirst || len(got.SortColumns) != 2 || got.SortColumns[0] != "first_name" {
+		t.Errorf("Expected favorites first, then by name, got %+v", got)
+	}
+}
+
+// ========== Phone Backfill Tests ==========
+
+// TestBackfillPhoneE164 tests filling in stored phone numbers in the owner's region
+func TestBackfillPhoneE164(t *testing.T) {
+	saved := make(map[uint]string)
+	var region_lookups []uint
+	mockRepo := &MockContactRepository{
+		GetContactsWithoutPhoneE164Func: func(after_id uint, limit int) ([]contacts.Contact, error) {
+			stored := []contacts.Contact{
+				{ID: 1, UserID: 7, Phone: "0812-3456-789"},
+				{ID: 2, UserID: 8, Phone: "(415) 555-2671"},
+				{ID: 3, UserID: 7, Phone: "not a number"},
+				{ID: 4, UserID: 7, Phone: "+44 20 7946 0958"},
+			}
+			var batch []contacts.Contact
+			for _, contact := range stored {
+				if contact.ID > after_id && len(batch) < limit {
+					batch = append(batch, contact)
+				}
+			}
+			return batch, nil
+		},
+		FindUserRegionFunc: func(user_id uint) (string, error) {
+			region_lookups = append(region_lookups, user_id)
+			if user_id == 8 {
+				return "US", nil
+			}
+			return "ID", nil
+		},
+		SetPhoneE164Func: func(id uint, phone_e164 string) error {
+			saved[id] = phone_e164
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	filled, err := service.BackfillPhoneE164()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filled != 3 || len(saved) != 3 {
+		t.Fatalf("Expected 3 contacts filled in, got %d: %v", filled, saved)
+	}
+	if saved[1] != "+628123456789" || saved[2] != "+14155552671" || saved[4] != "+442079460958" {
+		t.Errorf("Unexpected E.164 numbers %v", saved)
+	}
+	if len(region_lookups) != 2 {
+		t.Errorf("Expected one region lookup per owner, got %v", region_lookups)
+	}
+}
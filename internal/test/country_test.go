@@ -0,0 +1,175 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+)
+
+// ========== Country Lookup Tests ==========
+
+// TestCountryLookup_Spellings tests finding a country by code, name and alias
+func TestCountryLookup_Spellings(t *testing.T) {
+	tests := []struct {
+		input string
+		code  string
+	}{
+		{"ID", "ID"},
+		{"idn", "ID"},
+		{"Indonesia", "ID"},
+		{"  republik indonesia ", "ID"},
+		{"U.S.A.", "US"},
+		{"United States of America", "US"},
+		{"UK", "GB"},
+		{"Cote d'Ivoire", "CI"},
+		{"Österreich", "AT"},
+	}
+
+	for _, tt := range tests {
+		c, err := country.Lookup(tt.input)
+		if err != nil {
+			t.Errorf("Lookup(%q): expected no error, got %v", tt.input, err)
+			continue
+		}
+
+		if c.Code != tt.code {
+			t.Errorf("Lookup(%q): expected %s, got %s", tt.input, tt.code, c.Code)
+		}
+	}
+}
+
+// TestCountryLookup_Unknown tests rejecting a value that is not a country
+func TestCountryLookup_Unknown(t *testing.T) {
+	_, err := country.Lookup("Atlantis")
+
+	if !errors.Is(err, country.ErrUnknownCountry) {
+		t.Errorf("Expected ErrUnknownCountry, got %v", err)
+	}
+}
+
+// TestCountryAll_Complete tests that the embedded data covers ISO 3166-1
+func TestCountryAll_Complete(t *testing.T) {
+	all := country.All()
+
+	if len(all) != 249 {
+		t.Errorf("Expected 249 countries, got %d", len(all))
+	}
+
+	if country.Name("DE") != "Germany" || country.Name("Deutschland") != "Deutschland" {
+		t.Errorf("Expected names for codes only, got %q and %q", country.Name("DE"), country.Name("Deutschland"))
+	}
+}
+
+// ========== Postal Code Tests ==========
+
+// TestNormalizePostalCode_Formats tests normalizing and checking postal codes per country
+func TestNormalizePostalCode_Formats(t *testing.T) {
+	tests := []struct {
+		country string
+		input   string
+		output  string
+		valid   bool
+	}{
+		{"ID", "40115", "40115", true},
+		{"ID", "4011", "", false},
+		{"US", "94105-1234", "94105-1234", true},
+		{"GB", "sw1a  1aa", "SW1A 1AA", true},
+		{"CA", "k1a0b1", "K1A0B1", true},
+		{"NL", "1012 js", "1012 JS", true},
+		{"JP", "ABC-DEFG", "", false},
+		{"AE", "anything", "ANYTHING", true},
+		{"DE", "", "", true},
+	}
+
+	for _, tt := range tests {
+		c, _ := country.Lookup(tt.country)
+		output, err := c.NormalizePostalCode(tt.input)
+
+		if tt.valid && err != nil {
+			t.Errorf("%s %q: expected no error, got %v", tt.country, tt.input, err)
+			continue
+		}
+
+		if !tt.valid {
+			if !errors.Is(err, country.ErrInvalidPostalCode) {
+				t.Errorf("%s %q: expected ErrInvalidPostalCode, got %v", tt.country, tt.input, err)
+			}
+			continue
+		}
+
+		if output != tt.output {
+			t.Errorf("%s %q: expected %q, got %q", tt.country, tt.input, tt.output, output)
+		}
+	}
+}
+
+// ========== Subdivision Tests ==========
+
+// TestNormalizeSubdivision_Styles tests that states are written the way each country writes them
+func TestNormalizeSubdivision_Styles(t *testing.T) {
+	us, _ := country.Lookup("US")
+	if state, err := us.NormalizeSubdivision("california"); err != nil || state != "CA" {
+		t.Errorf("Expected CA, got %q (%v)", state, err)
+	}
+
+	id, _ := country.Lookup("ID")
+	if state, err := id.NormalizeSubdivision("west java"); err != nil || state != "Jawa Barat" {
+		t.Errorf("Expected Jawa Barat, got %q (%v)", state, err)
+	}
+
+	if state, err := id.NormalizeSubdivision(""); err != nil || state != "" {
+		t.Errorf("Expected an optional province, got %q (%v)", state, err)
+	}
+}
+
+// TestNormalizeSubdivision_Errors tests missing and unknown states
+func TestNormalizeSubdivision_Errors(t *testing.T) {
+	us, _ := country.Lookup("US")
+
+	if _, err := us.NormalizeSubdivision(" "); !errors.Is(err, country.ErrSubdivisionRequired) {
+		t.Errorf("Expected ErrSubdivisionRequired, got %v", err)
+	}
+
+	if _, err := us.NormalizeSubdivision("Ontario"); !errors.Is(err, country.ErrUnknownSubdivision) {
+		t.Errorf("Expected ErrUnknownSubdivision, got %v", err)
+	}
+}
+
+// ========== Address Normalize Tests ==========
+
+// TestAddressNormalize_Canonical tests rewriting an address in canonical form
+func TestAddressNormalize_Canonical(t *testing.T) {
+	address := addresses.Address{City: "San Francisco", State: "California", PostalCode: " 94105 ", Country: "United States of America"}
+
+	if err := addresses.Normalize(&address); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if address.Country != "US" || address.State != "CA" || address.PostalCode != "94105" {
+		t.Errorf("Unexpected address %+v", address)
+	}
+
+	data, _ := json.Marshal(address)
+	if !strings.Contains(string(data), `"country":"US"`) || !strings.Contains(string(data), `"country_name":"United States"`) {
+		t.Errorf("Expected the country code and name, got %s", data)
+	}
+}
+
+// TestAddressNormalize_Invalid tests that validation errors are told apart from database errors
+func TestAddressNormalize_Invalid(t *testing.T) {
+	address := addresses.Address{PostalCode: "123", Country: "Indonesia"}
+
+	err := addresses.Normalize(&address)
+
+	if !errors.Is(err, country.ErrInvalidPostalCode) || !addresses.IsValidationError(err) {
+		t.Errorf("Expected a postal code validation error, got %v", err)
+	}
+
+	if addresses.IsValidationError(errors.New("database connection error")) {
+		t.Error("Expected database errors not to be validation errors")
+	}
+}
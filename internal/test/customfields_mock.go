@@ -0,0 +1,63 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+)
+
+// MockCustomFieldRepository implements customfields.CustomFieldRepository interface
+type MockCustomFieldRepository struct {
+	GetFieldsFunc      func(user_id uint) ([]customfields.Field, error)
+	FindFieldByIdFunc  func(id, user_id uint) (*customfields.Field, error)
+	FindFieldByKeyFunc func(user_id uint, key string) (*customfields.Field, error)
+	CreateFieldFunc    func(field *customfields.Field) error
+	UpdateFieldFunc    func(field *customfields.Field) error
+	DeleteFieldFunc    func(id, user_id uint) error
+}
+
+// GetFields implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) GetFields(user_id uint) ([]customfields.Field, error) {
+	if m.GetFieldsFunc != nil {
+		return m.GetFieldsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindFieldById implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) FindFieldById(id, user_id uint) (*customfields.Field, error) {
+	if m.FindFieldByIdFunc != nil {
+		return m.FindFieldByIdFunc(id, user_id)
+	}
+	return nil, nil
+}
+
+// FindFieldByKey implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) FindFieldByKey(user_id uint, key string) (*customfields.Field, error) {
+	if m.FindFieldByKeyFunc != nil {
+		return m.FindFieldByKeyFunc(user_id, key)
+	}
+	return nil, nil
+}
+
+// CreateField implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) CreateField(field *customfields.Field) error {
+	if m.CreateFieldFunc != nil {
+		return m.CreateFieldFunc(field)
+	}
+	return nil
+}
+
+// UpdateField implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) UpdateField(field *customfields.Field) error {
+	if m.UpdateFieldFunc != nil {
+		return m.UpdateFieldFunc(field)
+	}
+	return nil
+}
+
+// DeleteField implements customfields.CustomFieldRepository
+func (m *MockCustomFieldRepository) DeleteField(id, user_id uint) error {
+	if m.DeleteFieldFunc != nil {
+		return m.DeleteFieldFunc(id, user_id)
+	}
+	return nil
+}
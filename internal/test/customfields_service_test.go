@@ -0,0 +1,294 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"gorm.io/gorm"
+)
+
+func testCustomFields() []customfields.Field {
+	return []customfields.Field{
+		{ID: 1, UserID: 1, Key: "company", Label: "Company", Type: customfields.TypeText, Required: true},
+		{ID: 2, UserID: 1, Key: "score", Label: "Score", Type: customfields.TypeNumber},
+		{ID: 3, UserID: 1, Key: "tier", Label: "Tier", Type: customfields.TypeEnum, Options: []string{"gold", "silver"}},
+	}
+}
+
+// ========== Parse Tests ==========
+
+// TestCustomFieldParse tests value validation for every field type
+func TestCustomFieldParse(t *testing.T) {
+	tests := []struct {
+		field   customfields.Field
+		raw     any
+		want    string
+		wantErr bool
+	}{
+		{customfields.Field{Key: "note", Type: customfields.TypeText}, " hello ", "hello", false},
+		{customfields.Field{Key: "score", Type: customfields.TypeNumber}, 12.50, "12.5", false},
+		{customfields.Field{Key: "score", Type: customfields.TypeNumber}, "12.50", "12.5", false},
+		{customfields.Field{Key: "score", Type: customfields.TypeNumber}, "abc", "", true},
+		{customfields.Field{Key: "since", Type: customfields.TypeDate}, "2024-02-29", "2024-02-29", false},
+		{customfields.Field{Key: "since", Type: customfields.TypeDate}, "29/02/2024", "", true},
+		{customfields.Field{Key: "tier", Type: customfields.TypeEnum, Options: []string{"gold"}}, "gold", "gold", false},
+		{customfields.Field{Key: "tier", Type: customfields.TypeEnum, Options: []string{"gold"}}, "bronze", "", true},
+		{customfields.Field{Key: "site", Type: customfields.TypeURL}, "https://example.com", "https://example.com", false},
+		{customfields.Field{Key: "site", Type: customfields.TypeURL}, "ftp://example.com", "", true},
+		{customfields.Field{Key: "note", Type: customfields.TypeText}, true, "", true},
+		{customfields.Field{Key: "note", Type: customfields.TypeText}, nil, "", false},
+	}
+
+	for _, tt := range tests {
+		value, err := tt.field.Parse(tt.raw)
+		if tt.wantErr {
+			if !errors.Is(err, customfields.ErrInvalidValue) {
+				t.Errorf("Parse(%s, %v): expected ErrInvalidValue, got %v", tt.field.Type, tt.raw, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%s, %v): expected no error, got %v", tt.field.Type, tt.raw, err)
+			continue
+		}
+		if value.Value != tt.want {
+			t.Errorf("Parse(%s, %v): expected %q, got %q", tt.field.Type, tt.raw, tt.want, value.Value)
+		}
+	}
+}
+
+// ========== CreateField Tests ==========
+
+// TestCreateField_Success tests successful custom field creation
+func TestCreateField_Success(t *testing.T) {
+	mockRepo := &MockCustomFieldRepository{
+		FindFieldByKeyFunc: func(user_id uint, key string) (*customfields.Field, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		CreateFieldFunc: func(field *customfields.Field) error {
+			field.ID = 1
+			return nil
+		},
+	}
+
+	service := customfields.NewCustomFieldService(mockRepo)
+
+	field, err := service.CreateField(1, customfields.CreateFieldRequest{
+		Key:     "tier",
+		Label:   "Tier",
+		Type:    customfields.TypeEnum,
+		Options: []string{"gold", "silver"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if field.ID != 1 || field.UserID != 1 {
+		t.Errorf("Expected field 1 owned by user 1, got %+v", field)
+	}
+}
+
+// TestCreateField_InvalidKey tests that keys unusable in query parameters are rejected
+func TestCreateField_InvalidKey(t *testing.T) {
+	service := customfields.NewCustomFieldService(&MockCustomFieldRepository{})
+
+	_, err := service.CreateField(1, customfields.CreateFieldRequest{Key: "Company Name", Label: "Company", Type: customfields.TypeText})
+
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+}
+
+// TestCreateField_EnumWithoutOptions tests that enum fields need options
+func TestCreateField_EnumWithoutOptions(t *testing.T) {
+	service := customfields.NewCustomFieldService(&MockCustomFieldRepository{})
+
+	_, err := service.CreateField(1, customfields.CreateFieldRequest{Key: "tier", Label: "Tier", Type: customfields.TypeEnum})
+
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+}
+
+// TestCreateField_DuplicateKey tests creation with a key the user already has
+func TestCreateField_DuplicateKey(t *testing.T) {
+	mockRepo := &MockCustomFieldRepository{
+		FindFieldByKeyFunc: func(user_id uint, key string) (*customfields.Field, error) {
+			return &customfields.Field{ID: 1, Key: key}, nil
+		},
+	}
+
+	service := customfields.NewCustomFieldService(mockRepo)
+
+	_, err := service.CreateField(1, customfields.CreateFieldRequest{Key: "company", Label: "Company", Type: customfields.TypeText})
+
+	if err == nil || err.Error() != "custom field with this key already exists" {
+		t.Errorf("Expected duplicate key error, got %v", err)
+	}
+}
+
+// ========== DeleteField Tests ==========
+
+// TestDeleteField_NotFound tests deleting a field of another user
+func TestDeleteField_NotFound(t *testing.T) {
+	mockRepo := &MockCustomFieldRepository{
+		FindFieldByIdFunc: func(id, user_id uint) (*customfields.Field, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := customfields.NewCustomFieldService(mockRepo)
+
+	err := service.DeleteField(1, 2)
+
+	if err == nil || err.Error() != "custom field not found" {
+		t.Errorf("Expected 'custom field not found' error, got %v", err)
+	}
+}
+
+// ========== Contact Custom Field Tests ==========
+
+// TestCreateContact_WithCustomFields tests that custom values are validated and normalized
+func TestCreateContact_WithCustomFields(t *testing.T) {
+	var saved contacts.Contact
+	mockRepo := &MockContactRepository{
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return testCustomFields(), nil
+		},
+		CreateContactFunc: func(contact contacts.Contact) (*contacts.ContactResponse, error) {
+			saved = contact
+			return &contacts.ContactResponse{ID: 1, CustomFields: contact.CustomFields}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	result, err := service.CreateContact(contacts.Contact{
+		UserID:       1,
+		FirstName:    "John",
+		CustomFields: map[string]any{"company": "Acme", "score": "7.50", "tier": ""},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(saved.CustomValues) != 2 {
+		t.Errorf("Expected 2 custom values to be saved, got %d", len(saved.CustomValues))
+	}
+
+	if result.CustomFields["score"] != 7.5 {
+		t.Errorf("Expected score 7.5, got %v", result.CustomFields["score"])
+	}
+}
+
+// TestCreateContact_MissingRequiredCustomField tests creation without a required field
+func TestCreateContact_MissingRequiredCustomField(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return testCustomFields(), nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.CreateContact(contacts.Contact{UserID: 1, FirstName: "John"})
+
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+}
+
+// TestUpdateContact_UnknownCustomField tests update with a key the user has not defined
+func TestUpdateContact_UnknownCustomField(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id, FirstName: "John"}, nil
+		},
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return testCustomFields(), nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(1, 1, 0, contacts.Contact{CustomFields: map[string]any{"birthday": "2000-01-01"}})
+
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+}
+
+// TestUpdateContact_ClearCustomField tests that an empty value removes an optional field
+func TestUpdateContact_ClearCustomField(t *testing.T) {
+	var updated *contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id, FirstName: "John"}, nil
+		},
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return testCustomFields(), nil
+		},
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			updated = contact
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	if err := service.UpdateContact(1, 1, 0, contacts.Contact{CustomFields: map[string]any{"tier": nil}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(updated.CustomValues) != 1 || updated.CustomValues[0].FieldID != 3 || updated.CustomValues[0].Value != "" {
+		t.Errorf("Expected a removal of field 3, got %+v", updated.CustomValues)
+	}
+
+	err := service.UpdateContact(1, 1, 0, contacts.Contact{CustomFields: map[string]any{"company": ""}})
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue when clearing a required field, got %v", err)
+	}
+}
+
+// TestGetContacts_CustomFieldFilterAndSort tests that field keys are resolved to ids
+func TestGetContacts_CustomFieldFilterAndSort(t *testing.T) {
+	var received contacts.ContactFilter
+	mockRepo := &MockContactRepository{
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return testCustomFields(), nil
+		},
+		GetContactsFunc: func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
+			received = filter
+			return &contacts.GetContactsResponse{}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetContacts(1, 10, 1, "", contacts.ContactQuery{
+		CustomFields: map[string]string{"tier": "gold"},
+		Sort:         "cf.score",
+		Order:        "DESC",
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received.CustomFields) != 1 || received.CustomFields[0].FieldID != 3 || received.CustomFields[0].Value != "gold" {
+		t.Errorf("Expected filter on field 3, got %+v", received.CustomFields)
+	}
+
+	if received.SortField == nil || received.SortField.ID != 2 || received.Order != "desc" {
+		t.Errorf("Expected descending sort on field 2, got %+v %s", received.SortField, received.Order)
+	}
+
+	_, err = service.GetContacts(1, 10, 1, "", contacts.ContactQuery{Sort: "first_name"})
+	if !errors.Is(err, contacts.ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
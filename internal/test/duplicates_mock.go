@@ -0,0 +1,34 @@
+package test
+
+import "github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+// MockDuplicateRepository implements duplicates.DuplicateRepository interface
+type MockDuplicateRepository struct {
+	GetContactsFunc     func(user_id, workspace_id uint) ([]contacts.Contact, error)
+	FindContactByIdFunc func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	MergeContactsFunc   func(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error)
+}
+
+// GetContacts implements duplicates.DuplicateRepository
+func (m *MockDuplicateRepository) GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error) {
+	if m.GetContactsFunc != nil {
+		return m.GetContactsFunc(user_id, workspace_id)
+	}
+	return nil, nil
+}
+
+// FindContactById implements duplicates.DuplicateRepository
+func (m *MockDuplicateRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	if m.FindContactByIdFunc != nil {
+		return m.FindContactByIdFunc(id, user_id, workspace_id, permission)
+	}
+	return nil, nil
+}
+
+// MergeContacts implements duplicates.DuplicateRepository
+func (m *MockDuplicateRepository) MergeContacts(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error) {
+	if m.MergeContactsFunc != nil {
+		return m.MergeContactsFunc(user_id, survivor, loser_id)
+	}
+	return 0, nil
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/duplicates"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+// ========== Duplicates Repository Integration Tests ==========
+
+// TestDuplicateRepository_MergeContacts_BothPrimary_Integration tests merging two contacts that both have a primary home address
+func TestDuplicateRepository_MergeContacts_BothPrimary_Integration(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDBAfter(t, db)
+
+	repo := duplicates.NewDuplicateRepository(db)
+
+	user1 := users.User{Name: "User 1", Email: "user1@example.com", Password: "hash1"}
+	db.Create(&user1)
+
+	survivor := contacts.Contact{UserID: user1.ID, FirstName: "John", Email: "john@example.com"}
+	db.Create(&survivor)
+	loser := contacts.Contact{UserID: user1.ID, FirstName: "Johnny", Email: "johnny@example.com"}
+	db.Create(&loser)
+
+	kept := addresses.Address{ContactID: survivor.ID, Type: addresses.TypeHome, IsPrimary: true, Street: "1 Main St", Country: "US"}
+	db.Create(&kept)
+	moved := addresses.Address{ContactID: loser.ID, Type: addresses.TypeHome, IsPrimary: true, Street: "2 Side St", Country: "US"}
+	db.Create(&moved)
+
+	count, err := repo.MergeContacts(user1.ID, &survivor, loser.ID)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("Expected 1 address moved, got %d", count)
+	}
+
+	var address_list []addresses.Address
+	db.Where("contact_id = ?", survivor.ID).Order("address_id").Find(&address_list)
+	if len(address_list) != 2 {
+		t.Fatalf("Expected 2 addresses on the survivor, got %d", len(address_list))
+	}
+
+	if !address_list[0].IsPrimary || address_list[1].IsPrimary {
+		t.Errorf("Expected only the survivor's own address to stay primary, got %v and %v", address_list[0].IsPrimary, address_list[1].IsPrimary)
+	}
+
+	var remaining int64
+	db.Model(&contacts.Contact{}).Where("contact_id = ?", loser.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Error("Expected the loser to be deleted")
+	}
+}
@@ -0,0 +1,248 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/duplicates"
+	"gorm.io/gorm"
+)
+
+// ========== Score Tests ==========
+
+// TestDuplicateScore tests scoring on normalized email, phone and name
+func TestDuplicateScore(t *testing.T) {
+	a := contacts.Contact{FirstName: "John", LastName: "Doe", Email: "John+crm@Example.com", Phone: "0812-3456-789"}
+	b := contacts.Contact{FirstName: "Jon", LastName: "Doe", Email: "john@example.com", Phone: "+62 812 3456 789"}
+
+	score, reasons := duplicates.Score(a, b)
+
+	if len(reasons) != 3 {
+		t.Errorf("Expected email, phone and name reasons, got %v", reasons)
+	}
+
+	if score < 0.95 {
+		t.Errorf("Expected score close to 1, got %f", score)
+	}
+
+	score, _ = duplicates.Score(a, contacts.Contact{FirstName: "Alice", Email: "alice@example.com"})
+	if score != 0 {
+		t.Errorf("Expected score 0 for unrelated contacts, got %f", score)
+	}
+}
+
+// ========== FindDuplicates Tests ==========
+
+// TestFindDuplicates_Success tests that likely duplicates are returned ordered by score
+func TestFindDuplicates_Success(t *testing.T) {
+	mockRepo := &MockDuplicateRepository{
+		GetContactsFunc: func(user_id, workspace_id uint) ([]contacts.Contact, error) {
+			return []contacts.Contact{
+				{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "08123456789"},
+				{ID: 2, FirstName: "Jane", LastName: "Smith", Email: "jane@example.com"},
+				{ID: 3, FirstName: "Johnny", LastName: "Doe", Email: "JOHN@example.com"},
+				{ID: 4, FirstName: "Jon", LastName: "Doe", Email: "other@example.com", Phone: "+628123456789"},
+			}, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	result, err := service.FindDuplicates(1, 0, duplicates.DefaultMinScore)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 duplicate pairs, got %d (%+v)", result.Total, result.Data)
+	}
+
+	if result.Data[0].ContactA.ID != 1 || result.Data[0].ContactB.ID != 3 {
+		t.Errorf("Expected pair 1-3 first, got %d-%d", result.Data[0].ContactA.ID, result.Data[0].ContactB.ID)
+	}
+
+	if result.Data[0].Score < result.Data[1].Score {
+		t.Error("Expected pairs ordered by score")
+	}
+}
+
+// ========== MergeContacts Tests ==========
+
+// TestMergeContacts_Success tests field winners and the merge call
+func TestMergeContacts_Success(t *testing.T) {
+	var merged *contacts.Contact
+	var mergedLoser uint
+	mockRepo := &MockDuplicateRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if id == 1 {
+				return &contacts.Contact{ID: 1, UserID: user_id, FirstName: "Jon", Email: "john@example.com"}, nil
+			}
+			return &contacts.Contact{ID: 2, UserID: user_id, FirstName: "John", LastName: "Doe", Email: "old@example.com", Phone: "0812", PhoneE164: "+62812"}, nil
+		},
+		MergeContactsFunc: func(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error) {
+			merged = survivor
+			mergedLoser = loser_id
+			return 2, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	response, err := service.MergeContacts(1, 0, duplicates.MergeRequest{
+		SurvivorID: 1,
+		LoserID:    2,
+		Fields:     map[string]string{"first_name": "loser"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if mergedLoser != 2 || merged.ID != 1 {
+		t.Errorf("Expected contact 2 to be merged into 1, got %d into %d", mergedLoser, merged.ID)
+	}
+
+	if merged.FirstName != "John" {
+		t.Errorf("Expected first name from loser 'John', got '%s'", merged.FirstName)
+	}
+
+	if merged.Email != "john@example.com" {
+		t.Errorf("Expected survivor email to win, got '%s'", merged.Email)
+	}
+
+	if merged.LastName != "Doe" || merged.Phone != "0812" {
+		t.Errorf("Expected empty survivor fields to be filled from loser, got '%s' '%s'", merged.LastName, merged.Phone)
+	}
+
+	if merged.PhoneE164 != "+62812" {
+		t.Errorf("Expected the E.164 form of the loser's phone, got '%s'", merged.PhoneE164)
+	}
+
+	if response.MovedAddresses != 2 {
+		t.Errorf("Expected 2 moved addresses, got %d", response.MovedAddresses)
+	}
+}
+
+// TestMergeContacts_KeepsSurvivorPhone tests that the survivor keeps its own E.164 form with its phone
+func TestMergeContacts_KeepsSurvivorPhone(t *testing.T) {
+	var merged *contacts.Contact
+	mockRepo := &MockDuplicateRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if id == 1 {
+				return &contacts.Contact{ID: 1, Phone: "0812-1111-2222", PhoneE164: "+6281211112222"}, nil
+			}
+			return &contacts.Contact{ID: 2, Phone: "0813-3333-4444", PhoneE164: "+6281333334444"}, nil
+		},
+		MergeContactsFunc: func(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error) {
+			merged = survivor
+			return 0, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	if _, err := service.MergeContacts(1, 0, duplicates.MergeRequest{SurvivorID: 1, LoserID: 2}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if merged.Phone != "0812-1111-2222" || merged.PhoneE164 != "+6281211112222" {
+		t.Errorf("Expected the survivor's phone, got '%s' '%s'", merged.Phone, merged.PhoneE164)
+	}
+
+	if _, err := service.MergeContacts(1, 0, duplicates.MergeRequest{SurvivorID: 1, LoserID: 2, Fields: map[string]string{"phone": "loser"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if merged.Phone != "0813-3333-4444" || merged.PhoneE164 != "+6281333334444" {
+		t.Errorf("Expected the loser's phone, got '%s' '%s'", merged.Phone, merged.PhoneE164)
+	}
+}
+
+// TestMergeContacts_NotFound tests merging a contact of another user
+func TestMergeContacts_NotFound(t *testing.T) {
+	mockRepo := &MockDuplicateRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	_, err := service.MergeContacts(1, 0, duplicates.MergeRequest{SurvivorID: 1, LoserID: 2})
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
+
+// TestMergeContacts_InvalidField tests rejecting unknown merge fields
+func TestMergeContacts_InvalidField(t *testing.T) {
+	mockRepo := &MockDuplicateRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		MergeContactsFunc: func(user_id uint, survivor *contacts.Contact, loser_id uint) (int64, error) {
+			t.Error("Expected merge not to run")
+			return 0, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	_, err := service.MergeContacts(1, 0, duplicates.MergeRequest{SurvivorID: 1, LoserID: 2, Fields: map[string]string{"password": "loser"}})
+
+	if err == nil {
+		t.Error("Expected error for unknown field, got nil")
+	}
+}
+
+// ========== Duplicates Workspace Tests ==========
+
+// TestFindDuplicates_Workspace tests looking for duplicates among the contacts of the active workspace
+func TestFindDuplicates_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockDuplicateRepository{
+		GetContactsFunc: func(user_id, workspace_id uint) ([]contacts.Contact, error) {
+			gotWorkspace = workspace_id
+			return []contacts.Contact{
+				{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+				{ID: 2, FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+			}, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	result, err := service.FindDuplicates(1, 3, duplicates.DefaultMinScore)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 || result.Total != 1 {
+		t.Errorf("Expected 1 pair in workspace 3, got %d in workspace %d", result.Total, gotWorkspace)
+	}
+}
+
+// TestMergeContacts_Workspace tests that merging needs edit permission in the active workspace
+func TestMergeContacts_Workspace(t *testing.T) {
+	var gotWorkspaces []uint
+	var gotPermission string
+	mockRepo := &MockDuplicateRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			gotWorkspaces = append(gotWorkspaces, workspace_id)
+			gotPermission = permission
+			return &contacts.Contact{ID: id, FirstName: "John"}, nil
+		},
+	}
+
+	service := duplicates.NewDuplicateService(mockRepo)
+
+	if _, err := service.MergeContacts(1, 3, duplicates.MergeRequest{SurvivorID: 1, LoserID: 2}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(gotWorkspaces) != 2 || gotWorkspaces[0] != 3 || gotWorkspaces[1] != 3 {
+		t.Errorf("Expected both contacts in workspace 3, got %v", gotWorkspaces)
+	}
+	if gotPermission != contacts.PermissionEdit {
+		t.Errorf("Expected an edit check, got %q", gotPermission)
+	}
+}
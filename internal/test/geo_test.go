@@ -0,0 +1,280 @@
+package test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// MockGeocoder is a mock implementation of geo.Geocoder
+type MockGeocoder struct {
+	GeocodeFunc func(q geo.Query) (*geo.Point, error)
+}
+
+// Geocode implements geo.Geocoder
+func (m *MockGeocoder) Geocode(q geo.Query) (*geo.Point, error) {
+	if m.GeocodeFunc != nil {
+		return m.GeocodeFunc(q)
+	}
+	return nil, geo.ErrNotFound
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// ========== Geo Tests ==========
+
+// TestDistance tests the great-circle distance between two cities
+func TestDistance(t *testing.T) {
+	jakarta := geo.Point{Lat: -6.2088, Lng: 106.8456}
+	bandung := geo.Point{Lat: -6.9175, Lng: 107.6191}
+
+	distance := geo.Distance(jakarta, bandung)
+
+	if math.Abs(distance-116.5) > 1 {
+		t.Errorf("Expected about 116.5 km, got %f", distance)
+	}
+	if geo.Distance(jakarta, jakarta) != 0 {
+		t.Errorf("Expected 0 km to the same point")
+	}
+}
+
+// TestAround_CrossesAntimeridian tests a box around a point close to longitude 180
+func TestAround_CrossesAntimeridian(t *testing.T) {
+	box := geo.Around(geo.Point{Lat: -17.7, Lng: 179.9}, 50)
+
+	if !box.CrossesAntimeridian() {
+		t.Fatalf("Expected the box to cross the antimeridian, got %+v", box)
+	}
+	if box.MinLng < 179 || box.MaxLng > -179 {
+		t.Errorf("Unexpected longitudes %+v", box)
+	}
+}
+
+// TestAround_Pole tests that a box reaching a pole spans every longitude
+func TestAround_Pole(t *testing.T) {
+	box := geo.Around(geo.Point{Lat: 89.9, Lng: 10}, 50)
+
+	if box.MaxLat != 90 || box.MinLng != -180 || box.MaxLng != 180 {
+		t.Errorf("Unexpected box %+v", box)
+	}
+}
+
+// TestOfflineGeocoder tests locating by postal code, then by city
+func TestOfflineGeocoder(t *testing.T) {
+	geocoder := geo.NewOfflineGeocoder()
+
+	byPostalCode, err := geocoder.Geocode(geo.Query{Country: "GB", City: "London", PostalCode: "sw1a2aa"})
+	if err != nil || math.Abs(byPostalCode.Lat-51.5034) > 0.001 {
+		t.Errorf("Expected Downing Street, got %+v %v", byPostalCode, err)
+	}
+
+	byCity, err := geocoder.Geocode(geo.Query{Country: "BR", City: "Sao Paulo", PostalCode: "04000-000"})
+	if err != nil || math.Abs(byCity.Lat+23.5505) > 0.001 {
+		t.Errorf("Expected the centre of São Paulo, got %+v %v", byCity, err)
+	}
+
+	if _, err := geocoder.Geocode(geo.Query{Country: "US", City: "Bandung"}); !errors.Is(err, geo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a city of another country, got %v", err)
+	}
+}
+
+// ========== Address Geocoding Tests ==========
+
+// TestCreateAddress_Geocodes tests locating a new address after it is normalized
+func TestCreateAddress_Geocodes(t *testing.T) {
+	var saved addresses.CreateAddressRequest
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+			saved = address
+			return &addresses.AddressResponse{}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, geo.NewOfflineGeocoder(), "")
+
+	_, err := service.CreateAddress(1, 0, 2, addresses.CreateAddressRequest{City: "Bandung", State: "Jawa Barat", Country: "Indonesia"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Latitude == nil || saved.Longitude == nil || math.Abs(*saved.Latitude+6.9175) > 0.001 {
+		t.Errorf("Expected the coordinates of Bandung, got %v %v", saved.Latitude, saved.Longitude)
+	}
+}
+
+// TestCreateAddress_GivenCoordinates tests that coordinates from the client are kept
+func TestCreateAddress_GivenCoordinates(t *testing.T) {
+	var saved addresses.CreateAddressRequest
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+		CreateAddressFunc: func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+			saved = address
+			return &addresses.AddressResponse{}, nil
+		},
+	}
+	geocoder := &MockGeocoder{GeocodeFunc: func(q geo.Query) (*geo.Point, error) {
+		t.Fatalf("Expected no geocoding")
+		return nil, nil
+	}}
+
+	service := addresses.NewAddressService(mockRepo, geocoder, "")
+
+	_, err := service.CreateAddress(1, 0, 2, addresses.CreateAddressRequest{City: "Bandung", State: "Jawa Barat", Country: "ID", Latitude: float(-6.9), Longitude: float(107.6)})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *saved.Latitude != -6.9 || *saved.Longitude != 107.6 {
+		t.Errorf("Expected the given coordinates, got %v %v", *saved.Latitude, *saved.Longitude)
+	}
+}
+
+// TestCreateAddress_HalfCoordinates tests rejecting a latitude without a longitude
+func TestCreateAddress_HalfCoordinates(t *testing.T) {
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	_, err := service.CreateAddress(1, 0, 2, addresses.CreateAddressRequest{Country: "ID", Latitude: float(-6.9)})
+
+	if !errors.Is(err, addresses.ErrInvalidCoordinates) {
+		t.Errorf("Expected ErrInvalidCoordinates, got %v", err)
+	}
+}
+
+// TestUpdateAddress_GeocodesOnlyWhenMoved tests that an unchanged location keeps its coordinates
+func TestUpdateAddress_GeocodesOnlyWhenMoved(t *testing.T) {
+	calls := 0
+	geocoder := &MockGeocoder{GeocodeFunc: func(q geo.Query) (*geo.Point, error) {
+		calls++
+		return &geo.Point{Lat: 1, Lng: 2}, nil
+	}}
+	var saved *addresses.Address
+	mockRepo := &MockAddressRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+		FindContactAddressFunc: func(contact_id, address_id uint) (*addresses.Address, error) {
+			return &addresses.Address{ID: address_id, ContactID: contact_id, City: "Bandung", State: "Jawa Barat", Country: "ID", Latitude: float(-6.9), Longitude: float(107.6)}, nil
+		},
+		UpdateAddressFunc: func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+			saved = address
+			return &addresses.AddressResponse{}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, geocoder, "")
+
+	if _, err := service.UpdateAddress(1, 0, 2, 3, addresses.UpdateAddressRequest{Street: "Jl. Braga 1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 0 || *saved.Latitude != -6.9 {
+		t.Errorf("Expected the coordinates to be kept, got %d calls and %v", calls, *saved.Latitude)
+	}
+
+	if _, err := service.UpdateAddress(1, 0, 2, 3, addresses.UpdateAddressRequest{City: "Jakarta", State: "DKI Jakarta"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 1 || *saved.Latitude != 1 || *saved.Longitude != 2 {
+		t.Errorf("Expected the moved address to be geocoded, got %d calls and %v", calls, *saved.Latitude)
+	}
+}
+
+// TestBackfillCoordinates tests locating stored addresses that have no coordinates yet
+func TestBackfillCoordinates(t *testing.T) {
+	located := make(map[uint]geo.Point)
+	mockRepo := &MockAddressRepository{
+		GetAddressesWithoutCoordinatesFunc: func(after_id uint, limit int) ([]addresses.Address, error) {
+			stored := []addresses.Address{
+				{ID: 1, City: "Bandung", State: "JB", Country: "ID"},
+				{ID: 2, City: "Atlantis", Country: "ID"},
+				{ID: 3, City: "Jakarta", Country: "ID"},
+			}
+			var batch []addresses.Address
+			for _, address := range stored {
+				if address.ID > after_id && len(batch) < limit {
+					batch = append(batch, address)
+				}
+			}
+			return batch, nil
+		},
+		SetCoordinatesFunc: func(address_id uint, latitude, longitude float64) error {
+			located[address_id] = geo.Point{Lat: latitude, Lng: longitude}
+			return nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, geo.NewOfflineGeocoder(), "")
+
+	count, err := service.BackfillCoordinates()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 2 || len(located) != 2 {
+		t.Fatalf("Expected 2 addresses located, got %d: %v", count, located)
+	}
+	if math.Abs(located[1].Lat+6.9175) > 0.001 || math.Abs(located[3].Lat+6.2088) > 0.001 {
+		t.Errorf("Expected Bandung and Jakarta, got %v", located)
+	}
+}
+
+// TestNearby_SortsByDistance tests filtering by radius and ordering by distance
+func TestNearby_SortsByDistance(t *testing.T) {
+	var searched geo.Box
+	mockRepo := &MockAddressRepository{
+		GetAddressesWithinFunc: func(user_id, workspace_id uint, box geo.Box) ([]addresses.Address, error) {
+			searched = box
+			return []addresses.Address{
+				{ID: 1, City: "Bogor", Latitude: float(-6.5950), Longitude: float(106.8167)},
+				{ID: 2, City: "Jakarta", Latitude: float(-6.2088), Longitude: float(106.8456)},
+				{ID: 3, City: "Bandung", Latitude: float(-6.9175), Longitude: float(107.6191)},
+				{ID: 4, City: "Depok", Latitude: float(-6.4025), Longitude: float(106.7942)},
+			}, nil
+		},
+	}
+
+	service := addresses.NewAddressService(mockRepo, nil, "")
+
+	nearby, err := service.Nearby(1, 0, geo.Point{Lat: -6.2088, Lng: 106.8456}, 50, 0)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if searched.MinLat > -6.6 || searched.MaxLat < -5.8 {
+		t.Errorf("Expected a box around the radius, got %+v", searched)
+	}
+	if len(nearby) != 3 || nearby[0].Address.ID != 2 || nearby[1].Address.ID != 4 || nearby[2].Address.ID != 1 {
+		t.Fatalf("Expected Jakarta, Depok and Bogor, got %+v", nearby)
+	}
+	if nearby[0].DistanceKm != 0 || nearby[2].DistanceKm < 40 || nearby[2].DistanceKm > 50 {
+		t.Errorf("Unexpected distances %f %f", nearby[0].DistanceKm, nearby[2].DistanceKm)
+	}
+}
+
+// TestNearby_InvalidInput tests rejecting coordinates and radii out of range
+func TestNearby_InvalidInput(t *testing.T) {
+	service := addresses.NewAddressService(&MockAddressRepository{}, nil, "")
+
+	if _, err := service.Nearby(1, 0, geo.Point{Lat: 91, Lng: 0}, 10, 0); !errors.Is(err, addresses.ErrInvalidCoordinates) {
+		t.Errorf("Expected ErrInvalidCoordinates, got %v", err)
+	}
+	if _, err := service.Nearby(1, 0, geo.Point{Lat: 0, Lng: 0}, -1, 0); !errors.Is(err, addresses.ErrInvalidRadius) {
+		t.Errorf("Expected ErrInvalidRadius, got %v", err)
+	}
+}
@@ -2,7 +2,11 @@ package test
 
 import (
 	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
 	"gorm.io/gorm"
 )
@@ -73,17 +77,34 @@ func IsRecordNotFoundError(err error) bool {
 
 // MockContactRepository is a mock implementation of contacts.ContactRepository
 type MockContactRepository struct {
-	GetContactsFunc     func(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error)
-	CreateContactFunc   func(contact contacts.Contact) (*contacts.ContactResponse, error)
-	FindContactByIdFunc func(id, user_id uint) (*contacts.Contact, error)
-	UpdateContactFunc   func(id, user_id uint, contact *contacts.Contact) error
-	DeleteContactFunc   func(id, user_id uint) error
+	GetContactsFunc                 func(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error)
+	CreateContactFunc               func(contact contacts.Contact) (*contacts.ContactResponse, error)
+	FindContactByIdFunc             func(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	UpdateContactFunc               func(id, user_id, workspace_id uint, contact *contacts.Contact) error
+	DeleteContactFunc               func(id, user_id, workspace_id uint) error
+	FindUserRegionFunc              func(user_id uint) (string, error)
+	GetContactsWithoutPhoneE164Func func(after_id uint, limit int) ([]contacts.Contact, error)
+	SetPhoneE164Func                func(id uint, phone_e164 string) error
+	GetCustomFieldsFunc             func(user_id uint) ([]customfields.Field, error)
+	FindCompanyFunc                 func(id, user_id uint) (*companies.Company, error)
+	FindOrCreateCompanyFunc         func(user_id uint, name string) (*companies.Company, error)
+	AddTagsFunc                     func(id uint, tags []string) error
+	RemoveTagsFunc                  func(id uint, tags []string) error
+	CanEditContactFunc              func(id, user_id, workspace_id uint) (bool, error)
+	FindWorkspaceRoleFunc           func(workspace_id, user_id uint) (string, error)
+	GetRevisionsFunc                func(contact_id uint, page, limit int) (*history.GetRevisionsResponse, error)
+	FindRevisionFunc                func(contact_id, revision_id uint) (*history.Revision, error)
+	GetRevisionsAfterFunc           func(contact_id, revision_id uint) ([]history.Revision, error)
+	RestoreContactFunc              func(id, user_id uint, plan contacts.RestorePlan) error
+	SetFavoriteFunc                 func(id uint, favorite bool) error
+	FindSortPreferenceFunc          func(user_id uint) (*contacts.SortPreference, error)
+	SaveSortPreferenceFunc          func(user_id uint, preference contacts.SortPreference) error
 }
 
 // GetContacts implements contacts.ContactRepository
-func (m *MockContactRepository) GetContacts(page, limit, user_id int, search string) (*contacts.GetContactsResponse, error) {
+func (m *MockContactRepository) GetContacts(page, limit, user_id int, search string, filter contacts.ContactFilter) (*contacts.GetContactsResponse, error) {
 	if m.GetContactsFunc != nil {
-		return m.GetContactsFunc(page, limit, user_id, search)
+		return m.GetContactsFunc(page, limit, user_id, search, filter)
 	}
 	return nil, nil
 }
@@ -97,43 +118,202 @@ func (m *MockContactRepository) CreateContact(contact contacts.Contact) (*contac
 }
 
 // FindContactById implements contacts.ContactRepository
-func (m *MockContactRepository) FindContactById(id, user_id uint) (*contacts.Contact, error) {
+func (m *MockContactRepository) FindContactById(id, user_id, workspace_id uint) (*contacts.Contact, error) {
 	if m.FindContactByIdFunc != nil {
-		return m.FindContactByIdFunc(id, user_id)
+		return m.FindContactByIdFunc(id, user_id, workspace_id)
 	}
 	return nil, nil
 }
 
 // UpdateContact implements contacts.ContactRepository
-func (m *MockContactRepository) UpdateContact(id, user_id uint, contact *contacts.Contact) error {
+func (m *MockContactRepository) UpdateContact(id, user_id, workspace_id uint, contact *contacts.Contact) error {
 	if m.UpdateContactFunc != nil {
-		return m.UpdateContactFunc(id, user_id, contact)
+		return m.UpdateContactFunc(id, user_id, workspace_id, contact)
 	}
 	return nil
 }
 
 // DeleteContact implements contacts.ContactRepository
-func (m *MockContactRepository) DeleteContact(id, user_id uint) error {
+func (m *MockContactRepository) DeleteContact(id, user_id, workspace_id uint) error {
 	if m.DeleteContactFunc != nil {
-		return m.DeleteContactFunc(id, user_id)
+		return m.DeleteContactFunc(id, user_id, workspace_id)
+	}
+	return nil
+}
+
+// FindUserRegion implements contacts.ContactRepository
+func (m *MockContactRepository) FindUserRegion(user_id uint) (string, error) {
+	if m.FindUserRegionFunc != nil {
+		return m.FindUserRegionFunc(user_id)
+	}
+	return "", nil
+}
+
+// CanEditContact implements contacts.ContactRepository
+func (m *MockContactRepository) CanEditContact(id, user_id, workspace_id uint) (bool, error) {
+	if m.CanEditContactFunc != nil {
+		return m.CanEditContactFunc(id, user_id, workspace_id)
+	}
+	return false, nil
+}
+
+// GetRevisions implements contacts.ContactRepository
+func (m *MockContactRepository) GetRevisions(contact_id uint, page, limit int) (*history.GetRevisionsResponse, error) {
+	if m.GetRevisionsFunc != nil {
+		return m.GetRevisionsFunc(contact_id, page, limit)
+	}
+	return nil, nil
+}
+
+// FindRevision implements contacts.ContactRepository
+func (m *MockContactRepository) FindRevision(contact_id, revision_id uint) (*history.Revision, error) {
+	if m.FindRevisionFunc != nil {
+		return m.FindRevisionFunc(contact_id, revision_id)
+	}
+	return &history.Revision{ID: revision_id, ContactID: contact_id}, nil
+}
+
+// GetRevisionsAfter implements contacts.ContactRepository
+func (m *MockContactRepository) GetRevisionsAfter(contact_id, revision_id uint) ([]history.Revision, error) {
+	if m.GetRevisionsAfterFunc != nil {
+		return m.GetRevisionsAfterFunc(contact_id, revision_id)
+	}
+	return nil, nil
+}
+
+// RestoreContact implements contacts.ContactRepository
+func (m *MockContactRepository) RestoreContact(id, user_id uint, plan contacts.RestorePlan) error {
+	if m.RestoreContactFunc != nil {
+		return m.RestoreContactFunc(id, user_id, plan)
+	}
+	return nil
+}
+
+// SetFavorite implements contacts.ContactRepository
+func (m *MockContactRepository) SetFavorite(id uint, favorite bool) error {
+	if m.SetFavoriteFunc != nil {
+		return m.SetFavoriteFunc(id, favorite)
+	}
+	return nil
+}
+
+// FindSortPreference implements contacts.ContactRepository
+func (m *MockContactRepository) FindSortPreference(user_id uint) (*contacts.SortPreference, error) {
+	if m.FindSortPreferenceFunc != nil {
+		return m.FindSortPreferenceFunc(user_id)
+	}
+	return &contacts.SortPreference{}, nil
+}
+
+// SaveSortPreference implements contacts.ContactRepository
+func (m *MockContactRepository) SaveSortPreference(user_id uint, preference contacts.SortPreference) error {
+	if m.SaveSortPreferenceFunc != nil {
+		return m.SaveSortPreferenceFunc(user_id, preference)
+	}
+	return nil
+}
+
+// GetContactsWithoutPhoneE164 implements contacts.ContactRepository
+func (m *MockContactRepository) GetContactsWithoutPhoneE164(after_id uint, limit int) ([]contacts.Contact, error) {
+	if m.GetContactsWithoutPhoneE164Func != nil {
+		return m.GetContactsWithoutPhoneE164Func(after_id, limit)
+	}
+	return nil, nil
+}
+
+// SetPhoneE164 implements contacts.ContactRepository
+func (m *MockContactRepository) SetPhoneE164(id uint, phone_e164 string) error {
+	if m.SetPhoneE164Func != nil {
+		return m.SetPhoneE164Func(id, phone_e164)
+	}
+	return nil
+}
+
+// FindWorkspaceRole implements contacts.ContactRepository
+func (m *MockContactRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	if m.FindWorkspaceRoleFunc != nil {
+		return m.FindWorkspaceRoleFunc(workspace_id, user_id)
+	}
+	return "", nil
+}
+
+// GetCustomFields implements contacts.ContactRepository
+func (m *MockContactRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	if m.GetCustomFieldsFunc != nil {
+		return m.GetCustomFieldsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindCompany implements contacts.ContactRepository
+func (m *MockContactRepository) FindCompany(id, user_id uint) (*companies.Company, error) {
+	if m.FindCompanyFunc != nil {
+		return m.FindCompanyFunc(id, user_id)
+	}
+	return &companies.Company{ID: id, UserID: user_id}, nil
+}
+
+// FindOrCreateCompany implements contacts.ContactRepository
+func (m *MockContactRepository) FindOrCreateCompany(user_id uint, name string) (*companies.Company, error) {
+	if m.FindOrCreateCompanyFunc != nil {
+		return m.FindOrCreateCompanyFunc(user_id, name)
+	}
+	return &companies.Company{ID: 1, UserID: user_id, Name: name}, nil
+}
+
+// AddTags implements contacts.ContactRepository
+func (m *MockContactRepository) AddTags(id uint, tags []string) error {
+	if m.AddTagsFunc != nil {
+		return m.AddTagsFunc(id, tags)
+	}
+	return nil
+}
+
+// RemoveTags implements contacts.ContactRepository
+func (m *MockContactRepository) RemoveTags(id uint, tags []string) error {
+	if m.RemoveTagsFunc != nil {
+		return m.RemoveTagsFunc(id, tags)
 	}
 	return nil
 }
 
 // MockAddressRepository is a mock implementation of addresses.AddressRepository
 type MockAddressRepository struct {
-	CreateAddressFunc   func(address addresses.CreateAddressRequest) (*addresses.AddressResponse, error)
-	GetAddressesFunc    func(contact_id uint, page int, limit int, search string) (*addresses.GetAddressesResponse, error)
-	UpdateAddressFunc   func(address_id uint, address *addresses.Address) (*addresses.AddressResponse, error)
-	FindAddressByIdFunc func(address_id uint) (*addresses.Address, error)
-	DeleteAddressFunc   func(address_id uint) error
-	FindContactByIdFunc func(id, user_id uint) (*contacts.Contact, error)
+	CreateAddressFunc                  func(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error)
+	GetAddressesFunc                   func(contact_id uint, page int, limit int, search string) (*addresses.GetAddressesResponse, error)
+	UpdateAddressFunc                  func(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error)
+	FindAddressByIdFunc                func(address_id, user_id, workspace_id uint, permission string) (*addresses.Address, error)
+	FindContactAddressFunc             func(contact_id, address_id uint) (*addresses.Address, error)
+	GetContactAddressesFunc            func(contact_id uint) ([]addresses.Address, error)
+	DeleteAddressFunc                  func(user_id, address_id uint) error
+	FindContactByIdFunc                func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	GetAddressesWithinFunc             func(user_id, workspace_id uint, box geo.Box) ([]addresses.Address, error)
+	EachLocatedAddressBatchFunc        func(user_id, workspace_id uint, filter addresses.AddressFilter, fn func(address_list []addresses.Address) error) error
+	ListAddressesFunc                  func(user_id, workspace_id uint, page, limit int, filter addresses.AddressFilter) (*addresses.GetAddressesResponse, error)
+	GetAddressesWithoutCoordinatesFunc func(after_id uint, limit int) ([]addresses.Address, error)
+	SetCoordinatesFunc                 func(address_id uint, latitude, longitude float64) error
+}
+
+// GetAddressesWithoutCoordinates implements addresses.AddressRepository
+func (m *MockAddressRepository) GetAddressesWithoutCoordinates(after_id uint, limit int) ([]addresses.Address, error) {
+	if m.GetAddressesWithoutCoordinatesFunc != nil {
+		return m.GetAddressesWithoutCoordinatesFunc(after_id, limit)
+	}
+	return nil, nil
+}
+
+// SetCoordinates implements addresses.AddressRepository
+func (m *MockAddressRepository) SetCoordinates(address_id uint, latitude, longitude float64) error {
+	if m.SetCoordinatesFunc != nil {
+		return m.SetCoordinatesFunc(address_id, latitude, longitude)
+	}
+	return nil
 }
 
 // CreateAddress implements addresses.AddressRepository
-func (m *MockAddressRepository) CreateAddress(address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
+func (m *MockAddressRepository) CreateAddress(user_id uint, address addresses.CreateAddressRequest) (*addresses.AddressResponse, error) {
 	if m.CreateAddressFunc != nil {
-		return m.CreateAddressFunc(address)
+		return m.CreateAddressFunc(user_id, address)
 	}
 	return nil, nil
 }
@@ -147,33 +327,73 @@ func (m *MockAddressRepository) GetAddresses(contact_id uint, page int, limit in
 }
 
 // UpdateAddress implements addresses.AddressRepository
-func (m *MockAddressRepository) UpdateAddress(address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
+func (m *MockAddressRepository) UpdateAddress(user_id, address_id uint, address *addresses.Address) (*addresses.AddressResponse, error) {
 	if m.UpdateAddressFunc != nil {
-		return m.UpdateAddressFunc(address_id, address)
+		return m.UpdateAddressFunc(user_id, address_id, address)
 	}
 	return nil, nil
 }
 
 // FindAddressById implements addresses.AddressRepository
-func (m *MockAddressRepository) FindAddressById(address_id uint) (*addresses.Address, error) {
+func (m *MockAddressRepository) FindAddressById(address_id, user_id, workspace_id uint, permission string) (*addresses.Address, error) {
 	if m.FindAddressByIdFunc != nil {
-		return m.FindAddressByIdFunc(address_id)
+		return m.FindAddressByIdFunc(address_id, user_id, workspace_id, permission)
 	}
 	return nil, nil
 }
 
+// FindContactAddress implements addresses.AddressRepository
+func (m *MockAddressRepository) FindContactAddress(contact_id, address_id uint) (*addresses.Address, error) {
+	if m.FindContactAddressFunc != nil {
+		return m.FindContactAddressFunc(contact_id, address_id)
+	}
+	return nil, nil
+}
+
+// GetContactAddresses implements addresses.AddressRepository
+func (m *MockAddressRepository) GetContactAddresses(contact_id uint) ([]addresses.Address, error) {
+	if m.GetContactAddressesFunc != nil {
+		return m.GetContactAddressesFunc(contact_id)
+	}
+	return []addresses.Address{}, nil
+}
+
 // DeleteAddress implements addresses.AddressRepository
-func (m *MockAddressRepository) DeleteAddress(address_id uint) error {
+func (m *MockAddressRepository) DeleteAddress(user_id, address_id uint) error {
 	if m.DeleteAddressFunc != nil {
-		return m.DeleteAddressFunc(address_id)
+		return m.DeleteAddressFunc(user_id, address_id)
 	}
 	return nil
 }
 
 // FindContactById implements addresses.AddressRepository
-func (m *MockAddressRepository) FindContactById(id, user_id uint) (*contacts.Contact, error) {
+func (m *MockAddressRepository) FindContactById(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
 	if m.FindContactByIdFunc != nil {
-		return m.FindContactByIdFunc(id, user_id)
+		return m.FindContactByIdFunc(id, user_id, workspace_id, permission)
 	}
 	return nil, nil
 }
+
+// GetAddressesWithin implements addresses.AddressRepository
+func (m *MockAddressRepository) GetAddressesWithin(user_id, workspace_id uint, box geo.Box) ([]addresses.Address, error) {
+	if m.GetAddressesWithinFunc != nil {
+		return m.GetAddressesWithinFunc(user_id, workspace_id, box)
+	}
+	return []addresses.Address{}, nil
+}
+
+// EachLocatedAddressBatch implements addresses.AddressRepository
+func (m *MockAddressRepository) EachLocatedAddressBatch(user_id, workspace_id uint, filter addresses.AddressFilter, fn func(address_list []addresses.Address) error) error {
+	if m.EachLocatedAddressBatchFunc != nil {
+		return m.EachLocatedAddressBatchFunc(user_id, workspace_id, filter, fn)
+	}
+	return nil
+}
+
+// ListAddresses implements addresses.AddressRepository
+func (m *MockAddressRepository) ListAddresses(user_id, workspace_id uint, page, limit int, filter addresses.AddressFilter) (*addresses.GetAddressesResponse, error) {
+	if m.ListAddressesFunc != nil {
+		return m.ListAddressesFunc(user_id, workspace_id, page, limit, filter)
+	}
+	return &addresses.GetAddressesResponse{Addresses: []addresses.Address{}, Page: page, Limit: limit}, nil
+}
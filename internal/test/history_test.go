@@ -0,0 +1,259 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"gorm.io/gorm"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func ownContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	return &contacts.Contact{ID: id, UserID: user_id, FirstName: "John"}, nil
+}
+
+// ========== Diff Tests ==========
+
+// TestDiff_ChangedFields tests that only changed fields are returned, sorted by name
+func TestDiff_ChangedFields(t *testing.T) {
+	changes := history.Diff(
+		map[string]string{"first_name": "John", "email": "john@example.com", "phone": "+62 812"},
+		map[string]string{"first_name": "Johnny", "email": "john@example.com", "last_name": "Doe"},
+	)
+
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d", len(changes))
+	}
+
+	fields := []string{"first_name", "last_name", "phone"}
+	for i, field := range fields {
+		if changes[i].Field != field {
+			t.Errorf("Expected change %d to be %s, got %s", i, field, changes[i].Field)
+		}
+	}
+
+	if history.Value(changes[0].OldValue) != "John" || history.Value(changes[0].NewValue) != "Johnny" {
+		t.Errorf("Unexpected first_name change %v -> %v", changes[0].OldValue, changes[0].NewValue)
+	}
+
+	if changes[1].OldValue != nil {
+		t.Errorf("Expected nil old last_name, got %v", *changes[1].OldValue)
+	}
+
+	if changes[2].NewValue != nil {
+		t.Errorf("Expected nil new phone, got %v", *changes[2].NewValue)
+	}
+}
+
+// TestDiff_EmptyEqualsMissing tests that clearing an absent field is not a change
+func TestDiff_EmptyEqualsMissing(t *testing.T) {
+	changes := history.Diff(map[string]string{"last_name": ""}, map[string]string{})
+
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %d", len(changes))
+	}
+}
+
+// ========== RestoreContact Tests ==========
+
+// TestRestoreContact_RevertsFields tests restoring fields changed after the revision
+func TestRestoreContact_RevertsFields(t *testing.T) {
+	var plan contacts.RestorePlan
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: ownContact,
+		GetRevisionsAfterFunc: func(contact_id, revision_id uint) ([]history.Revision, error) {
+			return []history.Revision{
+				{ID: 4, Entity: history.EntityContact, Action: history.ActionUpdate, Changes: []history.Change{
+					{Field: "first_name", OldValue: stringPtr("Jon"), NewValue: stringPtr("Johnny")},
+				}},
+				{ID: 3, Entity: history.EntityContact, Action: history.ActionUpdate, Changes: []history.Change{
+					{Field: "first_name", OldValue: stringPtr("John"), NewValue: stringPtr("Jon")},
+					{Field: "email", OldValue: nil, NewValue: stringPtr("john@example.com")},
+				}},
+			}, nil
+		},
+		RestoreContactFunc: func(id, user_id uint, p contacts.RestorePlan) error {
+			plan = p
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.RestoreContact(1, 1, 0, 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(plan.Contact) != 2 {
+		t.Fatalf("Expected 2 contact changes, got %d", len(plan.Contact))
+	}
+
+	first_name := plan.Contact[0]
+	if first_name.Field != "first_name" || history.Value(first_name.OldValue) != "Johnny" || history.Value(first_name.NewValue) != "John" {
+		t.Errorf("Expected first_name Johnny -> John, got %+v", first_name)
+	}
+
+	email := plan.Contact[1]
+	if email.Field != "email" || email.NewValue != nil {
+		t.Errorf("Expected email to be cleared, got %+v", email)
+	}
+}
+
+// TestRestoreContact_Addresses tests restoring created, deleted and updated addresses
+func TestRestoreContact_Addresses(t *testing.T) {
+	var plan contacts.RestorePlan
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: ownContact,
+		GetRevisionsAfterFunc: func(contact_id, revision_id uint) ([]history.Revision, error) {
+			return []history.Revision{
+				{ID: 8, Entity: history.EntityAddress, EntityID: 10, Action: history.ActionDelete},
+				{ID: 7, Entity: history.EntityAddress, EntityID: 11, Action: history.ActionCreate, Changes: []history.Change{
+					{Field: "city", NewValue: stringPtr("Bandung")},
+				}},
+				{ID: 6, Entity: history.EntityAddress, EntityID: 12, Action: history.ActionUpdate, Changes: []history.Change{
+					{Field: "city", OldValue: stringPtr("Jakarta"), NewValue: stringPtr("Surabaya")},
+				}},
+				{ID: 5, Entity: history.EntityAddress, EntityID: 13, Action: history.ActionDelete},
+				{ID: 4, Entity: history.EntityAddress, EntityID: 13, Action: history.ActionCreate},
+			}, nil
+		},
+		RestoreContactFunc: func(id, user_id uint, p contacts.RestorePlan) error {
+			plan = p
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.RestoreContact(1, 1, 0, 3)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(plan.Addresses) != 3 {
+		t.Fatalf("Expected 3 address restores, got %d", len(plan.Addresses))
+	}
+
+	expected := []struct {
+		id     uint
+		action string
+	}{
+		{10, history.ActionCreate},
+		{11, history.ActionDelete},
+		{12, history.ActionRestore},
+	}
+	for i, want := range expected {
+		got := plan.Addresses[i]
+		if got.AddressID != want.id || got.Action != want.action {
+			t.Errorf("Expected address %d to %s, got %d to %s", want.id, want.action, got.AddressID, got.Action)
+		}
+	}
+
+	if city := plan.Addresses[2].Changes; len(city) != 1 || history.Value(city[0].NewValue) != "Jakarta" {
+		t.Errorf("Expected city back to Jakarta, got %+v", city)
+	}
+}
+
+// TestRestoreContact_UndeletedAddress tests that an address taken out of the trash after the revision is deleted again
+func TestRestoreContact_UndeletedAddress(t *testing.T) {
+	var plan contacts.RestorePlan
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: ownContact,
+		GetRevisionsAfterFunc: func(contact_id, revision_id uint) ([]history.Revision, error) {
+			return []history.Revision{
+				{ID: 5, Entity: history.EntityAddress, EntityID: 10, Action: history.ActionUndelete},
+			}, nil
+		},
+		RestoreContactFunc: func(id, user_id uint, p contacts.RestorePlan) error {
+			plan = p
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.RestoreContact(1, 1, 0, 4)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(plan.Addresses) != 1 || plan.Addresses[0].Action != history.ActionDelete {
+		t.Errorf("Expected address 10 to be deleted, got %+v", plan.Addresses)
+	}
+}
+
+// TestRestoreContact_RevisionNotFound tests restoring a revision of another contact
+func TestRestoreContact_RevisionNotFound(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: ownContact,
+		FindRevisionFunc: func(contact_id, revision_id uint) (*history.Revision, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.RestoreContact(1, 1, 0, 99)
+
+	if err == nil || err.Error() != "revision not found" {
+		t.Errorf("Expected 'revision not found', got %v", err)
+	}
+}
+
+// TestRestoreContact_ViewOnly tests that a view-only user cannot restore
+func TestRestoreContact_ViewOnly(t *testing.T) {
+	restored := false
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 2}, nil
+		},
+		RestoreContactFunc: func(id, user_id uint, plan contacts.RestorePlan) error {
+			restored = true
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.RestoreContact(1, 1, 0, 2)
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+
+	if restored {
+		t.Error("Expected contact not to be restored")
+	}
+}
+
+// ========== GetHistory Tests ==========
+
+// TestGetHistory_ContactNotFound tests reading the history of an inaccessible contact
+func TestGetHistory_ContactNotFound(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		GetRevisionsFunc: func(contact_id uint, page, limit int) (*history.GetRevisionsResponse, error) {
+			t.Error("Expected revisions not to be read")
+			return nil, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.GetHistory(1, 1, 0, 1, 20)
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found', got %v", err)
+	}
+}
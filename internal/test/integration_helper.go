@@ -5,9 +5,18 @@ import (
 	"os"
 	"testing"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
 	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/avatars"
+	"github.com/DioSaputra28/belajar-gin-1/internal/companies"
 	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"github.com/DioSaputra28/belajar-gin-1/internal/reminders"
+	"github.com/DioSaputra28/belajar-gin-1/internal/sharing"
 	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -21,7 +30,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	_ = godotenv.Load(".env")
 
 	// Create database connection
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27",
 		os.Getenv("DB_USER"),
 		os.Getenv("DB_PASSWORD"),
 		os.Getenv("DB_HOST"),
@@ -43,8 +52,24 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 
 	// Drop existing tables to ensure clean migration
 	db.Exec("SET FOREIGN_KEY_CHECKS = 0")
+	db.Exec("DROP TABLE IF EXISTS contact_tags")
+	db.Exec("DROP TABLE IF EXISTS contact_relationships")
+	db.Exec("DROP TABLE IF EXISTS avatars")
+	db.Exec("DROP TABLE IF EXISTS contact_revision_changes")
+	db.Exec("DROP TABLE IF EXISTS contact_revisions")
+	db.Exec("DROP TABLE IF EXISTS workspace_invitations")
+	db.Exec("DROP TABLE IF EXISTS workspace_members")
+	db.Exec("DROP TABLE IF EXISTS contact_shares")
+	db.Exec("DROP TABLE IF EXISTS contact_group_members")
+	db.Exec("DROP TABLE IF EXISTS contact_groups")
+	db.Exec("DROP TABLE IF EXISTS reminder_notifications")
+	db.Exec("DROP TABLE IF EXISTS activities")
+	db.Exec("DROP TABLE IF EXISTS contact_custom_values")
+	db.Exec("DROP TABLE IF EXISTS custom_fields")
 	db.Exec("DROP TABLE IF EXISTS addresses")
 	db.Exec("DROP TABLE IF EXISTS contacts")
+	db.Exec("DROP TABLE IF EXISTS companies")
+	db.Exec("DROP TABLE IF EXISTS workspaces")
 	db.Exec("DROP TABLE IF EXISTS users")
 	db.Exec("SET FOREIGN_KEY_CHECKS = 1")
 
@@ -55,6 +80,11 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to migrate users table: %v", err)
 	}
 
+	err = db.AutoMigrate(&companies.Company{})
+	if err != nil {
+		t.Fatalf("Failed to migrate companies table: %v", err)
+	}
+
 	err = db.AutoMigrate(&contacts.Contact{})
 	if err != nil {
 		t.Fatalf("Failed to migrate contacts table: %v", err)
@@ -65,6 +95,51 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to migrate addresses table: %v", err)
 	}
 
+	err = db.AutoMigrate(&customfields.Field{}, &customfields.Value{})
+	if err != nil {
+		t.Fatalf("Failed to migrate custom field tables: %v", err)
+	}
+
+	err = db.AutoMigrate(&activities.Activity{})
+	if err != nil {
+		t.Fatalf("Failed to migrate activities table: %v", err)
+	}
+
+	err = db.AutoMigrate(&reminders.Notification{})
+	if err != nil {
+		t.Fatalf("Failed to migrate reminder_notifications table: %v", err)
+	}
+
+	err = db.AutoMigrate(&groups.Group{}, &groups.Member{}, &sharing.Share{})
+	if err != nil {
+		t.Fatalf("Failed to migrate group and share tables: %v", err)
+	}
+
+	err = db.AutoMigrate(&workspaces.Workspace{}, &workspaces.Member{}, &workspaces.Invitation{})
+	if err != nil {
+		t.Fatalf("Failed to migrate workspace tables: %v", err)
+	}
+
+	err = db.AutoMigrate(&history.Revision{}, &history.Change{})
+	if err != nil {
+		t.Fatalf("Failed to migrate contact history tables: %v", err)
+	}
+
+	err = db.AutoMigrate(&avatars.Avatar{})
+	if err != nil {
+		t.Fatalf("Failed to migrate avatars table: %v", err)
+	}
+
+	err = db.AutoMigrate(&contacts.Relationship{})
+	if err != nil {
+		t.Fatalf("Failed to migrate contact relationships table: %v", err)
+	}
+
+	err = db.AutoMigrate(&contacts.Tag{})
+	if err != nil {
+		t.Fatalf("Failed to migrate contact tags table: %v", err)
+	}
+
 	return db
 }
 
@@ -72,8 +147,24 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 func CleanupTestDB(t *testing.T, db *gorm.DB) {
 	// Delete in correct order (foreign key constraints)
 	db.Exec("SET FOREIGN_KEY_CHECKS = 0")
+	db.Exec("TRUNCATE TABLE contact_tags")
+	db.Exec("TRUNCATE TABLE contact_relationships")
+	db.Exec("TRUNCATE TABLE avatars")
+	db.Exec("TRUNCATE TABLE contact_revision_changes")
+	db.Exec("TRUNCATE TABLE contact_revisions")
+	db.Exec("TRUNCATE TABLE workspace_invitations")
+	db.Exec("TRUNCATE TABLE workspace_members")
+	db.Exec("TRUNCATE TABLE contact_shares")
+	db.Exec("TRUNCATE TABLE contact_group_members")
+	db.Exec("TRUNCATE TABLE contact_groups")
+	db.Exec("TRUNCATE TABLE reminder_notifications")
+	db.Exec("TRUNCATE TABLE activities")
+	db.Exec("TRUNCATE TABLE contact_custom_values")
+	db.Exec("TRUNCATE TABLE custom_fields")
 	db.Exec("TRUNCATE TABLE addresses")
 	db.Exec("TRUNCATE TABLE contacts")
+	db.Exec("TRUNCATE TABLE companies")
+	db.Exec("TRUNCATE TABLE workspaces")
 	db.Exec("TRUNCATE TABLE users")
 	db.Exec("SET FOREIGN_KEY_CHECKS = 1")
 }
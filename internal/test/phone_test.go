@@ -0,0 +1,156 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/phone"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// ========== Parse Tests ==========
+
+// TestPhoneParse_Formats tests that different spellings of a number normalize to the same E.164 form
+func TestPhoneParse_Formats(t *testing.T) {
+	tests := []struct {
+		raw     string
+		region  string
+		e164    string
+		display string
+	}{
+		{"0812-3456-789", "ID", "+628123456789", "+62 812 3456 789"},
+		{"+62 812 3456 789", "US", "+628123456789", "+62 812 3456 789"},
+		{"628123456789", "ID", "+628123456789", "+62 812 3456 789"},
+		{"+62 (0)812 3456 789", "ID", "+628123456789", "+62 812 3456 789"},
+		{"0062 812 3456 789", "ID", "+628123456789", "+62 812 3456 789"},
+		{"(415) 555-2671", "US", "+14155552671", "+1 415 555 2671"},
+		{"1 415 555 2671", "US", "+14155552671", "+1 415 555 2671"},
+		{"+44 20 7946 0958", "ID", "+442079460958", "+44 20 7946 0958"},
+		{"+7 912 345 67 89", "ID", "+79123456789", "+7 912 3456 789"},
+	}
+
+	for _, tt := range tests {
+		number, err := phone.Parse(tt.raw, tt.region)
+		if err != nil {
+			t.Errorf("Parse(%q, %q): expected no error, got %v", tt.raw, tt.region, err)
+			continue
+		}
+
+		if number.E164 != tt.e164 {
+			t.Errorf("Parse(%q, %q): expected E.164 '%s', got '%s'", tt.raw, tt.region, tt.e164, number.E164)
+		}
+
+		if number.Display != tt.display {
+			t.Errorf("Parse(%q, %q): expected display '%s', got '%s'", tt.raw, tt.region, tt.display, number.Display)
+		}
+	}
+}
+
+// TestPhoneParse_Invalid tests that unparseable numbers are rejected
+func TestPhoneParse_Invalid(t *testing.T) {
+	tests := []struct {
+		raw    string
+		region string
+	}{
+		{"call me", "ID"},
+		{"0812", "ID"},
+		{"+999 1234 5678", "ID"},
+		{"08123456789012345", "ID"},
+		{"12+345678", "ID"},
+		{"081234567", "ZZ"},
+	}
+
+	for _, tt := range tests {
+		_, err := phone.Parse(tt.raw, tt.region)
+		if !errors.Is(err, phone.ErrInvalidNumber) {
+			t.Errorf("Parse(%q, %q): expected ErrInvalidNumber, got %v", tt.raw, tt.region, err)
+		}
+	}
+}
+
+// TestPhoneSearchDigits tests extracting digits from search terms
+func TestPhoneSearchDigits(t *testing.T) {
+	if digits := phone.SearchDigits("0812-3456"); digits != "8123456" {
+		t.Errorf("Expected '8123456', got '%s'", digits)
+	}
+
+	if digits := phone.SearchDigits("john"); digits != "" {
+		t.Errorf("Expected no digits for a name, got '%s'", digits)
+	}
+
+	if digits := phone.SearchDigits("john2000"); digits != "" {
+		t.Errorf("Expected no digits for a mostly alphabetic term, got '%s'", digits)
+	}
+}
+
+// ========== Contact Phone Tests ==========
+
+// TestCreateContact_NormalizesPhone tests that the user's region is used for national numbers
+func TestCreateContact_NormalizesPhone(t *testing.T) {
+	var saved contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindUserRegionFunc: func(user_id uint) (string, error) {
+			return "US", nil
+		},
+		CreateContactFunc: func(contact contacts.Contact) (*contacts.ContactResponse, error) {
+			saved = contact
+			return &contacts.ContactResponse{ID: 1, Phone: contact.Phone, PhoneE164: contact.PhoneE164}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.CreateContact(contacts.Contact{UserID: 1, FirstName: "John", Email: "john@example.com", Phone: "415.555.2671"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.PhoneE164 != "+14155552671" || saved.Phone != "+1 415 555 2671" {
+		t.Errorf("Expected normalized phone, got '%s' / '%s'", saved.Phone, saved.PhoneE164)
+	}
+}
+
+// TestCreateContact_InvalidPhone tests that an unparseable number is rejected before saving
+func TestCreateContact_InvalidPhone(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		CreateContactFunc: func(contact contacts.Contact) (*contacts.ContactResponse, error) {
+			t.Error("Expected contact not to be saved")
+			return nil, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	_, err := service.CreateContact(contacts.Contact{UserID: 1, FirstName: "John", Email: "john@example.com", Phone: "ext. 12"})
+
+	if !errors.Is(err, phone.ErrInvalidNumber) {
+		t.Errorf("Expected ErrInvalidNumber, got %v", err)
+	}
+}
+
+// TestUpdateContact_NormalizesPhone tests that an updated phone is stored in both forms
+func TestUpdateContact_NormalizesPhone(t *testing.T) {
+	var saved *contacts.Contact
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id, FirstName: "John", Email: "john@example.com"}, nil
+		},
+		UpdateContactFunc: func(id, user_id, workspace_id uint, contact *contacts.Contact) error {
+			saved = contact
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(1, 1, 0, contacts.Contact{Phone: "0812 3456 789"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.PhoneE164 != "+628123456789" {
+		t.Errorf("Expected E.164 '+628123456789', got '%s'", saved.PhoneE164)
+	}
+}
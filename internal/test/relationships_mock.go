@@ -0,0 +1,54 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// MockRelationshipRepository implements relationships.RelationshipRepository interface
+type MockRelationshipRepository struct {
+	FindContactFunc         func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error)
+	CreateRelationshipsFunc func(relationships []contacts.Relationship) error
+	FindRelationshipFunc    func(id, contact_id uint) (*contacts.Relationship, error)
+	DeleteRelationshipFunc  func(relationship contacts.Relationship) error
+	GetRelationshipsFunc    func(contact_ids []uint) ([]contacts.Relationship, error)
+}
+
+// FindContact implements relationships.RelationshipRepository
+func (m *MockRelationshipRepository) FindContact(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+	if m.FindContactFunc != nil {
+		return m.FindContactFunc(id, user_id, workspace_id, permission)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// CreateRelationships implements relationships.RelationshipRepository
+func (m *MockRelationshipRepository) CreateRelationships(relationships []contacts.Relationship) error {
+	if m.CreateRelationshipsFunc != nil {
+		return m.CreateRelationshipsFunc(relationships)
+	}
+	return nil
+}
+
+// FindRelationship implements relationships.RelationshipRepository
+func (m *MockRelationshipRepository) FindRelationship(id, contact_id uint) (*contacts.Relationship, error) {
+	if m.FindRelationshipFunc != nil {
+		return m.FindRelationshipFunc(id, contact_id)
+	}
+	return nil, nil
+}
+
+// DeleteRelationship implements relationships.RelationshipRepository
+func (m *MockRelationshipRepository) DeleteRelationship(relationship contacts.Relationship) error {
+	if m.DeleteRelationshipFunc != nil {
+		return m.DeleteRelationshipFunc(relationship)
+	}
+	return nil
+}
+
+// GetRelationships implements relationships.RelationshipRepository
+func (m *MockRelationshipRepository) GetRelationships(contact_ids []uint) ([]contacts.Relationship, error) {
+	if m.GetRelationshipsFunc != nil {
+		return m.GetRelationshipsFunc(contact_ids)
+	}
+	return []contacts.Relationship{}, nil
+}
@@ -0,0 +1,214 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/relationships"
+	"gorm.io/gorm"
+)
+
+// graphRepository serves GetRelationships from a fixed list of edges
+func graphRepository(edges []contacts.Relationship) *MockRelationshipRepository {
+	return &MockRelationshipRepository{
+		GetRelationshipsFunc: func(contact_ids []uint) ([]contacts.Relationship, error) {
+			from := make(map[uint]bool, len(contact_ids))
+			for _, id := range contact_ids {
+				from[id] = true
+			}
+			var result []contacts.Relationship
+			for _, edge := range edges {
+				if from[edge.ContactID] {
+					edge.Related = &contacts.Contact{ID: edge.RelatedID}
+					result = append(result, edge)
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+// ========== Relationship Service Tests ==========
+
+// TestCreateRelationship_Bidirectional tests that the reverse direction gets the inverse type
+func TestCreateRelationship_Bidirectional(t *testing.T) {
+	var created []contacts.Relationship
+	mockRepo := &MockRelationshipRepository{
+		CreateRelationshipsFunc: func(relationships []contacts.Relationship) error {
+			created = relationships
+			return nil
+		},
+	}
+	service := relationships.NewRelationshipService(mockRepo)
+
+	result, err := service.CreateRelationship(1, 1, 0, relationships.CreateRelationshipRequest{RelatedID: 2, Type: "Manager", Bidirectional: true})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(created) != 2 || len(result) != 2 {
+		t.Fatalf("Expected 2 relationships, got %d", len(created))
+	}
+
+	if created[0].ContactID != 1 || created[0].RelatedID != 2 || created[0].Type != "manager" {
+		t.Errorf("Unexpected relationship %+v", created[0])
+	}
+
+	if created[1].ContactID != 2 || created[1].RelatedID != 1 || created[1].Type != "report" {
+		t.Errorf("Unexpected inverse relationship %+v", created[1])
+	}
+
+	if result[0].Related == nil || result[0].Related.ID != 2 {
+		t.Errorf("Expected related contact to be returned, got %+v", result[0].Related)
+	}
+}
+
+// TestCreateRelationship_InvalidType tests rejecting an unknown type
+func TestCreateRelationship_InvalidType(t *testing.T) {
+	service := relationships.NewRelationshipService(&MockRelationshipRepository{})
+
+	_, err := service.CreateRelationship(1, 1, 0, relationships.CreateRelationshipRequest{RelatedID: 2, Type: "nemesis"})
+
+	if !errors.Is(err, relationships.ErrInvalidType) {
+		t.Errorf("Expected ErrInvalidType, got %v", err)
+	}
+}
+
+// TestCreateRelationship_Self tests rejecting a contact related to itself
+func TestCreateRelationship_Self(t *testing.T) {
+	service := relationships.NewRelationshipService(&MockRelationshipRepository{})
+
+	_, err := service.CreateRelationship(1, 1, 0, relationships.CreateRelationshipRequest{RelatedID: 1, Type: "friend"})
+
+	if !errors.Is(err, relationships.ErrSelfRelationship) {
+		t.Errorf("Expected ErrSelfRelationship, got %v", err)
+	}
+}
+
+// TestCreateRelationship_RelatedNotFound tests linking to a contact of another user
+func TestCreateRelationship_RelatedNotFound(t *testing.T) {
+	mockRepo := &MockRelationshipRepository{
+		FindContactFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if id == 2 {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		CreateRelationshipsFunc: func(relationships []contacts.Relationship) error {
+			t.Error("Expected nothing to be created")
+			return nil
+		},
+	}
+	service := relationships.NewRelationshipService(mockRepo)
+
+	_, err := service.CreateRelationship(1, 1, 0, relationships.CreateRelationshipRequest{RelatedID: 2, Type: "friend"})
+
+	if err == nil || err.Error() != "related contact not found" {
+		t.Errorf("Expected 'related contact not found', got %v", err)
+	}
+}
+
+// TestCreateRelationship_OtherOwner tests that a shared contact cannot be linked to one of the user's own
+func TestCreateRelationship_OtherOwner(t *testing.T) {
+	mockRepo := &MockRelationshipRepository{
+		FindContactFunc: func(id, user_id, workspace_id uint, permission string) (*contacts.Contact, error) {
+			if id == 2 {
+				return &contacts.Contact{ID: id, UserID: 9}, nil
+			}
+			return &contacts.Contact{ID: id, UserID: user_id}, nil
+		},
+		CreateRelationshipsFunc: func(relationships []contacts.Relationship) error {
+			t.Error("Expected nothing to be created")
+			return nil
+		},
+	}
+	service := relationships.NewRelationshipService(mockRepo)
+
+	_, err := service.CreateRelationship(1, 1, 0, relationships.CreateRelationshipRequest{RelatedID: 2, Type: "friend"})
+
+	if err == nil || err.Error() != "related contact not found" {
+		t.Errorf("Expected 'related contact not found', got %v", err)
+	}
+}
+
+// TestDeleteRelationship_NotFound tests deleting a relationship of another contact
+func TestDeleteRelationship_NotFound(t *testing.T) {
+	mockRepo := &MockRelationshipRepository{
+		FindRelationshipFunc: func(id, contact_id uint) (*contacts.Relationship, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	service := relationships.NewRelationshipService(mockRepo)
+
+	err := service.DeleteRelationship(1, 5, 1, 0)
+
+	if err == nil || err.Error() != "relationship not found" {
+		t.Errorf("Expected 'relationship not found', got %v", err)
+	}
+}
+
+// TestGetRelated_Depth tests that traversal stops at the requested depth and skips visited contacts
+func TestGetRelated_Depth(t *testing.T) {
+	mockRepo := graphRepository([]contacts.Relationship{
+		{ID: 1, ContactID: 1, RelatedID: 2, Type: "spouse"},
+		{ID: 2, ContactID: 2, RelatedID: 1, Type: "spouse"},
+		{ID: 3, ContactID: 2, RelatedID: 3, Type: "parent"},
+		{ID: 4, ContactID: 1, RelatedID: 4, Type: "colleague"},
+		{ID: 5, ContactID: 3, RelatedID: 5, Type: "sibling"},
+	})
+	service := relationships.NewRelationshipService(mockRepo)
+
+	one, err := service.GetRelated(1, 1, 0, 1, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(one.Data) != 2 || one.Data[0].Contact.ID != 2 || one.Data[1].Contact.ID != 4 {
+		t.Errorf("Expected contacts 2 and 4 at depth 1, got %+v", one.Data)
+	}
+
+	two, err := service.GetRelated(1, 1, 0, 2, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(two.Data) != 3 {
+		t.Fatalf("Expected 3 contacts at depth 2, got %d", len(two.Data))
+	}
+
+	if two.Data[2].Contact.ID != 3 || two.Data[2].Via != 2 || two.Data[2].Depth != 2 || two.Data[2].Type != "parent" {
+		t.Errorf("Unexpected second hop %+v", two.Data[2])
+	}
+}
+
+// TestGetRelated_FilterByType tests following only the requested types
+func TestGetRelated_FilterByType(t *testing.T) {
+	mockRepo := graphRepository([]contacts.Relationship{
+		{ID: 1, ContactID: 1, RelatedID: 2, Type: "spouse"},
+		{ID: 2, ContactID: 1, RelatedID: 3, Type: "colleague"},
+	})
+	service := relationships.NewRelationshipService(mockRepo)
+
+	result, err := service.GetRelated(1, 1, 0, 1, []string{"colleague"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Data) != 1 || result.Data[0].Contact.ID != 3 {
+		t.Errorf("Expected only contact 3, got %+v", result.Data)
+	}
+}
+
+// TestGetRelated_InvalidDepth tests rejecting a depth above the maximum
+func TestGetRelated_InvalidDepth(t *testing.T) {
+	service := relationships.NewRelationshipService(&MockRelationshipRepository{})
+
+	_, err := service.GetRelated(1, 1, 0, relationships.MaxDepth+1, nil)
+
+	if !errors.Is(err, relationships.ErrInvalidDepth) {
+		t.Errorf("Expected ErrInvalidDepth, got %v", err)
+	}
+}
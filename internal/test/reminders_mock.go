@@ -0,0 +1,73 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/reminders"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+// MockReminderRepository implements reminders.ReminderRepository interface
+type MockReminderRepository struct {
+	FindUserByIdFunc         func(user_id uint) (*users.User, error)
+	EachUserBatchFunc        func(fn func(user_list []users.User) error) error
+	FindWorkspaceIdsFunc     func(user_id uint) ([]uint, error)
+	GetSourcesFunc           func(user_id, workspace_id uint) ([]reminders.Source, error)
+	CreateNotificationsFunc  func(notifications []reminders.Notification) (int64, error)
+	GetNotificationsFunc     func(user_id uint, page, limit int, unread bool) (*reminders.GetNotificationsResponse, error)
+	MarkNotificationReadFunc func(id, user_id uint) (*reminders.Notification, error)
+}
+
+// FindUserById implements reminders.ReminderRepository
+func (m *MockReminderRepository) FindUserById(user_id uint) (*users.User, error) {
+	if m.FindUserByIdFunc != nil {
+		return m.FindUserByIdFunc(user_id)
+	}
+	return &users.User{ID: user_id, Timezone: "UTC"}, nil
+}
+
+// EachUserBatch implements reminders.ReminderRepository
+func (m *MockReminderRepository) EachUserBatch(fn func(user_list []users.User) error) error {
+	if m.EachUserBatchFunc != nil {
+		return m.EachUserBatchFunc(fn)
+	}
+	return nil
+}
+
+// FindWorkspaceIds implements reminders.ReminderRepository
+func (m *MockReminderRepository) FindWorkspaceIds(user_id uint) ([]uint, error) {
+	if m.FindWorkspaceIdsFunc != nil {
+		return m.FindWorkspaceIdsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// GetSources implements reminders.ReminderRepository
+func (m *MockReminderRepository) GetSources(user_id, workspace_id uint) ([]reminders.Source, error) {
+	if m.GetSourcesFunc != nil {
+		return m.GetSourcesFunc(user_id, workspace_id)
+	}
+	return nil, nil
+}
+
+// CreateNotifications implements reminders.ReminderRepository
+func (m *MockReminderRepository) CreateNotifications(notifications []reminders.Notification) (int64, error) {
+	if m.CreateNotificationsFunc != nil {
+		return m.CreateNotificationsFunc(notifications)
+	}
+	return int64(len(notifications)), nil
+}
+
+// GetNotifications implements reminders.ReminderRepository
+func (m *MockReminderRepository) GetNotifications(user_id uint, page, limit int, unread bool) (*reminders.GetNotificationsResponse, error) {
+	if m.GetNotificationsFunc != nil {
+		return m.GetNotificationsFunc(user_id, page, limit, unread)
+	}
+	return nil, nil
+}
+
+// MarkNotificationRead implements reminders.ReminderRepository
+func (m *MockReminderRepository) MarkNotificationRead(id, user_id uint) (*reminders.Notification, error) {
+	if m.MarkNotificationReadFunc != nil {
+		return m.MarkNotificationReadFunc(id, user_id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,261 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/date"
+	"github.com/DioSaputra28/belajar-gin-1/internal/reminders"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"gorm.io/gorm"
+)
+
+// ========== Upcoming Tests ==========
+
+// TestUpcoming_OrderAndWindow tests that only dates inside the window are returned, soonest first
+func TestUpcoming_OrderAndWindow(t *testing.T) {
+	today := date.New(2025, time.December, 20)
+	sources := []reminders.Source{
+		{ContactID: 1, ContactName: "Alice", Kind: reminders.KindBirthday, Label: "birthday", Date: date.New(1990, time.January, 5)},
+		{ContactID: 2, ContactName: "Bob", Kind: reminders.KindAnniversary, Label: "anniversary", Date: date.New(2015, time.December, 20)},
+		{ContactID: 3, ContactName: "Carol", Kind: reminders.KindBirthday, Label: "birthday", Date: date.New(1985, time.March, 1)},
+	}
+
+	result := reminders.Upcoming(sources, today, 30)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 reminders, got %d (%+v)", len(result), result)
+	}
+
+	if result[0].ContactID != 2 || result[0].DaysUntil != 0 || result[0].Years != 10 {
+		t.Errorf("Expected Bob's 10th anniversary today first, got %+v", result[0])
+	}
+
+	if result[1].ContactID != 1 || result[1].Date.String() != "2026-01-05" || result[1].Years != 36 {
+		t.Errorf("Expected Alice's birthday on 2026-01-05, got %+v", result[1])
+	}
+}
+
+// TestUpcoming_LeapDay tests that February 29 falls on February 28 in common years
+func TestUpcoming_LeapDay(t *testing.T) {
+	sources := []reminders.Source{
+		{ContactID: 1, ContactName: "Leap", Kind: reminders.KindBirthday, Label: "birthday", Date: date.New(2000, time.February, 29)},
+	}
+
+	result := reminders.Upcoming(sources, date.New(2026, time.February, 1), 30)
+	if len(result) != 1 || result[0].Date.String() != "2026-02-28" {
+		t.Errorf("Expected reminder on 2026-02-28, got %+v", result)
+	}
+
+	result = reminders.Upcoming(sources, date.New(2028, time.February, 1), 30)
+	if len(result) != 1 || result[0].Date.String() != "2028-02-29" {
+		t.Errorf("Expected reminder on 2028-02-29, got %+v", result)
+	}
+}
+
+// TestToday_Timezone tests that the current date depends on the user's timezone
+func TestToday_Timezone(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 20, 0, 0, 0, time.UTC)
+
+	if got := reminders.Today(now, "Asia/Jakarta").String(); got != "2025-06-02" {
+		t.Errorf("Expected 2025-06-02 in Asia/Jakarta, got %s", got)
+	}
+
+	if got := reminders.Today(now, "America/Los_Angeles").String(); got != "2025-06-01" {
+		t.Errorf("Expected 2025-06-01 in America/Los_Angeles, got %s", got)
+	}
+
+	if got := reminders.Today(now, "Not/AZone").String(); got != "2025-06-01" {
+		t.Errorf("Expected unknown timezones to fall back to UTC, got %s", got)
+	}
+}
+
+// TestDate_JSON tests that dates are encoded without a time of day
+func TestDate_JSON(t *testing.T) {
+	var value struct {
+		Birthday *date.Date `json:"birthday"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"birthday":"1990-05-17"}`), &value); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	encoded, _ := json.Marshal(value)
+	if string(encoded) != `{"birthday":"1990-05-17"}` {
+		t.Errorf("Expected round trip, got %s", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"birthday":"17/05/1990"}`), &value); err == nil {
+		t.Error("Expected an error for a non ISO date")
+	}
+}
+
+// ========== GetUpcoming Tests ==========
+
+// TestGetUpcoming_InvalidDays tests the window limits
+func TestGetUpcoming_InvalidDays(t *testing.T) {
+	service := reminders.NewReminderService(&MockReminderRepository{})
+
+	for _, days := range []int{0, 400} {
+		if _, err := service.GetUpcoming(1, 0, days); !errors.Is(err, reminders.ErrInvalidDays) {
+			t.Errorf("Expected ErrInvalidDays for %d days, got %v", days, err)
+		}
+	}
+}
+
+// TestGetUpcoming_UserNotFound tests upcoming reminders for a deleted user
+func TestGetUpcoming_UserNotFound(t *testing.T) {
+	mockRepo := &MockReminderRepository{
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	_, err := service.GetUpcoming(1, 0, 30)
+
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found' error, got %v", err)
+	}
+}
+
+// TestGetUpcoming_Success tests that the user's timezone is reported
+func TestGetUpcoming_Success(t *testing.T) {
+	mockRepo := &MockReminderRepository{
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return &users.User{ID: user_id, Timezone: "Asia/Jakarta"}, nil
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	result, err := service.GetUpcoming(1, 0, 30)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Timezone != "Asia/Jakarta" || result.Days != 30 || result.Data == nil {
+		t.Errorf("Unexpected response %+v", result)
+	}
+}
+
+// ========== GenerateNotifications Tests ==========
+
+// TestGenerateNotifications_PerUserTimezone tests that each user's own date is used
+func TestGenerateNotifications_PerUserTimezone(t *testing.T) {
+	var saved []reminders.Notification
+	mockRepo := &MockReminderRepository{
+		EachUserBatchFunc: func(fn func(user_list []users.User) error) error {
+			return fn([]users.User{
+				{ID: 1, Timezone: "Asia/Tokyo"},
+				{ID: 2, Timezone: "America/New_York"},
+			})
+		},
+		GetSourcesFunc: func(user_id, workspace_id uint) ([]reminders.Source, error) {
+			return []reminders.Source{
+				{ContactID: user_id, ContactName: "Dana", Kind: reminders.KindBirthday, Label: "birthday", Date: date.New(1990, time.March, 10)},
+			}, nil
+		},
+		CreateNotificationsFunc: func(notifications []reminders.Notification) (int64, error) {
+			saved = append(saved, notifications...)
+			return int64(len(notifications)), nil
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	// 2025-03-09 20:00 UTC is already March 10 in Tokyo but still March 9 in New York.
+	created, err := service.GenerateNotifications(time.Date(2025, time.March, 9, 20, 0, 0, 0, time.UTC), 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created != 1 || len(saved) != 1 || saved[0].UserID != 1 {
+		t.Fatalf("Expected one notification for the Tokyo user, got %+v", saved)
+	}
+
+	if saved[0].Message != "Dana's birthday is today (2025-03-10)" {
+		t.Errorf("Unexpected message %q", saved[0].Message)
+	}
+}
+
+// ========== MarkNotificationRead Tests ==========
+
+// TestMarkNotificationRead_NotFound tests marking a notification of another user
+func TestMarkNotificationRead_NotFound(t *testing.T) {
+	mockRepo := &MockReminderRepository{
+		MarkNotificationReadFunc: func(id, user_id uint) (*reminders.Notification, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	_, err := service.MarkNotificationRead(1, 2)
+
+	if err == nil || err.Error() != "notification not found" {
+		t.Errorf("Expected 'notification not found' error, got %v", err)
+	}
+}
+
+// ========== Reminders Workspace Tests ==========
+
+// TestGetUpcoming_Workspace tests listing the dates of the active workspace
+func TestGetUpcoming_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockReminderRepository{
+		GetSourcesFunc: func(user_id, workspace_id uint) ([]reminders.Source, error) {
+			gotWorkspace = workspace_id
+			return nil, nil
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	if _, err := service.GetUpcoming(1, 3, 30); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 {
+		t.Errorf("Expected workspace 3, got %d", gotWorkspace)
+	}
+}
+
+// TestGenerateNotifications_Workspaces tests notifying about the contacts of every workspace of a user
+func TestGenerateNotifications_Workspaces(t *testing.T) {
+	var saved []reminders.Notification
+	mockRepo := &MockReminderRepository{
+		EachUserBatchFunc: func(fn func(user_list []users.User) error) error {
+			return fn([]users.User{{ID: 1, Timezone: "UTC"}})
+		},
+		FindWorkspaceIdsFunc: func(user_id uint) ([]uint, error) {
+			return []uint{3}, nil
+		},
+		GetSourcesFunc: func(user_id, workspace_id uint) ([]reminders.Source, error) {
+			// Contact 10 is personal, contact 30 belongs to workspace 3.
+			contact_ids := map[uint]uint{0: 10, 3: 30}
+			return []reminders.Source{
+				{ContactID: contact_ids[workspace_id], ContactName: "Dana", Kind: reminders.KindBirthday, Label: "birthday", Date: date.New(1990, time.March, 10)},
+			}, nil
+		},
+		CreateNotificationsFunc: func(notifications []reminders.Notification) (int64, error) {
+			saved = append(saved, notifications...)
+			return int64(len(notifications)), nil
+		},
+	}
+
+	service := reminders.NewReminderService(mockRepo)
+
+	created, err := service.GenerateNotifications(time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC), 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created != 2 || saved[0].ContactID != 10 || saved[1].ContactID != 30 {
+		t.Errorf("Expected notifications for the personal and the workspace contact, got %+v", saved)
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/search"
+)
+
+// MockSearchRepository implements search.SearchRepository interface. Without
+// funcs it serves the contacts and documents in Docs.
+type MockSearchRepository struct {
+	FindContactsFunc  func(contact_ids []uint) ([]contacts.Contact, error)
+	LoadDocumentsFunc func(contact_ids []uint) ([]search.Document, error)
+	Docs              map[uint]search.Document
+}
+
+// FindContacts implements search.SearchRepository
+func (m *MockSearchRepository) FindContacts(contact_ids []uint) ([]contacts.Contact, error) {
+	if m.FindContactsFunc != nil {
+		return m.FindContactsFunc(contact_ids)
+	}
+	var result []contacts.Contact
+	for _, id := range contact_ids {
+		if doc, ok := m.Docs[id]; ok {
+			result = append(result, contacts.Contact{ID: id, UserID: doc.UserID, WorkspaceID: doc.WorkspaceID})
+		}
+	}
+	return result, nil
+}
+
+// LoadDocuments implements search.SearchRepository
+func (m *MockSearchRepository) LoadDocuments(contact_ids []uint) ([]search.Document, error) {
+	if m.LoadDocumentsFunc != nil {
+		return m.LoadDocumentsFunc(contact_ids)
+	}
+	var result []search.Document
+	for _, id := range contact_ids {
+		if doc, ok := m.Docs[id]; ok {
+			result = append(result, doc)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/activities"
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/search"
+)
+
+// searchFixture indexes three contacts of user 1 and one of user 2
+func searchFixture() (*search.MemoryIndex, *MockSearchRepository) {
+	docs := []search.Document{
+		search.NewDocument(
+			contacts.Contact{ID: 1, UserID: 1, FirstName: "John", LastName: "Smith", Email: "john@acme.com"},
+			[]addresses.Address{{ID: 10, ContactID: 1, City: "London", Country: "UK"}},
+			nil,
+		),
+		search.NewDocument(
+			contacts.Contact{ID: 2, UserID: 1, FirstName: "Jane", LastName: "Johnson", Email: "jane@example.com"},
+			nil,
+			[]activities.Activity{{ID: 20, ContactID: 2, Body: "Met at the London office to discuss the renewal"}},
+		),
+		search.NewDocument(
+			contacts.Contact{ID: 3, UserID: 1, FirstName: "Peter", LastName: "Parker", Email: "peter@example.com"},
+			[]addresses.Address{{ID: 30, ContactID: 3, City: "New York", Country: "US"}},
+			nil,
+		),
+		search.NewDocument(
+			contacts.Contact{ID: 4, UserID: 2, FirstName: "John", LastName: "Other", Email: "other@example.com"},
+			nil,
+			nil,
+		),
+	}
+
+	index := search.NewMemoryIndex()
+	repo := &MockSearchRepository{Docs: map[uint]search.Document{}}
+	for _, doc := range docs {
+		index.Put(doc)
+		repo.Docs[doc.ContactID] = doc
+	}
+	return index, repo
+}
+
+func hitIds(hits []search.Hit) []uint {
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ContactID
+	}
+	return ids
+}
+
+// ========== Search Text Tests ==========
+
+// TestParseQuery_Terms tests splitting, lowercasing and removing repeated words
+func TestParseQuery_Terms(t *testing.T) {
+	terms := search.ParseQuery("  John +SMITH john@acme.com ")
+
+	if strings.Join(terms, ",") != "john,smith,acme,com" {
+		t.Errorf("Unexpected terms %v", terms)
+	}
+}
+
+// TestMark_PrefixAndEscape tests marking prefix matches and escaping HTML
+func TestMark_PrefixAndEscape(t *testing.T) {
+	snippet, ok := search.Mark("<b>Jonathan</b> & Jo", []string{"jo"})
+
+	if !ok {
+		t.Fatal("Expected a match")
+	}
+
+	if snippet != "&lt;b&gt;<mark>Jonathan</mark>&lt;/b&gt; &amp; <mark>Jo</mark>" {
+		t.Errorf("Unexpected snippet %q", snippet)
+	}
+
+	if _, ok := search.Mark("Peter Parker", []string{"jo"}); ok {
+		t.Error("Expected no match")
+	}
+}
+
+// TestMark_Snippet tests cutting long text around the first match
+func TestMark_Snippet(t *testing.T) {
+	text := strings.Repeat("lorem ipsum ", 30) + "renewal " + strings.Repeat("dolor sit ", 30)
+
+	snippet, ok := search.Mark(text, []string{"renew"})
+
+	if !ok || !strings.Contains(snippet, "<mark>renewal</mark>") {
+		t.Fatalf("Expected the match in the snippet, got %q", snippet)
+	}
+
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") || len(snippet) > 200 {
+		t.Errorf("Expected a short snippet, got %q", snippet)
+	}
+}
+
+// ========== Memory Index Tests ==========
+
+// TestMemoryIndex_Prefix tests that a term matches the words it starts
+func TestMemoryIndex_Prefix(t *testing.T) {
+	index, _ := searchFixture()
+
+	hits, total, err := index.Search(search.Query{UserID: 1, Terms: []string{"jo"}, Page: 1, Limit: 10})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if total != 2 {
+		t.Fatalf("Expected 2 hits, got %v", hitIds(hits))
+	}
+
+	// John matches in first name and e-mail; Jane only through Johnson.
+	if hits[0].ContactID != 1 || hits[1].ContactID != 2 {
+		t.Errorf("Expected contact 1 ranked first, got %v", hitIds(hits))
+	}
+}
+
+// TestMemoryIndex_AndAcrossSources tests that every term must match, in any source
+func TestMemoryIndex_AndAcrossSources(t *testing.T) {
+	index, _ := searchFixture()
+
+	hits, _, err := index.Search(search.Query{UserID: 1, Terms: []string{"jane", "london"}, Page: 1, Limit: 10})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(hits) != 1 || hits[0].ContactID != 2 {
+		t.Errorf("Expected only contact 2, got %v", hitIds(hits))
+	}
+}
+
+// TestMemoryIndex_Visibility tests that other users' contacts are not found
+func TestMemoryIndex_Visibility(t *testing.T) {
+	index, _ := searchFixture()
+
+	hits, _, err := index.Search(search.Query{UserID: 2, Terms: []string{"john"}, Page: 1, Limit: 10})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(hits) != 1 || hits[0].ContactID != 4 {
+		t.Errorf("Expected only contact 4, got %v", hitIds(hits))
+	}
+}
+
+// TestMemoryIndex_Remove tests that a removed document is no longer found
+func TestMemoryIndex_Remove(t *testing.T) {
+	index, _ := searchFixture()
+	index.Remove(3)
+
+	hits, _, _ := index.Search(search.Query{UserID: 1, Terms: []string{"parker"}, Page: 1, Limit: 10})
+
+	if len(hits) != 0 {
+		t.Errorf("Expected no hits, got %v", hitIds(hits))
+	}
+}
+
+// ========== Country Name Tests ==========
+
+// TestCountryCodes_AgreesWithMemoryIndex tests that a country name finds the same addresses in both indexes
+func TestCountryCodes_AgreesWithMemoryIndex(t *testing.T) {
+	index := search.NewMemoryIndex()
+	index.Put(search.NewDocument(
+		contacts.Contact{ID: 1, UserID: 1, FirstName: "Budi"},
+		[]addresses.Address{{ID: 10, ContactID: 1, City: "Bandung", Country: "ID"}},
+		nil,
+	))
+
+	for _, term := range []string{"indonesia", "indo"} {
+		hits, _, _ := index.Search(search.Query{UserID: 1, Terms: []string{term}, Page: 1, Limit: 10})
+		if len(hits) != 1 {
+			t.Errorf("%q: expected the memory index to find contact 1, got %v", term, hitIds(hits))
+		}
+		if codes := search.CountryCodes(term); !slices.Contains(codes, "ID") {
+			t.Errorf("%q: expected ID among %v", term, codes)
+		}
+	}
+
+	// The code itself is not a word of the name.
+	hits, _, _ := index.Search(search.Query{UserID: 1, Terms: []string{"id"}, Page: 1, Limit: 10})
+	if len(hits) != 0 || slices.Contains(search.CountryCodes("id"), "ID") {
+		t.Errorf("Expected the code not to match, got %v and %v", hitIds(hits), search.CountryCodes("id"))
+	}
+
+	if codes := search.CountryCodes("states"); !slices.Contains(codes, "US") {
+		t.Errorf("Expected any word of the name to match, got %v", codes)
+	}
+}
+
+// ========== Search Service Tests ==========
+
+// TestSearch_Highlights tests that results carry the matching fields
+func TestSearch_Highlights(t *testing.T) {
+	index, repo := searchFixture()
+	service := search.NewSearchService(index, repo)
+
+	response, err := service.Search(1, 0, "london", 1, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 2 || len(response.Data) != 2 {
+		t.Fatalf("Expected 2 results, got %+v", response)
+	}
+
+	// The address of contact 1 outweighs the activity of contact 2.
+	first := response.Data[0]
+	if first.Contact.ID != 1 || len(first.Highlights) != 1 {
+		t.Fatalf("Unexpected first result %+v", first)
+	}
+
+	if first.Highlights[0].Source != search.SourceAddress || first.Highlights[0].SourceID != 10 || first.Highlights[0].Snippet != "<mark>London</mark>" {
+		t.Errorf("Unexpected highlight %+v", first.Highlights[0])
+	}
+
+	if response.Data[1].Highlights[0].Source != search.SourceActivity {
+		t.Errorf("Expected an activity highlight, got %+v", response.Data[1].Highlights)
+	}
+}
+
+// TestSearch_Pagination tests paging through the ranked results
+func TestSearch_Pagination(t *testing.T) {
+	index, repo := searchFixture()
+	service := search.NewSearchService(index, repo)
+
+	response, err := service.Search(1, 0, "example", 2, 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 2 || response.TotalPages != 2 || len(response.Data) != 1 || response.Data[0].Contact.ID != 3 {
+		t.Errorf("Unexpected page %+v", response)
+	}
+}
+
+// TestSearch_EmptyQuery tests rejecting a query without words
+func TestSearch_EmptyQuery(t *testing.T) {
+	index, repo := searchFixture()
+	service := search.NewSearchService(index, repo)
+
+	_, err := service.Search(1, 0, " ?! ", 1, 10)
+
+	if !errors.Is(err, search.ErrEmptyQuery) {
+		t.Errorf("Expected ErrEmptyQuery, got %v", err)
+	}
+}
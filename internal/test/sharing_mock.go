@@ -0,0 +1,187 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/sharing"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+// MockShareRepository implements sharing.ShareRepository interface
+type MockShareRepository struct {
+	FindOwnedContactFunc      func(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindAccessibleContactFunc func(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindOwnedGroupFunc        func(id, user_id uint) (*groups.Group, error)
+	FindUserByEmailFunc       func(email string) (*users.User, error)
+	SaveShareFunc             func(share *sharing.Share) error
+	GetContactSharesFunc      func(contact_id uint) ([]sharing.Share, error)
+	GetGroupSharesFunc        func(group_id uint) ([]sharing.Share, error)
+	GetSharesFunc             func(user_id uint, received bool) ([]sharing.Share, error)
+	FindShareByIdFunc         func(id uint) (*sharing.Share, error)
+	DeleteShareFunc           func(id uint) error
+}
+
+// FindOwnedContact implements sharing.ShareRepository
+func (m *MockShareRepository) FindOwnedContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	if m.FindOwnedContactFunc != nil {
+		return m.FindOwnedContactFunc(id, user_id, workspace_id)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// FindAccessibleContact implements sharing.ShareRepository
+func (m *MockShareRepository) FindAccessibleContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	if m.FindAccessibleContactFunc != nil {
+		return m.FindAccessibleContactFunc(id, user_id, workspace_id)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// FindOwnedGroup implements sharing.ShareRepository
+func (m *MockShareRepository) FindOwnedGroup(id, user_id uint) (*groups.Group, error) {
+	if m.FindOwnedGroupFunc != nil {
+		return m.FindOwnedGroupFunc(id, user_id)
+	}
+	return &groups.Group{ID: id, UserID: user_id}, nil
+}
+
+// FindUserByEmail implements sharing.ShareRepository
+func (m *MockShareRepository) FindUserByEmail(email string) (*users.User, error) {
+	if m.FindUserByEmailFunc != nil {
+		return m.FindUserByEmailFunc(email)
+	}
+	return nil, nil
+}
+
+// SaveShare implements sharing.ShareRepository
+func (m *MockShareRepository) SaveShare(share *sharing.Share) error {
+	if m.SaveShareFunc != nil {
+		return m.SaveShareFunc(share)
+	}
+	return nil
+}
+
+// GetContactShares implements sharing.ShareRepository
+func (m *MockShareRepository) GetContactShares(contact_id uint) ([]sharing.Share, error) {
+	if m.GetContactSharesFunc != nil {
+		return m.GetContactSharesFunc(contact_id)
+	}
+	return nil, nil
+}
+
+// GetGroupShares implements sharing.ShareRepository
+func (m *MockShareRepository) GetGroupShares(group_id uint) ([]sharing.Share, error) {
+	if m.GetGroupSharesFunc != nil {
+		return m.GetGroupSharesFunc(group_id)
+	}
+	return nil, nil
+}
+
+// GetShares implements sharing.ShareRepository
+func (m *MockShareRepository) GetShares(user_id uint, received bool) ([]sharing.Share, error) {
+	if m.GetSharesFunc != nil {
+		return m.GetSharesFunc(user_id, received)
+	}
+	return nil, nil
+}
+
+// FindShareById implements sharing.ShareRepository
+func (m *MockShareRepository) FindShareById(id uint) (*sharing.Share, error) {
+	if m.FindShareByIdFunc != nil {
+		return m.FindShareByIdFunc(id)
+	}
+	return nil, nil
+}
+
+// DeleteShare implements sharing.ShareRepository
+func (m *MockShareRepository) DeleteShare(id uint) error {
+	if m.DeleteShareFunc != nil {
+		return m.DeleteShareFunc(id)
+	}
+	return nil
+}
+
+// MockGroupRepository implements groups.GroupRepository interface
+type MockGroupRepository struct {
+	GetGroupsFunc          func(user_id uint) ([]groups.Group, error)
+	FindGroupByIdFunc      func(id, user_id uint) (*groups.Group, error)
+	FindGroupByNameFunc    func(user_id uint, name string) (*groups.Group, error)
+	CreateGroupFunc        func(group *groups.Group) error
+	UpdateGroupFunc        func(group *groups.Group) error
+	DeleteGroupFunc        func(id uint) error
+	CountOwnedContactsFunc func(user_id, workspace_id uint, contact_ids []uint) (int64, error)
+	AddMembersFunc         func(group_id uint, contact_ids []uint) error
+	RemoveMembersFunc      func(group_id uint, contact_ids []uint) error
+}
+
+// GetGroups implements groups.GroupRepository
+func (m *MockGroupRepository) GetGroups(user_id uint) ([]groups.Group, error) {
+	if m.GetGroupsFunc != nil {
+		return m.GetGroupsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindGroupById implements groups.GroupRepository
+func (m *MockGroupRepository) FindGroupById(id, user_id uint) (*groups.Group, error) {
+	if m.FindGroupByIdFunc != nil {
+		return m.FindGroupByIdFunc(id, user_id)
+	}
+	return &groups.Group{ID: id, UserID: user_id}, nil
+}
+
+// FindGroupByName implements groups.GroupRepository
+func (m *MockGroupRepository) FindGroupByName(user_id uint, name string) (*groups.Group, error) {
+	if m.FindGroupByNameFunc != nil {
+		return m.FindGroupByNameFunc(user_id, name)
+	}
+	return nil, nil
+}
+
+// CreateGroup implements groups.GroupRepository
+func (m *MockGroupRepository) CreateGroup(group *groups.Group) error {
+	if m.CreateGroupFunc != nil {
+		return m.CreateGroupFunc(group)
+	}
+	return nil
+}
+
+// UpdateGroup implements groups.GroupRepository
+func (m *MockGroupRepository) UpdateGroup(group *groups.Group) error {
+	if m.UpdateGroupFunc != nil {
+		return m.UpdateGroupFunc(group)
+	}
+	return nil
+}
+
+// DeleteGroup implements groups.GroupRepository
+func (m *MockGroupRepository) DeleteGroup(id uint) error {
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(id)
+	}
+	return nil
+}
+
+// CountOwnedContacts implements groups.GroupRepository
+func (m *MockGroupRepository) CountOwnedContacts(user_id, workspace_id uint, contact_ids []uint) (int64, error) {
+	if m.CountOwnedContactsFunc != nil {
+		return m.CountOwnedContactsFunc(user_id, workspace_id, contact_ids)
+	}
+	return int64(len(contact_ids)), nil
+}
+
+// AddMembers implements groups.GroupRepository
+func (m *MockGroupRepository) AddMembers(group_id uint, contact_ids []uint) error {
+	if m.AddMembersFunc != nil {
+		return m.AddMembersFunc(group_id, contact_ids)
+	}
+	return nil
+}
+
+// RemoveMembers implements groups.GroupRepository
+func (m *MockGroupRepository) RemoveMembers(group_id uint, contact_ids []uint) error {
+	if m.RemoveMembersFunc != nil {
+		return m.RemoveMembersFunc(group_id, contact_ids)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/groups"
+	"github.com/DioSaputra28/belajar-gin-1/internal/sharing"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"gorm.io/gorm"
+)
+
+// ========== ShareContact Tests ==========
+
+// TestShareContact_Success tests granting a colleague access to a contact
+func TestShareContact_Success(t *testing.T) {
+	var saved *sharing.Share
+	mockRepo := &MockShareRepository{
+		FindUserByEmailFunc: func(email string) (*users.User, error) {
+			return &users.User{ID: 2, Email: email}, nil
+		},
+		SaveShareFunc: func(share *sharing.Share) error {
+			saved = share
+			return nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	_, err := service.ShareContact(1, 0, 10, sharing.ShareRequest{Email: "jane@example.com", Permission: contacts.PermissionEdit})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.OwnerID != 1 || saved.GranteeID != 2 || saved.ContactID == nil || *saved.ContactID != 10 || saved.GroupID != nil {
+		t.Errorf("Unexpected share %+v", saved)
+	}
+}
+
+// TestShareContact_NotOwner tests that only the owner can share a contact
+func TestShareContact_NotOwner(t *testing.T) {
+	mockRepo := &MockShareRepository{
+		FindOwnedContactFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	_, err := service.ShareContact(2, 0, 10, sharing.ShareRequest{Email: "john@example.com", Permission: contacts.PermissionView})
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
+
+// TestShareContact_WithYourself tests sharing with your own account
+func TestShareContact_WithYourself(t *testing.T) {
+	mockRepo := &MockShareRepository{
+		FindUserByEmailFunc: func(email string) (*users.User, error) {
+			return &users.User{ID: 1, Email: email}, nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	_, err := service.ShareContact(1, 0, 10, sharing.ShareRequest{Email: "john@example.com", Permission: contacts.PermissionView})
+
+	if err == nil || err.Error() != "cannot share with yourself" {
+		t.Errorf("Expected 'cannot share with yourself' error, got %v", err)
+	}
+}
+
+// ========== ShareGroup Tests ==========
+
+// TestShareGroup_Success tests granting access to a whole group
+func TestShareGroup_Success(t *testing.T) {
+	var saved *sharing.Share
+	mockRepo := &MockShareRepository{
+		FindUserByEmailFunc: func(email string) (*users.User, error) {
+			return &users.User{ID: 3, Email: email}, nil
+		},
+		SaveShareFunc: func(share *sharing.Share) error {
+			saved = share
+			return nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	if _, err := service.ShareGroup(1, 4, sharing.ShareRequest{Email: "team@example.com", Permission: contacts.PermissionView}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.GroupID == nil || *saved.GroupID != 4 || saved.ContactID != nil {
+		t.Errorf("Expected a share of group 4, got %+v", saved)
+	}
+}
+
+// ========== RevokeShare Tests ==========
+
+// TestRevokeShare_Unrelated tests that users outside a share cannot revoke it
+func TestRevokeShare_Unrelated(t *testing.T) {
+	deleted := false
+	mockRepo := &MockShareRepository{
+		FindShareByIdFunc: func(id uint) (*sharing.Share, error) {
+			return &sharing.Share{ID: id, OwnerID: 1, GranteeID: 2}, nil
+		},
+		DeleteShareFunc: func(id uint) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	if err := service.RevokeShare(3, 7); err == nil || err.Error() != "share not found" {
+		t.Errorf("Expected 'share not found' error, got %v", err)
+	}
+	if deleted {
+		t.Error("Expected the share to be kept")
+	}
+
+	if err := service.RevokeShare(2, 7); err != nil || !deleted {
+		t.Errorf("Expected the grantee to be able to give up the share, got %v", err)
+	}
+}
+
+// ========== Shared Contact Tests ==========
+
+// TestUpdateContact_SharedViewOnly tests that view grants cannot edit
+func TestUpdateContact_SharedViewOnly(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 1, FirstName: "John"}, nil
+		},
+		CanEditContactFunc: func(id, user_id, workspace_id uint) (bool, error) {
+			return false, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(10, 2, 0, contacts.Contact{FirstName: "Johnny"})
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestUpdateContact_SharedEdit tests that edit grants can update using the owner's custom fields
+func TestUpdateContact_SharedEdit(t *testing.T) {
+	var fields_of uint
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 1, FirstName: "John"}, nil
+		},
+		CanEditContactFunc: func(id, user_id, workspace_id uint) (bool, error) {
+			return true, nil
+		},
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			fields_of = user_id
+			return testCustomFields(), nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(10, 2, 0, contacts.Contact{CustomFields: map[string]any{"tier": "gold"}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fields_of != 1 {
+		t.Errorf("Expected the owner's custom fields, got fields of user %d", fields_of)
+	}
+}
+
+// TestDeleteContact_Shared tests that only the owner can delete a contact
+func TestDeleteContact_Shared(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 1}, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	if err := service.DeleteContact(10, 2, 0); !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// ========== Group Tests ==========
+
+// TestCreateGroup_DuplicateName tests creating a group with a name already in use
+func TestCreateGroup_DuplicateName(t *testing.T) {
+	mockRepo := &MockGroupRepository{
+		FindGroupByNameFunc: func(user_id uint, name string) (*groups.Group, error) {
+			return &groups.Group{ID: 1, UserID: user_id, Name: name}, nil
+		},
+	}
+
+	service := groups.NewGroupService(mockRepo)
+
+	_, err := service.CreateGroup(1, groups.CreateGroupRequest{Name: "Clients"})
+
+	if err == nil || err.Error() != "group with this name already exists" {
+		t.Errorf("Expected duplicate name error, got %v", err)
+	}
+}
+
+// TestAddContacts_NotOwned tests adding a contact of another user to a group
+func TestAddContacts_NotOwned(t *testing.T) {
+	mockRepo := &MockGroupRepository{
+		CountOwnedContactsFunc: func(user_id, workspace_id uint, contact_ids []uint) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	service := groups.NewGroupService(mockRepo)
+
+	err := service.AddContacts(1, 1, 0, groups.MembersRequest{ContactIDs: []uint{3, 4, 4}})
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
+
+// TestAddContacts_Success tests that duplicate ids are added once
+func TestAddContacts_Success(t *testing.T) {
+	var added []uint
+	mockRepo := &MockGroupRepository{
+		AddMembersFunc: func(group_id uint, contact_ids []uint) error {
+			added = contact_ids
+			return nil
+		},
+	}
+
+	service := groups.NewGroupService(mockRepo)
+
+	if err := service.AddContacts(1, 1, 0, groups.MembersRequest{ContactIDs: []uint{3, 4, 3}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(added) != 2 {
+		t.Errorf("Expected 2 contacts to be added, got %v", added)
+	}
+}
+
+// ========== Sharing Workspace Tests ==========
+
+// TestShareContact_WorkspaceContact tests that organization contacts are not shared one by one
+func TestShareContact_WorkspaceContact(t *testing.T) {
+	workspace_id := uint(3)
+	var gotWorkspace uint
+	mockRepo := &MockShareRepository{
+		FindOwnedContactFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			gotWorkspace = workspace_id
+			return &contacts.Contact{ID: id, UserID: 2, WorkspaceID: &workspace_id}, nil
+		},
+		SaveShareFunc: func(share *sharing.Share) error {
+			t.Fatalf("Expected no share to be saved")
+			return nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	_, err := service.ShareContact(1, workspace_id, 10, sharing.ShareRequest{Email: "jane@example.com", Permission: contacts.PermissionView})
+
+	if !errors.Is(err, sharing.ErrWorkspaceContact) {
+		t.Errorf("Expected ErrWorkspaceContact, got %v", err)
+	}
+	if gotWorkspace != 3 {
+		t.Errorf("Expected the contact to be looked up in workspace 3, got %d", gotWorkspace)
+	}
+}
+
+// TestGetContactShares_Workspace tests reading the shares of a contact in the active workspace
+func TestGetContactShares_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockShareRepository{
+		FindAccessibleContactFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			gotWorkspace = workspace_id
+			return &contacts.Contact{ID: id, UserID: 2}, nil
+		},
+	}
+
+	service := sharing.NewShareService(mockRepo)
+
+	response, err := service.GetContactShares(1, 3, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 || response.OwnerID != 2 {
+		t.Errorf("Expected the contact of workspace 3, got %+v in workspace %d", response, gotWorkspace)
+	}
+}
+
+// TestAddContacts_Workspace tests grouping contacts of the active workspace
+func TestAddContacts_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockGroupRepository{
+		CountOwnedContactsFunc: func(user_id, workspace_id uint, contact_ids []uint) (int64, error) {
+			gotWorkspace = workspace_id
+			return int64(len(contact_ids)), nil
+		},
+	}
+
+	service := groups.NewGroupService(mockRepo)
+
+	if err := service.AddContacts(1, 1, 3, groups.MembersRequest{ContactIDs: []uint{3, 4}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 {
+		t.Errorf("Expected the contacts to be checked in workspace 3, got %d", gotWorkspace)
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+)
+
+// MockTrashRepository implements trash.TrashRepository interface
+type MockTrashRepository struct {
+	GetContactsFunc                func(user_id, workspace_id uint) ([]contacts.Contact, error)
+	GetAddressesFunc               func(user_id, workspace_id uint) ([]addresses.Address, error)
+	FindContactFunc                func(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindAddressFunc                func(id, user_id, workspace_id uint) (*addresses.Address, error)
+	RestoreContactFunc             func(user_id uint, contact *contacts.Contact) error
+	RestoreAddressFunc             func(user_id uint, address *addresses.Address) error
+	PurgeContactFunc               func(id uint) error
+	PurgeAddressFunc               func(id uint) error
+	GetContactIdsDeletedBeforeFunc func(before time.Time) ([]uint, error)
+	PurgeDeletedBeforeFunc         func(before time.Time) (int64, error)
+}
+
+// GetContacts implements trash.TrashRepository
+func (m *MockTrashRepository) GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error) {
+	if m.GetContactsFunc != nil {
+		return m.GetContactsFunc(user_id, workspace_id)
+	}
+	return nil, nil
+}
+
+// GetAddresses implements trash.TrashRepository
+func (m *MockTrashRepository) GetAddresses(user_id, workspace_id uint) ([]addresses.Address, error) {
+	if m.GetAddressesFunc != nil {
+		return m.GetAddressesFunc(user_id, workspace_id)
+	}
+	return nil, nil
+}
+
+// FindContact implements trash.TrashRepository
+func (m *MockTrashRepository) FindContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	if m.FindContactFunc != nil {
+		return m.FindContactFunc(id, user_id, workspace_id)
+	}
+	return &contacts.Contact{ID: id, UserID: user_id}, nil
+}
+
+// FindAddress implements trash.TrashRepository
+func (m *MockTrashRepository) FindAddress(id, user_id, workspace_id uint) (*addresses.Address, error) {
+	if m.FindAddressFunc != nil {
+		return m.FindAddressFunc(id, user_id, workspace_id)
+	}
+	return &addresses.Address{ID: id}, nil
+}
+
+// RestoreContact implements trash.TrashRepository
+func (m *MockTrashRepository) RestoreContact(user_id uint, contact *contacts.Contact) error {
+	if m.RestoreContactFunc != nil {
+		return m.RestoreContactFunc(user_id, contact)
+	}
+	return nil
+}
+
+// RestoreAddress implements trash.TrashRepository
+func (m *MockTrashRepository) RestoreAddress(user_id uint, address *addresses.Address) error {
+	if m.RestoreAddressFunc != nil {
+		return m.RestoreAddressFunc(user_id, address)
+	}
+	return nil
+}
+
+// PurgeContact implements trash.TrashRepository
+func (m *MockTrashRepository) PurgeContact(id uint) error {
+	if m.PurgeContactFunc != nil {
+		return m.PurgeContactFunc(id)
+	}
+	return nil
+}
+
+// PurgeAddress implements trash.TrashRepository
+func (m *MockTrashRepository) PurgeAddress(id uint) error {
+	if m.PurgeAddressFunc != nil {
+		return m.PurgeAddressFunc(id)
+	}
+	return nil
+}
+
+// GetContactIdsDeletedBefore implements trash.TrashRepository
+func (m *MockTrashRepository) GetContactIdsDeletedBefore(before time.Time) ([]uint, error) {
+	if m.GetContactIdsDeletedBeforeFunc != nil {
+		return m.GetContactIdsDeletedBeforeFunc(before)
+	}
+	return nil, nil
+}
+
+// PurgeDeletedBefore implements trash.TrashRepository
+func (m *MockTrashRepository) PurgeDeletedBefore(before time.Time) (int64, error) {
+	if m.PurgeDeletedBeforeFunc != nil {
+		return m.PurgeDeletedBeforeFunc(before)
+	}
+	return 0, nil
+}
+
+// MockAvatarRemover implements trash.AvatarRemover interface
+type MockAvatarRemover struct {
+	DeleteContactAvatarsFunc func(contact_ids []uint) error
+}
+
+// DeleteContactAvatars implements trash.AvatarRemover
+func (m *MockAvatarRemover) DeleteContactAvatars(contact_ids []uint) error {
+	if m.DeleteContactAvatarsFunc != nil {
+		return m.DeleteContactAvatarsFunc(contact_ids)
+	}
+	return nil
+}
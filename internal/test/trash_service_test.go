@@ -0,0 +1,292 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/trash"
+	"gorm.io/gorm"
+)
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+// ========== GetTrash Tests ==========
+
+// TestGetTrash_MergesAndSorts tests listing contacts and addresses newest first
+func TestGetTrash_MergesAndSorts(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	mockRepo := &MockTrashRepository{
+		GetContactsFunc: func(user_id, workspace_id uint) ([]contacts.Contact, error) {
+			return []contacts.Contact{
+				{ID: 1, FirstName: "John", LastName: "Doe", DeletedAt: deletedAt(now.Add(-2 * time.Hour))},
+			}, nil
+		},
+		GetAddressesFunc: func(user_id, workspace_id uint) ([]addresses.Address, error) {
+			return []addresses.Address{
+				{ID: 7, ContactID: 2, City: "Jakarta", Country: "Indonesia", DeletedAt: deletedAt(now.Add(-time.Hour))},
+			}, nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, 30*24*time.Hour)
+
+	response, err := service.GetTrash(1, 0, "", 1, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 2 || len(response.Data) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(response.Data))
+	}
+
+	address := response.Data[0]
+	if address.Type != trash.TypeAddress || address.ID != 7 || address.Name != "Jakarta, Indonesia" {
+		t.Errorf("Expected address 7 first, got %+v", address)
+	}
+
+	contact := response.Data[1]
+	if contact.Type != trash.TypeContact || contact.Name != "John Doe" {
+		t.Errorf("Expected contact John Doe second, got %+v", contact)
+	}
+
+	if !contact.ExpiresAt.Equal(contact.DeletedAt.Add(30 * 24 * time.Hour)) {
+		t.Errorf("Expected expiry 30 days after deletion, got %v", contact.ExpiresAt)
+	}
+}
+
+// TestGetTrash_FilterByType tests that type=contact skips addresses
+func TestGetTrash_FilterByType(t *testing.T) {
+	mockRepo := &MockTrashRepository{
+		GetAddressesFunc: func(user_id, workspace_id uint) ([]addresses.Address, error) {
+			t.Error("Expected addresses not to be read")
+			return nil, nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	_, err := service.GetTrash(1, 0, trash.TypeContact, 1, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+// TestGetTrash_InvalidType tests rejecting an unknown type
+func TestGetTrash_InvalidType(t *testing.T) {
+	service := trash.NewTrashService(&MockTrashRepository{}, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	_, err := service.GetTrash(1, 0, "group", 1, 10)
+
+	if !errors.Is(err, trash.ErrInvalidType) {
+		t.Errorf("Expected ErrInvalidType, got %v", err)
+	}
+}
+
+// TestGetTrash_Pagination tests paging past the end of the trash
+func TestGetTrash_Pagination(t *testing.T) {
+	mockRepo := &MockTrashRepository{
+		GetContactsFunc: func(user_id, workspace_id uint) ([]contacts.Contact, error) {
+			return []contacts.Contact{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	response, err := service.GetTrash(1, 0, "", 2, 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Data) != 1 || response.TotalPages != 2 {
+		t.Errorf("Expected 1 item on page 2 of 2, got %d items of %d pages", len(response.Data), response.TotalPages)
+	}
+
+	response, _ = service.GetTrash(1, 0, "", 5, 2)
+	if len(response.Data) != 0 {
+		t.Errorf("Expected empty page, got %d items", len(response.Data))
+	}
+}
+
+// ========== Restore Tests ==========
+
+// TestRestore_Contact tests restoring a contact from the trash
+func TestRestore_Contact(t *testing.T) {
+	var restored uint
+	mockRepo := &MockTrashRepository{
+		RestoreContactFunc: func(user_id uint, contact *contacts.Contact) error {
+			restored = contact.ID
+			return nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	err := service.Restore(1, 0, trash.TypeContact, 5)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if restored != 5 {
+		t.Errorf("Expected contact 5 to be restored, got %d", restored)
+	}
+}
+
+// TestRestore_NotInTrash tests restoring a record that is not in the trash
+func TestRestore_NotInTrash(t *testing.T) {
+	mockRepo := &MockTrashRepository{
+		FindContactFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	err := service.Restore(1, 0, trash.TypeContact, 5)
+
+	if err == nil || err.Error() != "contact not found in trash" {
+		t.Errorf("Expected 'contact not found in trash', got %v", err)
+	}
+}
+
+// TestRestore_AddressOfDeletedContact tests that the contact must be restored first
+func TestRestore_AddressOfDeletedContact(t *testing.T) {
+	mockRepo := &MockTrashRepository{
+		FindAddressFunc: func(id, user_id, workspace_id uint) (*addresses.Address, error) {
+			return &addresses.Address{ID: id, Contact: contacts.Contact{DeletedAt: deletedAt(time.Now())}}, nil
+		},
+		RestoreAddressFunc: func(user_id uint, address *addresses.Address) error {
+			t.Error("Expected address not to be restored")
+			return nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	err := service.Restore(1, 0, trash.TypeAddress, 7)
+
+	if !errors.Is(err, trash.ErrContactInTrash) {
+		t.Errorf("Expected ErrContactInTrash, got %v", err)
+	}
+}
+
+// ========== Purge Tests ==========
+
+// TestPurge_Address tests permanently deleting an address
+func TestPurge_Address(t *testing.T) {
+	var purged uint
+	mockRepo := &MockTrashRepository{
+		PurgeAddressFunc: func(id uint) error {
+			purged = id
+			return nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, trash.DefaultRetention)
+
+	err := service.Purge(1, 0, trash.TypeAddress, 7)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if purged != 7 {
+		t.Errorf("Expected address 7 to be purged, got %d", purged)
+	}
+}
+
+// TestPurge_ContactAvatar tests that the avatar files go before the contact is purged
+func TestPurge_ContactAvatar(t *testing.T) {
+	var steps []string
+	mockRepo := &MockTrashRepository{
+		FindContactFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id}, nil
+		},
+		PurgeContactFunc: func(id uint) error {
+			steps = append(steps, "purge")
+			return nil
+		},
+	}
+	remover := &MockAvatarRemover{DeleteContactAvatarsFunc: func(contact_ids []uint) error {
+		if len(contact_ids) != 1 || contact_ids[0] != 5 {
+			t.Errorf("Expected the avatar of contact 5, got %v", contact_ids)
+		}
+		steps = append(steps, "avatars")
+		return nil
+	}}
+
+	service := trash.NewTrashService(mockRepo, remover, trash.DefaultRetention)
+
+	if err := service.Purge(1, 0, trash.TypeContact, 5); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(steps) != 2 || steps[0] != "avatars" || steps[1] != "purge" {
+		t.Errorf("Expected the avatars to go first, got %v", steps)
+	}
+}
+
+// TestPurgeExpired_ContactAvatars tests that the retention job removes the avatars of expired contacts
+func TestPurgeExpired_ContactAvatars(t *testing.T) {
+	failure := errors.New("store unavailable")
+	mockRepo := &MockTrashRepository{
+		GetContactIdsDeletedBeforeFunc: func(before time.Time) ([]uint, error) {
+			return []uint{3, 4}, nil
+		},
+		PurgeDeletedBeforeFunc: func(before time.Time) (int64, error) {
+			t.Error("Expected nothing to be purged")
+			return 0, nil
+		},
+	}
+	var removed []uint
+	remover := &MockAvatarRemover{DeleteContactAvatarsFunc: func(contact_ids []uint) error {
+		removed = contact_ids
+		return failure
+	}}
+
+	service := trash.NewTrashService(mockRepo, remover, trash.DefaultRetention)
+
+	_, err := service.PurgeExpired(time.Now())
+
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the avatar error, got %v", err)
+	}
+	if len(removed) != 2 || removed[0] != 3 || removed[1] != 4 {
+		t.Errorf("Expected the avatars of contacts 3 and 4, got %v", removed)
+	}
+}
+
+// TestPurgeExpired_Cutoff tests that the retention job purges records older than the retention
+func TestPurgeExpired_Cutoff(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	var cutoff time.Time
+	mockRepo := &MockTrashRepository{
+		PurgeDeletedBeforeFunc: func(before time.Time) (int64, error) {
+			cutoff = before
+			return 3, nil
+		},
+	}
+
+	service := trash.NewTrashService(mockRepo, &MockAvatarRemover{}, 7*24*time.Hour)
+
+	purged, err := service.PurgeExpired(now)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if purged != 3 {
+		t.Errorf("Expected 3 purged, got %d", purged)
+	}
+
+	if !cutoff.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("Expected cutoff a week ago, got %v", cutoff)
+	}
+}
@@ -255,6 +255,31 @@ func TestUpdateUser_Success(t *testing.T) {
 	}
 }
 
+// TestUpdateUser_Timezone tests that only IANA timezone names are accepted
+func TestUpdateUser_Timezone(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		FindUserByIdFunc: func(id uint) (*users.UserResponse, error) {
+			return &users.UserResponse{ID: id}, nil
+		},
+		UpdateUserFunc: func(id uint, user users.UpdateUserRequest) error {
+			return nil
+		},
+	}
+
+	service := users.NewUserService(mockRepo)
+
+	if err := service.UpdateUser(1, users.UpdateUserRequest{Timezone: "Asia/Jakarta"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	for _, timezone := range []string{"Mars/Olympus", "Local"} {
+		err := service.UpdateUser(1, users.UpdateUserRequest{Timezone: timezone})
+		if err == nil || err.Error() != "unknown timezone" {
+			t.Errorf("Expected 'unknown timezone' error for %q, got %v", timezone, err)
+		}
+	}
+}
+
 // TestUpdateUser_NameOnly tests updating only the name
 func TestUpdateUser_NameOnly(t *testing.T) {
 	mockRepo := &MockUserRepository{
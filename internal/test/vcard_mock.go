@@ -0,0 +1,74 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+)
+
+// MockVCardRepository implements vcard.VCardRepository interface
+type MockVCardRepository struct {
+	GetContactsFunc              func(user_id, workspace_id uint, search string) ([]contacts.Contact, error)
+	FindContactByIdFunc          func(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	GetAddressesByContactIdsFunc func(contact_ids []uint) ([]addresses.Address, error)
+	CreateContactFunc            func(contact *contacts.Contact, address_list []addresses.Address) error
+	GetCustomFieldsFunc          func(user_id uint) ([]customfields.Field, error)
+	FindUserRegionFunc           func(user_id uint) (string, error)
+	FindWorkspaceRoleFunc        func(workspace_id, user_id uint) (string, error)
+}
+
+// GetContacts implements vcard.VCardRepository
+func (m *MockVCardRepository) GetContacts(user_id, workspace_id uint, search string) ([]contacts.Contact, error) {
+	if m.GetContactsFunc != nil {
+		return m.GetContactsFunc(user_id, workspace_id, search)
+	}
+	return nil, nil
+}
+
+// FindContactById implements vcard.VCardRepository
+func (m *MockVCardRepository) FindContactById(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	if m.FindContactByIdFunc != nil {
+		return m.FindContactByIdFunc(id, user_id, workspace_id)
+	}
+	return nil, nil
+}
+
+// GetAddressesByContactIds implements vcard.VCardRepository
+func (m *MockVCardRepository) GetAddressesByContactIds(contact_ids []uint) ([]addresses.Address, error) {
+	if m.GetAddressesByContactIdsFunc != nil {
+		return m.GetAddressesByContactIdsFunc(contact_ids)
+	}
+	return nil, nil
+}
+
+// CreateContact implements vcard.VCardRepository
+func (m *MockVCardRepository) CreateContact(contact *contacts.Contact, address_list []addresses.Address) error {
+	if m.CreateContactFunc != nil {
+		return m.CreateContactFunc(contact, address_list)
+	}
+	return nil
+}
+
+// FindUserRegion implements vcard.VCardRepository
+func (m *MockVCardRepository) FindUserRegion(user_id uint) (string, error) {
+	if m.FindUserRegionFunc != nil {
+		return m.FindUserRegionFunc(user_id)
+	}
+	return "", nil
+}
+
+// GetCustomFields implements vcard.VCardRepository
+func (m *MockVCardRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	if m.GetCustomFieldsFunc != nil {
+		return m.GetCustomFieldsFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindWorkspaceRole implements vcard.VCardRepository
+func (m *MockVCardRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	if m.FindWorkspaceRoleFunc != nil {
+		return m.FindWorkspaceRoleFunc(workspace_id, user_id)
+	}
+	return "", nil
+}
@@ -0,0 +1,361 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/vcard"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+	"gorm.io/gorm"
+)
+
+// ========== Codec Tests ==========
+
+// TestVCardDecode_MultipleCards tests parsing of folded, escaped and grouped properties
+func TestVCardDecode_MultipleCards(t *testing.T) {
+	data := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Doe;John;;;\r\n" +
+		"FN:John Doe\r\n" +
+		"item1.EMAIL;TYPE=INTERNET:john@example.com\r\n" +
+		"TEL;TYPE=CELL:+62 812 3456\r\n" +
+		"ADR;TYPE=HOME:;;Jl. Sudirman 1\\, Blok A;Jakar\r\n" +
+		" ta;DKI;10220;Indonesia\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\n" +
+		"VERSION:4.0\n" +
+		"FN:Jane Smith\n" +
+		"EMAIL:jane@example.com\n" +
+		"END:VCARD\n"
+
+	cards, errs := vcard.Decode([]byte(data))
+
+	if len(cards) != 2 {
+		t.Fatalf("Expected 2 cards, got %d", len(cards))
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Expected no error for card %d, got %v", i+1, err)
+		}
+	}
+
+	if cards[0].FirstName != "John" || cards[0].LastName != "Doe" {
+		t.Errorf("Expected 'John Doe', got '%s %s'", cards[0].FirstName, cards[0].LastName)
+	}
+
+	if len(cards[0].Emails) != 1 || cards[0].Emails[0] != "john@example.com" {
+		t.Errorf("Expected grouped email to be parsed, got %v", cards[0].Emails)
+	}
+
+	if len(cards[0].Addresses) != 1 {
+		t.Fatalf("Expected 1 address, got %d", len(cards[0].Addresses))
+	}
+
+	address := cards[0].Addresses[0]
+	if address.Street != "Jl. Sudirman 1, Blok A" {
+		t.Errorf("Expected unescaped street, got '%s'", address.Street)
+	}
+
+	if address.City != "Jakarta" {
+		t.Errorf("Expected folded city 'Jakarta', got '%s'", address.City)
+	}
+
+	if address.Type != "home" {
+		t.Errorf("Expected address type 'home', got '%s'", address.Type)
+	}
+
+	if cards[1].FullName != "Jane Smith" {
+		t.Errorf("Expected full name 'Jane Smith', got '%s'", cards[1].FullName)
+	}
+}
+
+// TestVCardDecode_MissingEnd tests that an unterminated card is reported
+func TestVCardDecode_MissingEnd(t *testing.T) {
+	cards, errs := vcard.Decode([]byte("BEGIN:VCARD\nVERSION:3.0\nFN:John\n"))
+
+	if len(cards) != 1 {
+		t.Fatalf("Expected 1 card, got %d", len(cards))
+	}
+
+	if errs[0] == nil {
+		t.Error("Expected error for missing END:VCARD, got nil")
+	}
+}
+
+// TestVCardEncode_RoundTrip tests that encoded cards decode to the same values
+func TestVCardEncode_RoundTrip(t *testing.T) {
+	for _, version := range []string{vcard.Version3, vcard.Version4} {
+		card := vcard.Card{
+			FirstName: "John",
+			LastName:  "Doe; Jr",
+			Emails:    []string{"john@example.com"},
+			Phones:    []string{"+628123456"},
+			Addresses: []vcard.CardAddress{
+				{Street: strings.Repeat("Jalan Panjang ", 8), City: "Bandung", PostalCode: "40111", Country: "Indonesia"},
+			},
+		}
+
+		data := vcard.Encode([]vcard.Card{card}, version)
+
+		for _, line := range strings.Split(string(data), "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("Expected folded lines of at most 75 octets, got %d", len(line))
+			}
+		}
+
+		cards, errs := vcard.Decode(data)
+		if len(cards) != 1 || errs[0] != nil {
+			t.Fatalf("Expected 1 valid card for version %s, got %d (%v)", version, len(cards), errs)
+		}
+
+		if cards[0].LastName != "Doe; Jr" {
+			t.Errorf("Expected last name 'Doe; Jr', got '%s'", cards[0].LastName)
+		}
+
+		if len(cards[0].Phones) != 1 || cards[0].Phones[0] != "+628123456" {
+			t.Errorf("Expected phone '+628123456' for version %s, got %v", version, cards[0].Phones)
+		}
+
+		if cards[0].Addresses[0].Street != strings.TrimSpace(card.Addresses[0].Street) {
+			t.Errorf("Expected street to survive folding, got '%s'", cards[0].Addresses[0].Street)
+		}
+	}
+}
+
+// ========== ExportContacts Tests ==========
+
+// TestVCardExportContacts_Success tests that contacts are exported with their addresses
+func TestVCardExportContacts_Success(t *testing.T) {
+	mockRepo := &MockVCardRepository{
+		GetContactsFunc: func(user_id, workspace_id uint, search string) ([]contacts.Contact, error) {
+			return []contacts.Contact{
+				{ID: 1, UserID: user_id, FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+				{ID: 2, UserID: user_id, FirstName: "Jane", Email: "jane@example.com"},
+			}, nil
+		},
+		GetAddressesByContactIdsFunc: func(contact_ids []uint) ([]addresses.Address, error) {
+			return []addresses.Address{
+				{ID: 1, ContactID: 1, City: "Bandung", Country: "Indonesia"},
+			}, nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	data, err := service.ExportContacts(1, 0, "", vcard.Version3)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := string(data)
+	if strings.Count(output, "BEGIN:VCARD") != 2 {
+		t.Errorf("Expected 2 cards, got output:\n%s", output)
+	}
+
+	if !strings.Contains(output, "ADR:;;;Bandung;;;Indonesia") {
+		t.Errorf("Expected address line in output, got:\n%s", output)
+	}
+}
+
+// TestVCardExportContact_NotFound tests exporting a contact of another user
+func TestVCardExportContact_NotFound(t *testing.T) {
+	mockRepo := &MockVCardRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	_, err := service.ExportContact(1, 999, 0, vcard.Version3)
+
+	if err == nil || err.Error() != "contact not found" {
+		t.Errorf("Expected 'contact not found' error, got %v", err)
+	}
+}
+
+// ========== ImportContacts Tests ==========
+
+// TestVCardImportContacts_PerCardErrors tests that invalid cards are reported without stopping the import
+func TestVCardImportContacts_PerCardErrors(t *testing.T) {
+	var created []contacts.Contact
+	var createdAddresses int
+	mockRepo := &MockVCardRepository{
+		CreateContactFunc: func(contact *contacts.Contact, address_list []addresses.Address) error {
+			if contact.Email == "fail@example.com" {
+				return errors.New("database connection error")
+			}
+			contact.ID = uint(len(created) + 1)
+			created = append(created, *contact)
+			createdAddresses += len(address_list)
+			return nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	data := "BEGIN:VCARD\nVERSION:3.0\nFN:John Doe\nEMAIL:john@example.com\nADR:;;Main St;Bandung;;;Indonesia\nEND:VCARD\n" +
+		"BEGIN:VCARD\nVERSION:3.0\nFN:No Email\nEND:VCARD\n" +
+		"BEGIN:VCARD\nVERSION:3.0\nFN:No Country\nEMAIL:nc@example.com\nADR:;;Main St;Bandung;;;\nEND:VCARD\n" +
+		"BEGIN:VCARD\nVERSION:3.0\nFN:Broken Db\nEMAIL:fail@example.com\nEND:VCARD\n"
+
+	response, err := service.ImportContacts(7, 0, []byte(data))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 4 || response.Imported != 1 || response.Failed != 3 {
+		t.Errorf("Expected 4 total, 1 imported, 3 failed, got %d/%d/%d", response.Total, response.Imported, response.Failed)
+	}
+
+	if len(created) != 1 || created[0].UserID != 7 || created[0].FirstName != "John" || created[0].LastName != "Doe" {
+		t.Errorf("Expected John Doe to be created for user 7, got %+v", created)
+	}
+
+	if createdAddresses != 1 {
+		t.Errorf("Expected 1 address to be created, got %d", createdAddresses)
+	}
+
+	if response.Results[0].ContactID != 1 || response.Results[0].Error != "" {
+		t.Errorf("Expected first card to succeed, got %+v", response.Results[0])
+	}
+
+	for _, result := range response.Results[1:] {
+		if result.Error == "" {
+			t.Errorf("Expected error for card %d, got none", result.Index)
+		}
+	}
+}
+
+// TestVCardImportContacts_Empty tests importing a body without any card
+func TestVCardImportContacts_Empty(t *testing.T) {
+	service := vcard.NewVCardService(&MockVCardRepository{}, nil)
+
+	_, err := service.ImportContacts(1, 0, []byte("hello"))
+
+	if err == nil {
+		t.Error("Expected error for empty import, got nil")
+	}
+}
+
+// TestVCardImportContacts_Geocodes tests that imported addresses get coordinates
+func TestVCardImportContacts_Geocodes(t *testing.T) {
+	var saved []addresses.Address
+	mockRepo := &MockVCardRepository{
+		CreateContactFunc: func(contact *contacts.Contact, address_list []addresses.Address) error {
+			saved = address_list
+			return nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, geo.NewOfflineGeocoder())
+
+	_, err := service.ImportContacts(1, 0, []byte("BEGIN:VCARD\nVERSION:3.0\nFN:John Doe\nEMAIL:john@example.com\nADR:;;Jl. Braga 1;Bandung;Jawa Barat;;Indonesia\nEND:VCARD\n"))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(saved) != 1 || saved[0].Latitude == nil || saved[0].Longitude == nil || *saved[0].Latitude > -6.8 || *saved[0].Latitude < -7 {
+		t.Errorf("Expected the coordinates of Bandung, got %+v", saved)
+	}
+}
+
+// TestVCardImportContacts_RequiredCustomField tests that cards fail while the user has a required custom field
+func TestVCardImportContacts_RequiredCustomField(t *testing.T) {
+	mockRepo := &MockVCardRepository{
+		GetCustomFieldsFunc: func(user_id uint) ([]customfields.Field, error) {
+			return []customfields.Field{{ID: 2, UserID: user_id, Key: "department", Type: customfields.TypeText, Required: true}}, nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address_list []addresses.Address) error {
+			t.Error("Expected no contact to be created")
+			return nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	response, err := service.ImportContacts(1, 0, []byte("BEGIN:VCARD\nVERSION:3.0\nFN:John Doe\nEMAIL:john@example.com\nEND:VCARD\n"))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Failed != 1 || !strings.Contains(response.Results[0].Error, "department is required") {
+		t.Errorf("Expected the card to fail on the required field, got %+v", response)
+	}
+}
+
+// ========== vCard Workspace Tests ==========
+
+// TestVCardExportContacts_Workspace tests exporting the contacts of the active workspace
+func TestVCardExportContacts_Workspace(t *testing.T) {
+	var gotWorkspace uint
+	mockRepo := &MockVCardRepository{
+		GetContactsFunc: func(user_id, workspace_id uint, search string) ([]contacts.Contact, error) {
+			gotWorkspace = workspace_id
+			return []contacts.Contact{{ID: 1, FirstName: "Jane"}}, nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	if _, err := service.ExportContacts(1, 3, "", vcard.Version3); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotWorkspace != 3 {
+		t.Errorf("Expected workspace 3, got %d", gotWorkspace)
+	}
+}
+
+// TestVCardImportContacts_Workspace tests that imported contacts join the active workspace
+func TestVCardImportContacts_Workspace(t *testing.T) {
+	var created *contacts.Contact
+	mockRepo := &MockVCardRepository{
+		FindWorkspaceRoleFunc: func(workspace_id, user_id uint) (string, error) {
+			return workspaces.RoleMember, nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address_list []addresses.Address) error {
+			created = contact
+			return nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	response, err := service.ImportContacts(7, 3, []byte("BEGIN:VCARD\nVERSION:3.0\nFN:Jane Doe\nEMAIL:jane@example.com\nEND:VCARD\n"))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Imported != 1 || created == nil || created.WorkspaceID == nil || *created.WorkspaceID != 3 {
+		t.Errorf("Expected the contact in workspace 3, got %+v", created)
+	}
+}
+
+// TestVCardImportContacts_WorkspaceViewer tests that viewers cannot import into a workspace
+func TestVCardImportContacts_WorkspaceViewer(t *testing.T) {
+	mockRepo := &MockVCardRepository{
+		FindWorkspaceRoleFunc: func(workspace_id, user_id uint) (string, error) {
+			return workspaces.RoleViewer, nil
+		},
+		CreateContactFunc: func(contact *contacts.Contact, address_list []addresses.Address) error {
+			t.Fatalf("Expected no contact to be created")
+			return nil
+		},
+	}
+
+	service := vcard.NewVCardService(mockRepo, nil)
+
+	_, err := service.ImportContacts(7, 3, []byte("BEGIN:VCARD\nVERSION:3.0\nFN:Jane Doe\nEMAIL:jane@example.com\nEND:VCARD\n"))
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
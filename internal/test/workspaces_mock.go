@@ -0,0 +1,184 @@
+package test
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+	"gorm.io/gorm"
+)
+
+// MockWorkspaceRepository implements workspaces.WorkspaceRepository interface
+type MockWorkspaceRepository struct {
+	GetWorkspacesFunc         func(user_id uint) ([]workspaces.WorkspaceResponse, error)
+	FindWorkspaceByIdFunc     func(id uint) (*workspaces.Workspace, error)
+	CreateWorkspaceFunc       func(workspace *workspaces.Workspace) error
+	UpdateWorkspaceFunc       func(workspace *workspaces.Workspace) error
+	FindUserByIdFunc          func(user_id uint) (*users.User, error)
+	SetActiveWorkspaceFunc    func(user_id uint, workspace_id *uint) error
+	FindMemberFunc            func(workspace_id, user_id uint) (*workspaces.Member, error)
+	GetMembersFunc            func(workspace_id uint) ([]workspaces.Member, error)
+	CountOwnersFunc           func(workspace_id uint) (int64, error)
+	UpdateMemberRoleFunc      func(workspace_id, user_id uint, role string) error
+	DeleteMemberFunc          func(workspace_id, user_id uint) error
+	CreateInvitationFunc      func(invitation *workspaces.Invitation) error
+	GetInvitationsFunc        func(workspace_id uint) ([]workspaces.Invitation, error)
+	GetInvitationsByEmailFunc func(email string) ([]workspaces.Invitation, error)
+	FindInvitationByIdFunc    func(id, workspace_id uint) (*workspaces.Invitation, error)
+	FindInvitationByTokenFunc func(token string) (*workspaces.Invitation, error)
+	DeleteInvitationFunc      func(id uint) error
+	AcceptInvitationFunc      func(invitation *workspaces.Invitation, user_id uint) error
+}
+
+// GetWorkspaces implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) GetWorkspaces(user_id uint) ([]workspaces.WorkspaceResponse, error) {
+	if m.GetWorkspacesFunc != nil {
+		return m.GetWorkspacesFunc(user_id)
+	}
+	return nil, nil
+}
+
+// FindWorkspaceById implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) FindWorkspaceById(id uint) (*workspaces.Workspace, error) {
+	if m.FindWorkspaceByIdFunc != nil {
+		return m.FindWorkspaceByIdFunc(id)
+	}
+	return &workspaces.Workspace{ID: id, Name: "Acme"}, nil
+}
+
+// CreateWorkspace implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) CreateWorkspace(workspace *workspaces.Workspace) error {
+	if m.CreateWorkspaceFunc != nil {
+		return m.CreateWorkspaceFunc(workspace)
+	}
+	return nil
+}
+
+// UpdateWorkspace implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) UpdateWorkspace(workspace *workspaces.Workspace) error {
+	if m.UpdateWorkspaceFunc != nil {
+		return m.UpdateWorkspaceFunc(workspace)
+	}
+	return nil
+}
+
+// FindUserById implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) FindUserById(user_id uint) (*users.User, error) {
+	if m.FindUserByIdFunc != nil {
+		return m.FindUserByIdFunc(user_id)
+	}
+	return &users.User{ID: user_id}, nil
+}
+
+// SetActiveWorkspace implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) SetActiveWorkspace(user_id uint, workspace_id *uint) error {
+	if m.SetActiveWorkspaceFunc != nil {
+		return m.SetActiveWorkspaceFunc(user_id, workspace_id)
+	}
+	return nil
+}
+
+// FindMember implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) FindMember(workspace_id, user_id uint) (*workspaces.Member, error) {
+	if m.FindMemberFunc != nil {
+		return m.FindMemberFunc(workspace_id, user_id)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// GetMembers implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) GetMembers(workspace_id uint) ([]workspaces.Member, error) {
+	if m.GetMembersFunc != nil {
+		return m.GetMembersFunc(workspace_id)
+	}
+	return nil, nil
+}
+
+// CountOwners implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) CountOwners(workspace_id uint) (int64, error) {
+	if m.CountOwnersFunc != nil {
+		return m.CountOwnersFunc(workspace_id)
+	}
+	return 1, nil
+}
+
+// UpdateMemberRole implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) UpdateMemberRole(workspace_id, user_id uint, role string) error {
+	if m.UpdateMemberRoleFunc != nil {
+		return m.UpdateMemberRoleFunc(workspace_id, user_id, role)
+	}
+	return nil
+}
+
+// DeleteMember implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) DeleteMember(workspace_id, user_id uint) error {
+	if m.DeleteMemberFunc != nil {
+		return m.DeleteMemberFunc(workspace_id, user_id)
+	}
+	return nil
+}
+
+// CreateInvitation implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) CreateInvitation(invitation *workspaces.Invitation) error {
+	if m.CreateInvitationFunc != nil {
+		return m.CreateInvitationFunc(invitation)
+	}
+	return nil
+}
+
+// GetInvitations implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) GetInvitations(workspace_id uint) ([]workspaces.Invitation, error) {
+	if m.GetInvitationsFunc != nil {
+		return m.GetInvitationsFunc(workspace_id)
+	}
+	return nil, nil
+}
+
+// GetInvitationsByEmail implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) GetInvitationsByEmail(email string) ([]workspaces.Invitation, error) {
+	if m.GetInvitationsByEmailFunc != nil {
+		return m.GetInvitationsByEmailFunc(email)
+	}
+	return nil, nil
+}
+
+// FindInvitationById implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) FindInvitationById(id, workspace_id uint) (*workspaces.Invitation, error) {
+	if m.FindInvitationByIdFunc != nil {
+		return m.FindInvitationByIdFunc(id, workspace_id)
+	}
+	return &workspaces.Invitation{ID: id, WorkspaceID: workspace_id}, nil
+}
+
+// FindInvitationByToken implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) FindInvitationByToken(token string) (*workspaces.Invitation, error) {
+	if m.FindInvitationByTokenFunc != nil {
+		return m.FindInvitationByTokenFunc(token)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// DeleteInvitation implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) DeleteInvitation(id uint) error {
+	if m.DeleteInvitationFunc != nil {
+		return m.DeleteInvitationFunc(id)
+	}
+	return nil
+}
+
+// AcceptInvitation implements workspaces.WorkspaceRepository
+func (m *MockWorkspaceRepository) AcceptInvitation(invitation *workspaces.Invitation, user_id uint) error {
+	if m.AcceptInvitationFunc != nil {
+		return m.AcceptInvitationFunc(invitation, user_id)
+	}
+	return nil
+}
+
+// memberRoles returns a FindMemberFunc backed by a user_id -> role map.
+func memberRoles(roles map[uint]string) func(workspace_id, user_id uint) (*workspaces.Member, error) {
+	return func(workspace_id, user_id uint) (*workspaces.Member, error) {
+		role, ok := roles[user_id]
+		if !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &workspaces.Member{WorkspaceID: workspace_id, UserID: user_id, Role: role}, nil
+	}
+}
@@ -0,0 +1,350 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+)
+
+// ========== ResolveWorkspace Tests ==========
+
+// TestResolveWorkspace_Header tests selecting a workspace with the header
+func TestResolveWorkspace_Header(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleViewer}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	workspace_id, err := service.ResolveWorkspace(1, "5")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if workspace_id != 5 {
+		t.Errorf("Expected workspace 5, got %d", workspace_id)
+	}
+}
+
+// TestResolveWorkspace_HeaderNotMember tests that the header cannot select a foreign workspace
+func TestResolveWorkspace_HeaderNotMember(t *testing.T) {
+	service := workspaces.NewWorkspaceService(&MockWorkspaceRepository{})
+
+	_, err := service.ResolveWorkspace(1, "5")
+
+	if !errors.Is(err, workspaces.ErrNotMember) {
+		t.Errorf("Expected ErrNotMember, got %v", err)
+	}
+}
+
+// TestResolveWorkspace_HeaderPersonal tests that 0 selects the personal workspace
+func TestResolveWorkspace_HeaderPersonal(t *testing.T) {
+	active := uint(5)
+	mockRepo := &MockWorkspaceRepository{
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return &users.User{ID: user_id, ActiveWorkspaceID: &active}, nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	workspace_id, err := service.ResolveWorkspace(1, "0")
+
+	if err != nil || workspace_id != workspaces.Personal {
+		t.Errorf("Expected personal workspace, got %d (%v)", workspace_id, err)
+	}
+}
+
+// TestResolveWorkspace_StoredWorkspaceLeft tests falling back to personal after leaving the stored workspace
+func TestResolveWorkspace_StoredWorkspaceLeft(t *testing.T) {
+	active := uint(5)
+	mockRepo := &MockWorkspaceRepository{
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return &users.User{ID: user_id, ActiveWorkspaceID: &active}, nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	workspace_id, err := service.ResolveWorkspace(1, "")
+
+	if err != nil || workspace_id != workspaces.Personal {
+		t.Errorf("Expected personal workspace, got %d (%v)", workspace_id, err)
+	}
+}
+
+// ========== Member Tests ==========
+
+// TestUpdateMember_AdminCannotGrantOwner tests that only owners hand out ownership
+func TestUpdateMember_AdminCannotGrantOwner(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleAdmin, 2: workspaces.RoleMember}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	err := service.UpdateMember(5, 1, 2, workspaces.UpdateMemberRequest{Role: workspaces.RoleOwner})
+
+	if !errors.Is(err, workspaces.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestUpdateMember_LastOwner tests that the last owner cannot be demoted
+func TestUpdateMember_LastOwner(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleOwner}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	err := service.UpdateMember(5, 1, 1, workspaces.UpdateMemberRequest{Role: workspaces.RoleAdmin})
+
+	if !errors.Is(err, workspaces.ErrLastOwner) {
+		t.Errorf("Expected ErrLastOwner, got %v", err)
+	}
+}
+
+// TestUpdateMember_Success tests an admin changing a member's role
+func TestUpdateMember_Success(t *testing.T) {
+	var updated string
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleAdmin, 2: workspaces.RoleMember}),
+		UpdateMemberRoleFunc: func(workspace_id, user_id uint, role string) error {
+			updated = role
+			return nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	err := service.UpdateMember(5, 1, 2, workspaces.UpdateMemberRequest{Role: workspaces.RoleViewer})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated != workspaces.RoleViewer {
+		t.Errorf("Expected role viewer, got %q", updated)
+	}
+}
+
+// TestRemoveMember_Leave tests that a viewer can leave a workspace
+func TestRemoveMember_Leave(t *testing.T) {
+	var removed uint
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{3: workspaces.RoleViewer}),
+		DeleteMemberFunc: func(workspace_id, user_id uint) error {
+			removed = user_id
+			return nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	if err := service.RemoveMember(5, 3, 3); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if removed != 3 {
+		t.Errorf("Expected user 3 removed, got %d", removed)
+	}
+}
+
+// TestRemoveMember_MemberCannotRemoveOthers tests that members cannot remove colleagues
+func TestRemoveMember_MemberCannotRemoveOthers(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleMember, 2: workspaces.RoleViewer}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	err := service.RemoveMember(5, 1, 2)
+
+	if !errors.Is(err, workspaces.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// ========== Invitation Tests ==========
+
+// TestCreateInvitation_Success tests inviting a colleague by email
+func TestCreateInvitation_Success(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleOwner}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	invitation, err := service.CreateInvitation(5, 1, workspaces.InviteRequest{Email: "Jane@Example.com", Role: workspaces.RoleMember})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if invitation.Email != "jane@example.com" || invitation.Token == "" || invitation.WorkspaceID != 5 {
+		t.Errorf("Unexpected invitation %+v", invitation)
+	}
+
+	if !invitation.ExpiresAt.After(time.Now()) {
+		t.Error("Expected invitation to expire in the future")
+	}
+}
+
+// TestCreateInvitation_Forbidden tests that members cannot invite
+func TestCreateInvitation_Forbidden(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindMemberFunc: memberRoles(map[uint]string{1: workspaces.RoleMember}),
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	_, err := service.CreateInvitation(5, 1, workspaces.InviteRequest{Email: "jane@example.com", Role: workspaces.RoleMember})
+
+	if !errors.Is(err, workspaces.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestAcceptInvitation_Success tests joining a workspace with an invitation
+func TestAcceptInvitation_Success(t *testing.T) {
+	joined := false
+	mockRepo := &MockWorkspaceRepository{
+		FindInvitationByTokenFunc: func(token string) (*workspaces.Invitation, error) {
+			return &workspaces.Invitation{ID: 1, WorkspaceID: 5, Email: "jane@example.com", Role: workspaces.RoleMember, ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return &users.User{ID: user_id, Email: "Jane@example.com"}, nil
+		},
+		AcceptInvitationFunc: func(invitation *workspaces.Invitation, user_id uint) error {
+			joined = true
+			return nil
+		},
+		FindMemberFunc: func(workspace_id, user_id uint) (*workspaces.Member, error) {
+			return &workspaces.Member{WorkspaceID: workspace_id, UserID: user_id, Role: workspaces.RoleMember}, nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	workspace, err := service.AcceptInvitation("token", 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !joined || workspace.ID != 5 || workspace.Role != workspaces.RoleMember {
+		t.Errorf("Unexpected workspace %+v", workspace)
+	}
+}
+
+// TestAcceptInvitation_WrongEmail tests that invitations are bound to their email
+func TestAcceptInvitation_WrongEmail(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindInvitationByTokenFunc: func(token string) (*workspaces.Invitation, error) {
+			return &workspaces.Invitation{ID: 1, WorkspaceID: 5, Email: "jane@example.com", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		FindUserByIdFunc: func(user_id uint) (*users.User, error) {
+			return &users.User{ID: user_id, Email: "mallory@example.com"}, nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	_, err := service.AcceptInvitation("token", 2)
+
+	if !errors.Is(err, workspaces.ErrInvitationEmail) {
+		t.Errorf("Expected ErrInvitationEmail, got %v", err)
+	}
+}
+
+// TestAcceptInvitation_Expired tests that expired invitations cannot be used
+func TestAcceptInvitation_Expired(t *testing.T) {
+	mockRepo := &MockWorkspaceRepository{
+		FindInvitationByTokenFunc: func(token string) (*workspaces.Invitation, error) {
+			return &workspaces.Invitation{ID: 1, WorkspaceID: 5, Email: "jane@example.com", ExpiresAt: time.Now().Add(-time.Hour)}, nil
+		},
+	}
+
+	service := workspaces.NewWorkspaceService(mockRepo)
+
+	_, err := service.AcceptInvitation("token", 2)
+
+	if !errors.Is(err, workspaces.ErrInvitationExpired) {
+		t.Errorf("Expected ErrInvitationExpired, got %v", err)
+	}
+}
+
+// ========== Workspace Contact Tests ==========
+
+// TestCreateContact_WorkspaceViewer tests that viewers cannot add contacts to a workspace
+func TestCreateContact_WorkspaceViewer(t *testing.T) {
+	mockRepo := &MockContactRepository{
+		FindWorkspaceRoleFunc: func(workspace_id, user_id uint) (string, error) {
+			return workspaces.RoleViewer, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	workspace_id := uint(5)
+	_, err := service.CreateContact(contacts.Contact{UserID: 1, WorkspaceID: &workspace_id, FirstName: "John", Email: "john@example.com"})
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestUpdateContact_WorkspaceViewer tests that viewers cannot change workspace contacts they created
+func TestUpdateContact_WorkspaceViewer(t *testing.T) {
+	workspace_id := uint(5)
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: user_id, WorkspaceID: &workspace_id}, nil
+		},
+		CanEditContactFunc: func(id, user_id, workspace_id uint) (bool, error) {
+			return false, nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	err := service.UpdateContact(10, 1, workspace_id, contacts.Contact{FirstName: "Jane"})
+
+	if !errors.Is(err, contacts.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestDeleteContact_WorkspaceMember tests that members can delete colleagues' workspace contacts
+func TestDeleteContact_WorkspaceMember(t *testing.T) {
+	workspace_id := uint(5)
+	deleted := false
+	mockRepo := &MockContactRepository{
+		FindContactByIdFunc: func(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: id, UserID: 2, WorkspaceID: &workspace_id}, nil
+		},
+		CanEditContactFunc: func(id, user_id, workspace_id uint) (bool, error) {
+			return true, nil
+		},
+		DeleteContactFunc: func(id, user_id, workspace_id uint) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	service := contacts.NewContactService(mockRepo)
+
+	if err := service.DeleteContact(10, 1, workspace_id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !deleted {
+		t.Error("Expected contact to be deleted")
+	}
+}
@@ -0,0 +1,109 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler interface {
+	GetTrash(c *gin.Context)
+	RestoreItem(c *gin.Context)
+	PurgeItem(c *gin.Context)
+}
+
+type trashHandler struct {
+	svc TrashService
+}
+
+func NewTrashHandler(svc TrashService) TrashHandler {
+	return &trashHandler{svc: svc}
+}
+
+func (h *trashHandler) GetTrash(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intPage, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || intPage < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	intLimit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || intLimit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	response, err := h.svc.GetTrash(user_id.(uint), c.GetUint("workspace_id"), c.Query("type"), intPage, intLimit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Trash retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *trashHandler) RestoreItem(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	if err := h.svc.Restore(user_id.(uint), c.GetUint("workspace_id"), c.Param("type"), uint(intId)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Item restored successfully",
+	})
+}
+
+func (h *trashHandler) PurgeItem(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	intId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	if err := h.svc.Purge(user_id.(uint), c.GetUint("workspace_id"), c.Param("type"), uint(intId)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Item permanently deleted",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidType), errors.Is(err, ErrContactInTrash):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,33 @@
+package trash
+
+import (
+	"time"
+)
+
+const (
+	TypeContact = "contact"
+	TypeAddress = "address"
+
+	// DefaultRetention is how long deleted records stay in the trash when
+	// TRASH_RETENTION_DAYS is not set.
+	DefaultRetention = 30 * 24 * time.Hour
+)
+
+// Item is a soft-deleted contact or address. Addresses of a deleted contact
+// are not listed on their own; they come back with the contact.
+type Item struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	ContactID uint      `json:"contact_id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type GetTrashResponse struct {
+	Data       []Item `json:"data"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+}
@@ -0,0 +1,49 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Purger periodically removes records that have outlived the trash
+// retention. Like the reminder scheduler it runs inside the API process.
+type Purger struct {
+	svc      TrashService
+	interval time.Duration
+}
+
+func NewPurger(svc TrashService, interval time.Duration) *Purger {
+	return &Purger{svc: svc, interval: interval}
+}
+
+// Start runs the purger in the background until ctx is cancelled. The first
+// run happens immediately.
+func (p *Purger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			p.RunOnce(time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce purges what has expired at now.
+func (p *Purger) RunOnce(now time.Time) {
+	purged, err := p.svc.PurgeExpired(now)
+	if err != nil {
+		log.Printf("trash: purging expired records failed: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("trash: purged %d records", purged)
+	}
+}
@@ -0,0 +1,200 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+
+	"gorm.io/gorm"
+)
+
+type TrashRepository interface {
+	GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error)
+	GetAddresses(user_id, workspace_id uint) ([]addresses.Address, error)
+	FindContact(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	FindAddress(id, user_id, workspace_id uint) (*addresses.Address, error)
+	RestoreContact(user_id uint, contact *contacts.Contact) error
+	RestoreAddress(user_id uint, address *addresses.Address) error
+	PurgeContact(id uint) error
+	PurgeAddress(id uint) error
+	GetContactIdsDeletedBefore(before time.Time) ([]uint, error)
+	PurgeDeletedBefore(before time.Time) (int64, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// GetContacts returns the deleted contacts of the active workspace that
+// user_id may edit.
+func (r *trashRepository) GetContacts(user_id, workspace_id uint) ([]contacts.Contact, error) {
+	var contacts_db []contacts.Contact
+	err := r.db.Unscoped().
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionEdit)).
+		Where("contacts.deleted_at IS NOT NULL").
+		Find(&contacts_db).Error
+	if err != nil {
+		return nil, err
+	}
+	return contacts_db, nil
+}
+
+// GetAddresses returns the deleted addresses of contacts that are not in the
+// trash themselves.
+func (r *trashRepository) GetAddresses(user_id, workspace_id uint) ([]addresses.Address, error) {
+	var addresses_db []addresses.Address
+	err := r.db.Unscoped().
+		Joins("JOIN contacts ON contacts.contact_id = addresses.contact_id AND contacts.deleted_at IS NULL").
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionEdit)).
+		Where("addresses.deleted_at IS NOT NULL").
+		Find(&addresses_db).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses_db, nil
+}
+
+func (r *trashRepository) FindContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	err := r.db.Unscoped().
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionEdit)).
+		Where("contacts.contact_id = ? AND contacts.deleted_at IS NOT NULL", id).
+		First(&contact).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// FindAddress finds a deleted address. The contact is loaded even when it
+// is in the trash too, so the caller can tell the two cases apart.
+func (r *trashRepository) FindAddress(id, user_id, workspace_id uint) (*addresses.Address, error) {
+	var address addresses.Address
+	err := r.db.Unscoped().
+		Preload("Contact", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Joins("JOIN contacts ON contacts.contact_id = addresses.contact_id").
+		Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionEdit)).
+		Where("addresses.address_id = ? AND addresses.deleted_at IS NOT NULL", id).
+		First(&address).Error
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// RestoreContact takes the contact out of the trash together with the
+// addresses that were deleted with it. Addresses deleted on their own
+// before the contact stay in the trash.
+func (r *trashRepository) RestoreContact(user_id uint, contact *contacts.Contact) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&contacts.Contact{}).Where("contact_id = ?", contact.ID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := undelete(tx, user_id, contact.ID, history.EntityContact, contact.ID); err != nil {
+			return err
+		}
+
+		var address_ids []uint
+		err := tx.Unscoped().Model(&addresses.Address{}).
+			Where("contact_id = ? AND deleted_at >= ?", contact.ID, contact.DeletedAt.Time).
+			Pluck("address_id", &address_ids).Error
+		if err != nil {
+			return err
+		}
+		if len(address_ids) == 0 {
+			return nil
+		}
+		if err := tx.Unscoped().Model(&addresses.Address{}).Where("address_id IN ?", address_ids).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		for _, address_id := range address_ids {
+			if err := undelete(tx, user_id, contact.ID, history.EntityAddress, address_id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RestoreAddress takes the address out of the trash. An address that was
+// primary comes back as a plain one if the contact has another primary of
+// its type by now.
+func (r *trashRepository) RestoreAddress(user_id uint, address *addresses.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		columns := map[string]any{"deleted_at": nil}
+		if address.IsPrimary {
+			var others int64
+			err := tx.Model(&addresses.Address{}).
+				Where("contact_id = ? AND type = ? AND is_primary = ?", address.ContactID, address.Type, true).
+				Count(&others).Error
+			if err != nil {
+				return err
+			}
+			if others > 0 {
+				columns["is_primary"] = false
+			}
+		}
+		if err := tx.Unscoped().Model(&addresses.Address{}).Where("address_id = ?", address.ID).Updates(columns).Error; err != nil {
+			return err
+		}
+		return undelete(tx, user_id, address.ContactID, history.EntityAddress, address.ID)
+	})
+}
+
+// PurgeContact deletes the contact for good. Its addresses, custom values,
+// activities, shares and history go with it through the foreign keys.
+func (r *trashRepository) PurgeContact(id uint) error {
+	return r.db.Unscoped().Where("contact_id = ?", id).Delete(&contacts.Contact{}).Error
+}
+
+func (r *trashRepository) PurgeAddress(id uint) error {
+	return r.db.Unscoped().Where("address_id = ?", id).Delete(&addresses.Address{}).Error
+}
+
+func (r *trashRepository) GetContactIdsDeletedBefore(before time.Time) ([]uint, error) {
+	var contact_ids []uint
+	err := r.db.Unscoped().Model(&contacts.Contact{}).
+		Where("deleted_at < ?", before).
+		Pluck("contact_id", &contact_ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return contact_ids, nil
+}
+
+// PurgeDeletedBefore permanently deletes the contacts and addresses that went
+// to the trash before the given time and returns how many were removed.
+func (r *trashRepository) PurgeDeletedBefore(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&addresses.Address{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+
+		result = tx.Unscoped().Where("deleted_at < ?", before).Delete(&contacts.Contact{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+		return nil
+	})
+	return purged, err
+}
+
+func undelete(tx *gorm.DB, user_id, contact_id uint, entity string, entity_id uint) error {
+	return history.Record(tx, &history.Revision{
+		ContactID: contact_id,
+		Entity:    entity,
+		EntityID:  entity_id,
+		Action:    history.ActionUndelete,
+		AuthorID:  user_id,
+	})
+}
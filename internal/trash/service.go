@@ -0,0 +1,211 @@
+package trash
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidType = errors.New("type must be contact or address")
+
+var ErrContactInTrash = errors.New("the contact of this address is in the trash, restore the contact instead")
+
+type TrashService interface {
+	GetTrash(user_id, workspace_id uint, item_type string, page, limit int) (*GetTrashResponse, error)
+	Restore(user_id, workspace_id uint, item_type string, id uint) error
+	Purge(user_id, workspace_id uint, item_type string, id uint) error
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+// AvatarRemover deletes the avatars of contacts before they are purged, so
+// their files do not stay behind in the BlobStore.
+type AvatarRemover interface {
+	DeleteContactAvatars(contact_ids []uint) error
+}
+
+type trashService struct {
+	repo      TrashRepository
+	avatars   AvatarRemover
+	retention time.Duration
+}
+
+// NewTrashService returns a service that keeps deleted records for
+// retention before PurgeExpired removes them.
+func NewTrashService(repo TrashRepository, avatars AvatarRemover, retention time.Duration) TrashService {
+	return &trashService{repo: repo, avatars: avatars, retention: retention}
+}
+
+// GetTrash lists deleted contacts and addresses, most recently deleted
+// first. item_type narrows the list to one kind; "" lists both.
+func (s *trashService) GetTrash(user_id, workspace_id uint, item_type string, page, limit int) (*GetTrashResponse, error) {
+	if item_type != "" && item_type != TypeContact && item_type != TypeAddress {
+		return nil, ErrInvalidType
+	}
+
+	var items []Item
+	if item_type != TypeAddress {
+		contacts_db, err := s.repo.GetContacts(user_id, workspace_id)
+		if err != nil {
+			return nil, err
+		}
+		for _, contact := range contacts_db {
+			items = append(items, s.item(TypeContact, contact.ID, contact.ID, contactName(contact), contact.DeletedAt))
+		}
+	}
+	if item_type != TypeContact {
+		addresses_db, err := s.repo.GetAddresses(user_id, workspace_id)
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range addresses_db {
+			items = append(items, s.item(TypeAddress, address.ID, address.ContactID, addressName(address), address.DeletedAt))
+		}
+	}
+
+	// Two tables feed the list, so it is sorted and paged here. The
+	// retention job keeps the trash small enough for that.
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		if items[i].Type != items[j].Type {
+			return items[i].Type == TypeContact
+		}
+		return items[i].ID > items[j].ID
+	})
+
+	total := len(items)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	totalPages := total / limit
+	if total%limit != 0 {
+		totalPages++
+	}
+
+	return &GetTrashResponse{
+		Data:       items[start:end],
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *trashService) Restore(user_id, workspace_id uint, item_type string, id uint) error {
+	switch item_type {
+	case TypeContact:
+		contact, err := s.findContact(id, user_id, workspace_id)
+		if err != nil {
+			return err
+		}
+		return s.repo.RestoreContact(user_id, contact)
+	case TypeAddress:
+		address, err := s.findAddress(id, user_id, workspace_id)
+		if err != nil {
+			return err
+		}
+		return s.repo.RestoreAddress(user_id, address)
+	default:
+		return ErrInvalidType
+	}
+}
+
+// Purge permanently deletes a record that is already in the trash.
+func (s *trashService) Purge(user_id, workspace_id uint, item_type string, id uint) error {
+	switch item_type {
+	case TypeContact:
+		contact, err := s.findContact(id, user_id, workspace_id)
+		if err != nil {
+			return err
+		}
+		if err := s.avatars.DeleteContactAvatars([]uint{contact.ID}); err != nil {
+			return err
+		}
+		return s.repo.PurgeContact(contact.ID)
+	case TypeAddress:
+		address, err := s.findAddress(id, user_id, workspace_id)
+		if err != nil {
+			return err
+		}
+		return s.repo.PurgeAddress(address.ID)
+	default:
+		return ErrInvalidType
+	}
+}
+
+// PurgeExpired permanently deletes what has been in the trash longer than
+// the retention.
+func (s *trashService) PurgeExpired(now time.Time) (int64, error) {
+	before := now.Add(-s.retention)
+	contact_ids, err := s.repo.GetContactIdsDeletedBefore(before)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.avatars.DeleteContactAvatars(contact_ids); err != nil {
+		return 0, err
+	}
+	return s.repo.PurgeDeletedBefore(before)
+}
+
+func (s *trashService) findContact(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	contact, err := s.repo.FindContact(id, user_id, workspace_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found in trash")
+		}
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (s *trashService) findAddress(id, user_id, workspace_id uint) (*addresses.Address, error) {
+	address, err := s.repo.FindAddress(id, user_id, workspace_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("address not found in trash")
+		}
+		return nil, err
+	}
+	if address.Contact.DeletedAt.Valid {
+		return nil, ErrContactInTrash
+	}
+	return address, nil
+}
+
+func (s *trashService) item(item_type string, id, contact_id uint, name string, deleted_at gorm.DeletedAt) Item {
+	return Item{
+		Type:      item_type,
+		ID:        id,
+		ContactID: contact_id,
+		Name:      name,
+		DeletedAt: deleted_at.Time,
+		ExpiresAt: deleted_at.Time.Add(s.retention),
+	}
+}
+
+func contactName(contact contacts.Contact) string {
+	return strings.TrimSpace(contact.FirstName + " " + contact.LastName)
+}
+
+func addressName(address addresses.Address) string {
+	var parts []string
+	for _, part := range []string{address.Street, address.City, address.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
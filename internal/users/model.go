@@ -12,6 +12,11 @@ type User struct {
     Email     string         `gorm:"type:varchar(255);not null;uniqueIndex" json:"email"`
     Password  string         `gorm:"type:varchar(255);not null" json:"-"`
     Token     string         `gorm:"type:varchar(255)" json:"-"`
+    Region    string         `gorm:"type:varchar(2);not null;default:'ID'" json:"region"`
+    Timezone  string         `gorm:"type:varchar(64);not null;default:'UTC'" json:"timezone"`
+    ActiveWorkspaceID *uint  `gorm:"index" json:"active_workspace_id"`
+    ContactSort  string      `gorm:"type:varchar(60);not null;default:''" json:"contact_sort"`
+    ContactOrder string      `gorm:"type:varchar(4);not null;default:''" json:"contact_order"`
     CreatedAt time.Time      `json:"created_at"`
     UpdatedAt time.Time      `json:"updated_at"`
     DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -33,12 +38,16 @@ type CreateUserRequest struct {
 type UpdateUserRequest struct {
 	Name  string `json:"name" binding:"omitempty,min=3,max=100"`
 	Email string `json:"email" binding:"omitempty,email"`
+	Region string `json:"region" binding:"omitempty,len=2"`
+	Timezone string `json:"timezone" binding:"omitempty,max=64"`
 }
 
 type UserResponse struct {
 	ID    uint   `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Region string `json:"region"`
+	Timezone string `json:"timezone"`
 	CreatedAt time.Time      `json:"created_at"`
     UpdatedAt time.Time      `json:"updated_at"`
 }
@@ -1,6 +1,10 @@
 package users
 
-import "gorm.io/gorm"
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
 
 type UserRepository interface {
 	GetUsers(page, limit int, search string) (*GetUsersResponse, error)
@@ -80,6 +84,12 @@ func (u *userRepository) UpdateUser(id uint, user UpdateUserRequest) error {
 	if user.Email != "" {
 		user_db.Email = user.Email
 	}
+	if user.Region != "" {
+		user_db.Region = strings.ToUpper(user.Region)
+	}
+	if user.Timezone != "" {
+		user_db.Timezone = user.Timezone
+	}
 
 	if err := u.db.Save(&user_db).Error; err != nil {
 		return err
@@ -97,6 +107,8 @@ func (u *userRepository) FindUserById(id uint) (*UserResponse, error) {
 		ID:    user.ID,
 		Name:  user.Name,
 		Email: user.Email,
+		Region: user.Region,
+		Timezone: user.Timezone,
 	}, nil
 }
 
@@ -2,7 +2,9 @@ package users
 
 import (
 	"errors"
+	"time"
 
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/phone"
 	"gorm.io/gorm"
 )
 
@@ -41,6 +43,14 @@ func (s *userService) CreateUser(user CreateUserRequest) (*CreateUserResonse, er
 }
 
 func (s *userService) UpdateUser(id uint, user UpdateUserRequest) error {
+	if user.Region != "" && !phone.IsSupportedRegion(user.Region) {
+		return errors.New("unsupported region")
+	}
+	if user.Timezone != "" {
+		if _, err := time.LoadLocation(user.Timezone); err != nil || user.Timezone == "Local" {
+			return errors.New("unknown timezone")
+		}
+	}
 
 	_, err := s.repo.FindUserById(id)
 	if err != nil {
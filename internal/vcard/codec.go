@@ -0,0 +1,291 @@
+package vcard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the octet limit from RFC 6350 section 3.2 after which
+// content lines are folded.
+const maxLineLength = 75
+
+// Encode writes the cards as a single vCard stream in the requested version.
+func Encode(cards []Card, version string) []byte {
+	var buf bytes.Buffer
+	for _, card := range cards {
+		writeLine(&buf, "BEGIN:VCARD")
+		writeLine(&buf, "VERSION:"+version)
+		writeLine(&buf, "FN:"+escapeText(displayName(card)))
+		writeLine(&buf, "N:"+joinComponents(card.LastName, card.FirstName, "", "", ""))
+
+		for _, email := range card.Emails {
+			if version == Version3 {
+				writeLine(&buf, "EMAIL;TYPE=INTERNET:"+escapeText(email))
+			} else {
+				writeLine(&buf, "EMAIL:"+escapeText(email))
+			}
+		}
+		for _, phone := range card.Phones {
+			if version == Version3 {
+				writeLine(&buf, "TEL;TYPE=CELL:"+escapeText(phone))
+			} else {
+				writeLine(&buf, "TEL;VALUE=uri;TYPE=cell:tel:"+escapeText(phone))
+			}
+		}
+		for _, address := range card.Addresses {
+			name := "ADR"
+			if address.Type != "" {
+				if version == Version3 {
+					name += ";TYPE=" + strings.ToUpper(address.Type)
+				} else {
+					name += ";TYPE=" + strings.ToLower(address.Type)
+				}
+			}
+			writeLine(&buf, name+":"+joinComponents("", "", address.Street, address.City, address.State, address.PostalCode, address.Country))
+		}
+
+		writeLine(&buf, "END:VCARD")
+	}
+	return buf.Bytes()
+}
+
+// Decode parses a stream containing one or more vCards. The returned slices
+// have the same length: errs[i] is non-nil when cards[i] could not be read.
+func Decode(data []byte) ([]Card, []error) {
+	var cards []Card
+	var errs []error
+
+	var current *Card
+	var cardErr error
+	for _, line := range unfold(data) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			if current != nil && cardErr == nil {
+				cardErr = fmt.Errorf("malformed line %q", line)
+			}
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			if current != nil {
+				cards = append(cards, *current)
+				errs = append(errs, errors.New("missing END:VCARD"))
+			}
+			current = &Card{}
+			cardErr = nil
+			continue
+		case current == nil:
+			continue
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			cards = append(cards, *current)
+			errs = append(errs, cardErr)
+			current = nil
+			continue
+		}
+
+		switch name {
+		case "VERSION":
+			if value != "2.1" && value != Version3 && value != Version4 {
+				cardErr = fmt.Errorf("unsupported vCard version %q", value)
+			}
+		case "FN":
+			current.FullName = unescapeText(value)
+		case "N":
+			parts := splitComponents(value)
+			if len(parts) > 0 {
+				current.LastName = parts[0]
+			}
+			if len(parts) > 1 {
+				current.FirstName = parts[1]
+			}
+			if len(parts) > 2 && parts[2] != "" {
+				current.FirstName = strings.TrimSpace(current.FirstName + " " + parts[2])
+			}
+		case "EMAIL":
+			if email := strings.TrimSpace(unescapeText(value)); email != "" {
+				current.Emails = append(current.Emails, email)
+			}
+		case "TEL":
+			phone := strings.TrimSpace(unescapeText(value))
+			phone = strings.TrimPrefix(phone, "tel:")
+			if phone != "" {
+				current.Phones = append(current.Phones, phone)
+			}
+		case "ADR":
+			parts := splitComponents(value)
+			for len(parts) < 7 {
+				parts = append(parts, "")
+			}
+			street := strings.TrimSpace(strings.Join(nonEmpty(parts[0], parts[1], parts[2]), ", "))
+			current.Addresses = append(current.Addresses, CardAddress{
+				Type:       firstType(params),
+				Street:     street,
+				City:       parts[3],
+				State:      parts[4],
+				PostalCode: parts[5],
+				Country:    parts[6],
+			})
+		}
+	}
+
+	if current != nil {
+		cards = append(cards, *current)
+		errs = append(errs, errors.New("missing END:VCARD"))
+	}
+
+	return cards, errs
+}
+
+func displayName(card Card) string {
+	if card.FullName != "" {
+		return card.FullName
+	}
+	return strings.TrimSpace(card.FirstName + " " + card.LastName)
+}
+
+func writeLine(buf *bytes.Buffer, line string) {
+	for len(line) > maxLineLength {
+		cut := maxLineLength
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func unfold(data []byte) []string {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitProperty breaks a content line into its upper-cased name (without any
+// group prefix), its parameters and its raw value.
+func splitProperty(line string) (string, []string, string, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	head := strings.Split(line[:colon], ";")
+	name := strings.ToUpper(strings.TrimSpace(head[0]))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	return name, head[1:], line[colon+1:], true
+}
+
+func firstType(params []string) string {
+	for _, param := range params {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			// vCard 2.1 allows bare type parameters such as ";HOME".
+			value = key
+		} else if !strings.EqualFold(key, "TYPE") {
+			continue
+		}
+		for _, t := range strings.Split(strings.Trim(value, `"`), ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && t != "pref" {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func unescapeText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		if escaped {
+			if r == 'n' || r == 'N' {
+				b.WriteRune('\n')
+			} else {
+				b.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func joinComponents(components ...string) string {
+	escaped := make([]string, len(components))
+	for i, component := range components {
+		escaped[i] = escapeText(component)
+	}
+	return strings.Join(escaped, ";")
+}
+
+// splitComponents splits a structured value on unescaped semicolons and
+// unescapes each component.
+func splitComponents(value string) []string {
+	var parts []string
+	start := 0
+	escaped := false
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			parts = append(parts, strings.TrimSpace(unescapeText(value[start:i])))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(unescapeText(value[start:])))
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, strings.TrimSpace(value))
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package vcard
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize caps the size of an uploaded vCard file.
+const maxImportSize = 5 << 20
+
+type VCardHandler interface {
+	ExportContacts(c *gin.Context)
+	ExportContact(c *gin.Context)
+	ImportContacts(c *gin.Context)
+}
+
+type vcardHandler struct {
+	svc VCardService
+}
+
+func NewVCardHandler(svc VCardService) VCardHandler {
+	return &vcardHandler{svc: svc}
+}
+
+func (h *vcardHandler) ExportContacts(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	version := c.DefaultQuery("version", Version3)
+	if version != Version3 && version != Version4 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+	search := c.DefaultQuery("search", "")
+
+	data, err := h.svc.ExportContacts(user_id.(uint), c.GetUint("workspace_id"), search, version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="contacts.vcf"`)
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", data)
+}
+
+func (h *vcardHandler) ExportContact(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id := c.Param("id")
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	version := c.DefaultQuery("version", Version3)
+	if version != Version3 && version != Version4 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	data, err := h.svc.ExportContact(uint(intId), user_id.(uint), c.GetUint("workspace_id"), version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="contact-`+id+`.vcf"`)
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", data)
+}
+
+func (h *vcardHandler) ImportContacts(c *gin.Context) {
+	user_id, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer opened.Close()
+		body = opened
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.ImportContacts(user_id.(uint), c.GetUint("workspace_id"), data)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, contacts.ErrForbidden) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contacts imported",
+		"data":    response,
+	})
+}
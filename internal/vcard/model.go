@@ -0,0 +1,39 @@
+package vcard
+
+const (
+	Version3 = "3.0"
+	Version4 = "4.0"
+)
+
+type Card struct {
+	FirstName string
+	LastName  string
+	FullName  string
+	Emails    []string
+	Phones    []string
+	Addresses []CardAddress
+}
+
+type CardAddress struct {
+	Type       string
+	Street     string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+type ImportCardResult struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	ContactID uint   `json:"contact_id,omitempty"`
+	Addresses int    `json:"addresses"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ImportResponse struct {
+	Total    int                `json:"total"`
+	Imported int                `json:"imported"`
+	Failed   int                `json:"failed"`
+	Results  []ImportCardResult `json:"results"`
+}
@@ -0,0 +1,113 @@
+package vcard
+
+import (
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/history"
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+)
+
+type VCardRepository interface {
+	GetContacts(user_id, workspace_id uint, search string) ([]contacts.Contact, error)
+	FindContactById(id, user_id, workspace_id uint) (*contacts.Contact, error)
+	GetAddressesByContactIds(contact_ids []uint) ([]addresses.Address, error)
+	CreateContact(contact *contacts.Contact, address_list []addresses.Address) error
+	FindUserRegion(user_id uint) (string, error)
+	GetCustomFields(user_id uint) ([]customfields.Field, error)
+	FindWorkspaceRole(workspace_id, user_id uint) (string, error)
+}
+
+type vcardRepository struct {
+	db *gorm.DB
+}
+
+func NewVCardRepository(db *gorm.DB) VCardRepository {
+	return &vcardRepository{db: db}
+}
+
+func (r *vcardRepository) GetContacts(user_id, workspace_id uint, search string) ([]contacts.Contact, error) {
+	var contact_list []contacts.Contact
+
+	query := r.db.Model(&contacts.Contact{}).Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView))
+	if search != "" {
+		query = query.Where("first_name LIKE ? OR last_name LIKE ? OR email LIKE ? OR phone LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	if err := query.Order("contact_id").Find(&contact_list).Error; err != nil {
+		return nil, err
+	}
+	return contact_list, nil
+}
+
+func (r *vcardRepository) FindContactById(id, user_id, workspace_id uint) (*contacts.Contact, error) {
+	var contact contacts.Contact
+	if err := r.db.Scopes(contacts.InWorkspace(user_id, workspace_id, contacts.PermissionView)).Where("contact_id = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *vcardRepository) GetAddressesByContactIds(contact_ids []uint) ([]addresses.Address, error) {
+	var address_list []addresses.Address
+	if len(contact_ids) == 0 {
+		return address_list, nil
+	}
+
+	if err := r.db.Where("contact_id IN ?", contact_ids).Order("address_id").Find(&address_list).Error; err != nil {
+		return nil, err
+	}
+	return address_list, nil
+}
+
+// CreateContact stores the contact and its addresses in a single transaction
+// so a card is either imported completely or not at all. The history of the
+// contact starts with their creation, by the importing user.
+func (r *vcardRepository) CreateContact(contact *contacts.Contact, address_list []addresses.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contact).Error; err != nil {
+			return err
+		}
+		if err := contacts.RecordChanges(tx, contact.ID, contact.UserID, history.ActionCreate, nil); err != nil {
+			return err
+		}
+		for i := range address_list {
+			address_list[i].ContactID = contact.ID
+			if err := tx.Create(&address_list[i]).Error; err != nil {
+				return err
+			}
+			if err := addresses.Record(tx, contact.UserID, &address_list[i], history.ActionCreate, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *vcardRepository) FindUserRegion(user_id uint) (string, error) {
+	var user users.User
+	if err := r.db.Select("region").Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return "", err
+	}
+	return user.Region, nil
+}
+
+func (r *vcardRepository) GetCustomFields(user_id uint) ([]customfields.Field, error) {
+	var fields []customfields.Field
+	if err := r.db.Where("user_id = ?", user_id).Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// FindWorkspaceRole returns user_id's role in the workspace, or "" when
+// they are not a member.
+func (r *vcardRepository) FindWorkspaceRole(workspace_id, user_id uint) (string, error) {
+	var role string
+	err := r.db.Table("workspace_members").Select("role").
+		Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).
+		Limit(1).Scan(&role).Error
+	return role, err
+}
@@ -0,0 +1,271 @@
+package vcard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/addresses"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/country"
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/geo"
+	"github.com/DioSaputra28/belajar-gin-1/internal/contacts"
+	"github.com/DioSaputra28/belajar-gin-1/internal/customfields"
+	"github.com/DioSaputra28/belajar-gin-1/internal/workspaces"
+	"github.com/gin-gonic/gin/binding"
+
+	"gorm.io/gorm"
+)
+
+type VCardService interface {
+	ExportContacts(user_id, workspace_id uint, search, version string) ([]byte, error)
+	ExportContact(id, user_id, workspace_id uint, version string) ([]byte, error)
+	ImportContacts(user_id, workspace_id uint, data []byte) (*ImportResponse, error)
+}
+
+type vcardService struct {
+	repo     VCardRepository
+	geocoder geo.Geocoder
+}
+
+// NewVCardService returns the vCard service. Imported addresses are located
+// with geocoder, like the ones saved through the address endpoints.
+func NewVCardService(repo VCardRepository, geocoder geo.Geocoder) VCardService {
+	return &vcardService{repo: repo, geocoder: geocoder}
+}
+
+func (s *vcardService) ExportContacts(user_id, workspace_id uint, search, version string) ([]byte, error) {
+	contact_list, err := s.repo.GetContacts(user_id, workspace_id, search)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.toCards(contact_list)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(cards, version), nil
+}
+
+func (s *vcardService) ExportContact(id, user_id, workspace_id uint, version string) ([]byte, error) {
+	contact_db, err := s.repo.FindContactById(id, user_id, workspace_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+
+	cards, err := s.toCards([]contacts.Contact{*contact_db})
+	if err != nil {
+		return nil, err
+	}
+	return Encode(cards, version), nil
+}
+
+func (s *vcardService) ImportContacts(user_id, workspace_id uint, data []byte) (*ImportResponse, error) {
+	cards, errs := Decode(data)
+	if len(cards) == 0 {
+		return nil, errors.New("no vCard found in request body")
+	}
+
+	if err := s.checkWorkspace(user_id, workspace_id); err != nil {
+		return nil, err
+	}
+
+	region, err := s.repo.FindUserRegion(user_id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	fields, err := s.customFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportResponse{Total: len(cards), Results: []ImportCardResult{}}
+	for i, card := range cards {
+		result := ImportCardResult{Index: i + 1, Name: displayName(card)}
+
+		err := errs[i]
+		if err == nil {
+			result.ContactID, result.Addresses, err = s.importCard(user_id, workspace_id, region, fields, card)
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			response.Imported++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// importCard creates the contact of one card. Cards carry no custom fields,
+// so a card fails while the user has required fields.
+func (s *vcardService) importCard(user_id, workspace_id uint, region string, fields map[string]customfields.Field, card Card) (uint, int, error) {
+	first_name, last_name := card.FirstName, card.LastName
+	if first_name == "" && last_name == "" && card.FullName != "" {
+		first_name, last_name = splitFullName(card.FullName)
+	}
+
+	request := contacts.CreateContactRequest{
+		FirstName: first_name,
+		LastName:  last_name,
+	}
+	if len(card.Emails) > 0 {
+		request.Email = card.Emails[0]
+	}
+	if len(card.Phones) > 0 {
+		request.Phone = card.Phones[0]
+	}
+	if err := binding.Validator.ValidateStruct(request); err != nil {
+		return 0, 0, err
+	}
+
+	address_list := make([]addresses.Address, 0, len(card.Addresses))
+	for i, address := range card.Addresses {
+		if address.Country == "" {
+			return 0, 0, fmt.Errorf("address %d: country is required", i+1)
+		}
+		update := addresses.UpdateAddressRequest{
+			Street:     address.Street,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		}
+		if err := binding.Validator.ValidateStruct(update); err != nil {
+			return 0, 0, fmt.Errorf("address %d: %w", i+1, err)
+		}
+		address_db := addresses.Address{
+			Street:     address.Street,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Type:       addressType(address.Type),
+		}
+		if err := addresses.Normalize(&address_db); err != nil {
+			return 0, 0, fmt.Errorf("address %d: %w", i+1, err)
+		}
+		addresses.Geocode(s.geocoder, &address_db)
+		address_list = append(address_list, address_db)
+	}
+
+	contact := contacts.Contact{
+		UserID:    user_id,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		Email:     request.Email,
+		Phone:     request.Phone,
+	}
+	if workspace_id != workspaces.Personal {
+		contact.WorkspaceID = &workspace_id
+	}
+	if err := contacts.NormalizePhone(&contact, region); err != nil {
+		return 0, 0, err
+	}
+	if err := contacts.ParseCustomFields(&contact, fields, true); err != nil {
+		return 0, 0, err
+	}
+	if err := s.repo.CreateContact(&contact, address_list); err != nil {
+		return 0, 0, err
+	}
+	return contact.ID, len(address_list), nil
+}
+
+// checkWorkspace makes sure user_id may add contacts to the workspace the
+func (s *vcardService) customFields(user_id uint) (map[string]customfields.Field, error) {
+	fields, err := s.repo.GetCustomFields(user_id)
+	if err != nil {
+		return nil, err
+	}
+	by_key := make(map[string]customfields.Field, len(fields))
+	for _, field := range fields {
+		by_key[field.Key] = field
+	}
+	return by_key, nil
+}
+
+// cards are imported into.
+func (s *vcardService) checkWorkspace(user_id, workspace_id uint) error {
+	if workspace_id == workspaces.Personal {
+		return nil
+	}
+	role, err := s.repo.FindWorkspaceRole(workspace_id, user_id)
+	if err != nil {
+		return err
+	}
+	if !contacts.CanEdit(role) {
+		return contacts.ErrForbidden
+	}
+	return nil
+}
+
+func (s *vcardService) toCards(contact_list []contacts.Contact) ([]Card, error) {
+	contact_ids := make([]uint, len(contact_list))
+	for i, contact := range contact_list {
+		contact_ids[i] = contact.ID
+	}
+
+	address_list, err := s.repo.GetAddressesByContactIds(contact_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	by_contact := make(map[uint][]CardAddress)
+	for _, address := range address_list {
+		card_type := ""
+		if address.Type != addresses.TypeOther {
+			card_type = address.Type
+		}
+		by_contact[address.ContactID] = append(by_contact[address.ContactID], CardAddress{
+			Type:       card_type,
+			Street:     address.Street,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    country.Name(address.Country),
+		})
+	}
+
+	cards := make([]Card, 0, len(contact_list))
+	for _, contact := range contact_list {
+		card := Card{
+			FirstName: contact.FirstName,
+			LastName:  contact.LastName,
+			Addresses: by_contact[contact.ID],
+		}
+		if contact.Email != "" {
+			card.Emails = []string{contact.Email}
+		}
+		if contact.PhoneE164 != "" {
+			card.Phones = []string{contact.PhoneE164}
+		} else if contact.Phone != "" {
+			card.Phones = []string{contact.Phone}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+func splitFullName(full_name string) (string, string) {
+	full_name = strings.TrimSpace(full_name)
+	if i := strings.LastIndex(full_name, " "); i > 0 {
+		return full_name[:i], strings.TrimSpace(full_name[i+1:])
+	}
+	return full_name, ""
+}
+
+// addressType maps a vCard ADR type to an address type. vCard has no
+// billing or shipping; anything but home and work becomes other.
+func addressType(card_type string) string {
+	switch card_type {
+	case addresses.TypeHome, addresses.TypeWork:
+		return card_type
+	default:
+		return addresses.TypeOther
+	}
+}
@@ -0,0 +1,100 @@
+package workspaces
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+)
+
+// Personal is the workspace id of a user's own address book. Contacts in it
+// have no workspace_id.
+const Personal uint = 0
+
+// Header selects the active workspace for a single request. Without it the
+// workspace stored with the user's session is used.
+const Header = "X-Workspace-ID"
+
+// Member roles, from most to least privileged. Owners and admins manage
+// members and invitations; viewers can only read contacts.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// InvitationTTL is how long an invitation can be accepted.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// Workspace is an organization whose members share one address book.
+type Workspace struct {
+	ID        uint      `gorm:"column:workspace_id;primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	CreatedBy uint      `gorm:"not null;index" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+type Member struct {
+	WorkspaceID uint       `gorm:"primaryKey" json:"workspace_id"`
+	UserID      uint       `gorm:"primaryKey;index" json:"user_id"`
+	User        users.User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	Role        string     `gorm:"type:varchar(10);not null" json:"role"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (Member) TableName() string {
+	return "workspace_members"
+}
+
+type Invitation struct {
+	ID          uint       `gorm:"column:invitation_id;primaryKey" json:"id"`
+	WorkspaceID uint       `gorm:"not null;index" json:"workspace_id"`
+	Workspace   Workspace  `gorm:"foreignKey:WorkspaceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"workspace"`
+	Email       string     `gorm:"type:varchar(255);not null;index" json:"email"`
+	Role        string     `gorm:"type:varchar(10);not null" json:"role"`
+	Token       string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"token"`
+	InvitedBy   uint       `gorm:"not null" json:"invited_by"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (Invitation) TableName() string {
+	return "workspace_invitations"
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type InviteRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=admin member viewer"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member viewer"`
+}
+
+// SwitchWorkspaceRequest stores the workspace used when a request has no
+// X-Workspace-ID header. A workspace_id of 0 selects the personal workspace.
+type SwitchWorkspaceRequest struct {
+	WorkspaceID uint `json:"workspace_id"`
+}
+
+type WorkspaceResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
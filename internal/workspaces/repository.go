@@ -0,0 +1,187 @@
+package workspaces
+
+import (
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/users"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceRepository interface {
+	GetWorkspaces(user_id uint) ([]WorkspaceResponse, error)
+	FindWorkspaceById(id uint) (*Workspace, error)
+	CreateWorkspace(workspace *Workspace) error
+	UpdateWorkspace(workspace *Workspace) error
+	FindUserById(user_id uint) (*users.User, error)
+	SetActiveWorkspace(user_id uint, workspace_id *uint) error
+	FindMember(workspace_id, user_id uint) (*Member, error)
+	GetMembers(workspace_id uint) ([]Member, error)
+	CountOwners(workspace_id uint) (int64, error)
+	UpdateMemberRole(workspace_id, user_id uint, role string) error
+	DeleteMember(workspace_id, user_id uint) error
+	CreateInvitation(invitation *Invitation) error
+	GetInvitations(workspace_id uint) ([]Invitation, error)
+	GetInvitationsByEmail(email string) ([]Invitation, error)
+	FindInvitationById(id, workspace_id uint) (*Invitation, error)
+	FindInvitationByToken(token string) (*Invitation, error)
+	DeleteInvitation(id uint) error
+	AcceptInvitation(invitation *Invitation, user_id uint) error
+}
+
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+func (r *workspaceRepository) GetWorkspaces(user_id uint) ([]WorkspaceResponse, error) {
+	var workspace_list []WorkspaceResponse
+	err := r.db.Table("workspaces").
+		Select("workspaces.workspace_id AS id, workspaces.name, workspace_members.role, workspaces.created_at").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.workspace_id").
+		Where("workspace_members.user_id = ?", user_id).
+		Order("workspaces.name").
+		Scan(&workspace_list).Error
+	if err != nil {
+		return nil, err
+	}
+	return workspace_list, nil
+}
+
+func (r *workspaceRepository) FindWorkspaceById(id uint) (*Workspace, error) {
+	var workspace Workspace
+	if err := r.db.Where("workspace_id = ?", id).First(&workspace).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// CreateWorkspace stores the workspace and makes its creator the first owner.
+func (r *workspaceRepository) CreateWorkspace(workspace *Workspace) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		member := Member{WorkspaceID: workspace.ID, UserID: workspace.CreatedBy, Role: RoleOwner}
+		return tx.Omit("User").Create(&member).Error
+	})
+}
+
+func (r *workspaceRepository) UpdateWorkspace(workspace *Workspace) error {
+	return r.db.Model(workspace).Update("name", workspace.Name).Error
+}
+
+func (r *workspaceRepository) FindUserById(user_id uint) (*users.User, error) {
+	var user users.User
+	if err := r.db.Where("user_id = ?", user_id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *workspaceRepository) SetActiveWorkspace(user_id uint, workspace_id *uint) error {
+	return r.db.Model(&users.User{}).Where("user_id = ?", user_id).Update("active_workspace_id", workspace_id).Error
+}
+
+func (r *workspaceRepository) FindMember(workspace_id, user_id uint) (*Member, error) {
+	var member Member
+	if err := r.db.Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspace_id uint) ([]Member, error) {
+	var member_list []Member
+	if err := r.db.Preload("User").Where("workspace_id = ?", workspace_id).Order("created_at").Find(&member_list).Error; err != nil {
+		return nil, err
+	}
+	return member_list, nil
+}
+
+func (r *workspaceRepository) CountOwners(workspace_id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&Member{}).Where("workspace_id = ? AND role = ?", workspace_id, RoleOwner).Count(&count).Error
+	return count, err
+}
+
+func (r *workspaceRepository) UpdateMemberRole(workspace_id, user_id uint, role string) error {
+	return r.db.Model(&Member{}).Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).Update("role", role).Error
+}
+
+func (r *workspaceRepository) DeleteMember(workspace_id, user_id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspace_id, user_id).Delete(&Member{}).Error; err != nil {
+			return err
+		}
+		// A removed member falls back to the personal workspace.
+		return tx.Model(&users.User{}).
+			Where("user_id = ? AND active_workspace_id = ?", user_id, workspace_id).
+			Update("active_workspace_id", nil).Error
+	})
+}
+
+func (r *workspaceRepository) CreateInvitation(invitation *Invitation) error {
+	return r.db.Omit("Workspace").Create(invitation).Error
+}
+
+func (r *workspaceRepository) GetInvitations(workspace_id uint) ([]Invitation, error) {
+	var invitation_list []Invitation
+	err := r.db.Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", workspace_id, time.Now()).
+		Order("created_at DESC").
+		Find(&invitation_list).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitation_list, nil
+}
+
+func (r *workspaceRepository) GetInvitationsByEmail(email string) ([]Invitation, error) {
+	var invitation_list []Invitation
+	err := r.db.Preload("Workspace").
+		Where("email = ? AND accepted_at IS NULL AND expires_at > ?", email, time.Now()).
+		Order("created_at DESC").
+		Find(&invitation_list).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitation_list, nil
+}
+
+func (r *workspaceRepository) FindInvitationById(id, workspace_id uint) (*Invitation, error) {
+	var invitation Invitation
+	if err := r.db.Where("invitation_id = ? AND workspace_id = ?", id, workspace_id).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *workspaceRepository) FindInvitationByToken(token string) (*Invitation, error) {
+	var invitation Invitation
+	if err := r.db.Preload("Workspace").Where("token = ?", token).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *workspaceRepository) DeleteInvitation(id uint) error {
+	return r.db.Where("invitation_id = ?", id).Delete(&Invitation{}).Error
+}
+
+// AcceptInvitation adds the user to the workspace and marks the invitation
+// as used. A user who is already a member keeps their current role.
+func (r *workspaceRepository) AcceptInvitation(invitation *Invitation, user_id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		member := Member{WorkspaceID: invitation.WorkspaceID, UserID: user_id, Role: invitation.Role}
+		if err := tx.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(&member).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		invitation.AcceptedAt = &now
+		return tx.Model(&Invitation{}).Where("invitation_id = ?", invitation.ID).Update("accepted_at", now).Error
+	})
+}
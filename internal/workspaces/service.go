@@ -0,0 +1,348 @@
+package workspaces
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DioSaputra28/belajar-gin-1/internal/common/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrForbidden         = errors.New("you do not have permission to manage this workspace")
+	ErrNotMember         = errors.New("you are not a member of this workspace")
+	ErrLastOwner         = errors.New("a workspace needs at least one owner")
+	ErrInvitationExpired = errors.New("invitation has expired")
+	ErrInvitationEmail   = errors.New("invitation was sent to a different email")
+)
+
+type WorkspaceService interface {
+	GetWorkspaces(user_id uint) ([]WorkspaceResponse, error)
+	FindWorkspaceById(id, user_id uint) (*WorkspaceResponse, error)
+	CreateWorkspace(user_id uint, request CreateWorkspaceRequest) (*WorkspaceResponse, error)
+	UpdateWorkspace(id, user_id uint, request UpdateWorkspaceRequest) (*WorkspaceResponse, error)
+	SwitchWorkspace(user_id uint, request SwitchWorkspaceRequest) error
+	ResolveWorkspace(user_id uint, header string) (uint, error)
+	GetMembers(id, user_id uint) ([]Member, error)
+	UpdateMember(id, user_id, member_id uint, request UpdateMemberRequest) error
+	RemoveMember(id, user_id, member_id uint) error
+	CreateInvitation(id, user_id uint, request InviteRequest) (*Invitation, error)
+	GetInvitations(id, user_id uint) ([]Invitation, error)
+	DeleteInvitation(id, user_id, invitation_id uint) error
+	GetMyInvitations(user_id uint) ([]Invitation, error)
+	AcceptInvitation(token string, user_id uint) (*WorkspaceResponse, error)
+}
+
+type workspaceService struct {
+	repo WorkspaceRepository
+}
+
+func NewWorkspaceService(repo WorkspaceRepository) WorkspaceService {
+	return &workspaceService{repo: repo}
+}
+
+func (s *workspaceService) GetWorkspaces(user_id uint) ([]WorkspaceResponse, error) {
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		return nil, err
+	}
+	workspace_list, err := s.repo.GetWorkspaces(user_id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range workspace_list {
+		workspace_list[i].Active = user.ActiveWorkspaceID != nil && *user.ActiveWorkspaceID == workspace_list[i].ID
+	}
+	return workspace_list, nil
+}
+
+func (s *workspaceService) FindWorkspaceById(id, user_id uint) (*WorkspaceResponse, error) {
+	member, err := s.member(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+	return s.response(id, user_id, member.Role)
+}
+
+func (s *workspaceService) CreateWorkspace(user_id uint, request CreateWorkspaceRequest) (*WorkspaceResponse, error) {
+	workspace := Workspace{Name: request.Name, CreatedBy: user_id}
+	if err := s.repo.CreateWorkspace(&workspace); err != nil {
+		return nil, err
+	}
+	return &WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Role:      RoleOwner,
+		CreatedAt: workspace.CreatedAt,
+	}, nil
+}
+
+func (s *workspaceService) UpdateWorkspace(id, user_id uint, request UpdateWorkspaceRequest) (*WorkspaceResponse, error) {
+	member, err := s.manager(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.repo.FindWorkspaceById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Name = request.Name
+	if err := s.repo.UpdateWorkspace(workspace); err != nil {
+		return nil, err
+	}
+	return s.response(id, user_id, member.Role)
+}
+
+func (s *workspaceService) SwitchWorkspace(user_id uint, request SwitchWorkspaceRequest) error {
+	if request.WorkspaceID == Personal {
+		return s.repo.SetActiveWorkspace(user_id, nil)
+	}
+	if _, err := s.member(request.WorkspaceID, user_id); err != nil {
+		return err
+	}
+	return s.repo.SetActiveWorkspace(user_id, &request.WorkspaceID)
+}
+
+// ResolveWorkspace returns the workspace a request works in. The header wins
+// over the stored choice; "0" or an empty stored choice means the personal
+// workspace. A stored workspace the user has since left falls back to the
+// personal one instead of failing every request.
+func (s *workspaceService) ResolveWorkspace(user_id uint, header string) (uint, error) {
+	if header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			return 0, ErrNotMember
+		}
+		if uint(id) == Personal {
+			return Personal, nil
+		}
+		if _, err := s.member(uint(id), user_id); err != nil {
+			return 0, err
+		}
+		return uint(id), nil
+	}
+
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		return 0, err
+	}
+	if user.ActiveWorkspaceID == nil {
+		return Personal, nil
+	}
+	if _, err := s.member(*user.ActiveWorkspaceID, user_id); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			return Personal, nil
+		}
+		return 0, err
+	}
+	return *user.ActiveWorkspaceID, nil
+}
+
+func (s *workspaceService) GetMembers(id, user_id uint) ([]Member, error) {
+	if _, err := s.member(id, user_id); err != nil {
+		return nil, err
+	}
+	member_list, err := s.repo.GetMembers(id)
+	if err != nil {
+		return nil, err
+	}
+	return member_list, nil
+}
+
+func (s *workspaceService) UpdateMember(id, user_id, member_id uint, request UpdateMemberRequest) error {
+	caller, err := s.manager(id, user_id)
+	if err != nil {
+		return err
+	}
+	target, err := s.repo.FindMember(id, member_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("member not found")
+		}
+		return err
+	}
+	// Only owners hand out or take away ownership.
+	if (request.Role == RoleOwner || target.Role == RoleOwner) && caller.Role != RoleOwner {
+		return ErrForbidden
+	}
+	if target.Role == RoleOwner && request.Role != RoleOwner {
+		if err := s.checkLastOwner(id); err != nil {
+			return err
+		}
+	}
+	return s.repo.UpdateMemberRole(id, member_id, request.Role)
+}
+
+func (s *workspaceService) RemoveMember(id, user_id, member_id uint) error {
+	caller, err := s.member(id, user_id)
+	if err != nil {
+		return err
+	}
+	target := caller
+	// Every member may leave; removing someone else takes an admin, and
+	// removing an owner takes an owner.
+	if member_id != user_id {
+		if !canManage(caller.Role) {
+			return ErrForbidden
+		}
+		target, err = s.repo.FindMember(id, member_id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("member not found")
+			}
+			return err
+		}
+		if target.Role == RoleOwner && caller.Role != RoleOwner {
+			return ErrForbidden
+		}
+	}
+	if target.Role == RoleOwner {
+		if err := s.checkLastOwner(id); err != nil {
+			return err
+		}
+	}
+	return s.repo.DeleteMember(id, member_id)
+}
+
+func (s *workspaceService) CreateInvitation(id, user_id uint, request InviteRequest) (*Invitation, error) {
+	if _, err := s.manager(id, user_id); err != nil {
+		return nil, err
+	}
+
+	invitation := Invitation{
+		WorkspaceID: id,
+		Email:       strings.ToLower(request.Email),
+		Role:        request.Role,
+		Token:       utils.GenerateToken(),
+		InvitedBy:   user_id,
+		ExpiresAt:   time.Now().Add(InvitationTTL),
+	}
+	if err := s.repo.CreateInvitation(&invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (s *workspaceService) GetInvitations(id, user_id uint) ([]Invitation, error) {
+	if _, err := s.manager(id, user_id); err != nil {
+		return nil, err
+	}
+	invitation_list, err := s.repo.GetInvitations(id)
+	if err != nil {
+		return nil, err
+	}
+	return invitation_list, nil
+}
+
+func (s *workspaceService) DeleteInvitation(id, user_id, invitation_id uint) error {
+	if _, err := s.manager(id, user_id); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindInvitationById(invitation_id, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invitation not found")
+		}
+		return err
+	}
+	return s.repo.DeleteInvitation(invitation_id)
+}
+
+func (s *workspaceService) GetMyInvitations(user_id uint) ([]Invitation, error) {
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		return nil, err
+	}
+	invitation_list, err := s.repo.GetInvitationsByEmail(strings.ToLower(user.Email))
+	if err != nil {
+		return nil, err
+	}
+	return invitation_list, nil
+}
+
+func (s *workspaceService) AcceptInvitation(token string, user_id uint) (*WorkspaceResponse, error) {
+	invitation, err := s.repo.FindInvitationByToken(token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, err
+	}
+	if invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmail
+	}
+
+	if err := s.repo.AcceptInvitation(invitation, user_id); err != nil {
+		return nil, err
+	}
+	member, err := s.member(invitation.WorkspaceID, user_id)
+	if err != nil {
+		return nil, err
+	}
+	return s.response(invitation.WorkspaceID, user_id, member.Role)
+}
+
+func (s *workspaceService) member(id, user_id uint) (*Member, error) {
+	member, err := s.repo.FindMember(id, user_id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotMember
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// manager returns the caller's membership if they may manage the workspace.
+func (s *workspaceService) manager(id, user_id uint) (*Member, error) {
+	member, err := s.member(id, user_id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(member.Role) {
+		return nil, ErrForbidden
+	}
+	return member, nil
+}
+
+func (s *workspaceService) checkLastOwner(id uint) error {
+	owners, err := s.repo.CountOwners(id)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func (s *workspaceService) response(id, user_id uint, role string) (*WorkspaceResponse, error) {
+	workspace, err := s.repo.FindWorkspaceById(id)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindUserById(user_id)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Role:      role,
+		Active:    user.ActiveWorkspaceID != nil && *user.ActiveWorkspaceID == id,
+		CreatedAt: workspace.CreatedAt,
+	}, nil
+}
+
+func canManage(role string) bool {
+	return role == RoleOwner || role == RoleAdmin
+}